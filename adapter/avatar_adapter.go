@@ -7,14 +7,17 @@ import (
 
 type AvatarAdapter interface {
 	GetByID(id string) (*models.Avatar, error)
+	// GetByIDs idsのアバターを返す。見つからないIDは結果に含まれない。順序は保証しない
+	GetByIDs(ids []string) ([]models.Avatar, error)
 	GetByUserID(userID string) (*models.Avatar, error)
 	Create(avatar models.Avatar) (*models.Avatar, error)
 	Update(avatar models.Avatar) (*models.Avatar, error)
-	GetOppositeGenderAvatars(currentUserGender string) ([]models.Avatar, error)
+	// GetAvatarsByOwnerGenders 持ち主の性別がgendersに含まれるアバターを返す（excludeUserID自身のものは除く）
+	GetAvatarsByOwnerGenders(excludeUserID string, genders []string) ([]models.Avatar, error)
 	GetUserAvatarRelation(userID, avatarID string) (models.UserAvatarRelation, error)
 	GetUserAvatarRelationsByUserID(userID string) ([]models.UserAvatarRelation, error)
-	CreateUserAvatarRelation(relation models.UserAvatarRelation) error
-	UpdateUserAvatarRelation(relation models.UserAvatarRelation) error
+	// GetUserAvatarRelationsByAvatarIDs userIDとavatarIDsの各アバターとの関係のうち、存在するものを返す
+	GetUserAvatarRelationsByAvatarIDs(userID string, avatarIDs []string) ([]models.UserAvatarRelation, error)
 }
 type avatarAdapter struct {
 	db *gorm.DB
@@ -32,6 +35,17 @@ func (a *avatarAdapter) GetByID(id string) (*models.Avatar, error) {
 	return &avatar, nil
 }
 
+func (a *avatarAdapter) GetByIDs(ids []string) ([]models.Avatar, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var avatars []models.Avatar
+	if err := a.db.Where("id IN ?", ids).Find(&avatars).Error; err != nil {
+		return nil, err
+	}
+	return avatars, nil
+}
+
 func (a *avatarAdapter) GetByUserID(userID string) (*models.Avatar, error) {
 	var avatar models.Avatar
 	if err := a.db.Where("user_id = ?", userID).First(&avatar).Error; err != nil {
@@ -54,20 +68,11 @@ func (a *avatarAdapter) Update(avatar models.Avatar) (*models.Avatar, error) {
 	return &avatar, nil
 }
 
-func (a *avatarAdapter) GetOppositeGenderAvatars(currentUserGender string) ([]models.Avatar, error) {
+func (a *avatarAdapter) GetAvatarsByOwnerGenders(excludeUserID string, genders []string) ([]models.Avatar, error) {
 	var avatars []models.Avatar
-	var query *gorm.DB
-
-	targetGender := map[string]string{"male": "female", "female": "male"}[currentUserGender]
-
-	query = a.db.Joins("JOIN users ON avatars.user_id = users.id")
-	if targetGender != "" {
-		query = query.Where("users.gender = ?", targetGender)
-	} else {
-		query = query.Where("users.gender != ?", currentUserGender)
-	}
-
-	if err := query.Find(&avatars).Error; err != nil {
+	if err := a.db.Joins("JOIN users ON avatars.user_id = users.id").
+		Where("users.id <> ? AND users.gender IN ?", excludeUserID, genders).
+		Find(&avatars).Error; err != nil {
 		return nil, err
 	}
 	return avatars, nil
@@ -89,10 +94,13 @@ func (a *avatarAdapter) GetUserAvatarRelationsByUserID(userID string) ([]models.
 	return relations, nil
 }
 
-func (a *avatarAdapter) CreateUserAvatarRelation(relation models.UserAvatarRelation) error {
-	return a.db.Create(&relation).Error
-}
-
-func (a *avatarAdapter) UpdateUserAvatarRelation(relation models.UserAvatarRelation) error {
-	return a.db.Save(&relation).Error
+func (a *avatarAdapter) GetUserAvatarRelationsByAvatarIDs(userID string, avatarIDs []string) ([]models.UserAvatarRelation, error) {
+	if len(avatarIDs) == 0 {
+		return nil, nil
+	}
+	var relations []models.UserAvatarRelation
+	if err := a.db.Where("user_id = ? AND avatar_id IN ?", userID, avatarIDs).Find(&relations).Error; err != nil {
+		return nil, err
+	}
+	return relations, nil
 }
@@ -18,7 +18,10 @@ type AvatarChatMessage struct {
 
 type AvatarChatAdapter interface {
 	CreateAvatarChatMessage(ctx context.Context, userID string, avatarID string, message AvatarChatMessage) error
-	GetAvatarChatMessages(ctx context.Context, userID string, avatarID string) ([]AvatarChatMessage, error)
+	// GetAvatarChatMessages pageの範囲のメッセージを古い順に返す。続きがあれば次のカーソルを返す
+	GetAvatarChatMessages(ctx context.Context, userID string, avatarID string, page ChatPage) (messages []AvatarChatMessage, nextCursor string, err error)
+	// CountAvatarMessages アバターが送ったメッセージのうち、afterより新しいものの件数を返す
+	CountAvatarMessages(ctx context.Context, userID string, avatarID string, after string) (int, error)
 }
 
 type avatarChatAdapter struct {
@@ -36,7 +39,15 @@ func (a *avatarChatAdapter) CreateAvatarChatMessage(ctx context.Context, userID
 
 	doc := col.Doc(message.ID)
 
-	_, err := doc.Set(ctx, message)
+	// アバターとのチャットが一覧に出るのは話しかけた側のユーザーだけ
+	err := writeChatMessage(ctx, a.client, doc, message, message.ID, message.Message, message.CreatedAt, []chatSummaryUpdate{
+		{
+			ref:       chatSummaryRef(a.client, userID, models.ChatKindAvatar, avatarID),
+			kind:      models.ChatKindAvatar,
+			partnerID: avatarID,
+			unread:    message.SenderType == models.SenderTypeAvatarAI,
+		},
+	})
 	if err != nil {
 		return utils.WrapError(err)
 	}
@@ -44,13 +55,12 @@ func (a *avatarChatAdapter) CreateAvatarChatMessage(ctx context.Context, userID
 	return nil
 }
 
-func (a *avatarChatAdapter) GetAvatarChatMessages(ctx context.Context, userID string, avatarID string) ([]AvatarChatMessage, error) {
-	query := a.client.Collection("avatar_chats").Doc(userID).Collection(avatarID).
-		OrderBy("created_at", firestore.Asc)
+func (a *avatarChatAdapter) GetAvatarChatMessages(ctx context.Context, userID string, avatarID string, page ChatPage) ([]AvatarChatMessage, string, error) {
+	col := a.client.Collection("avatar_chats").Doc(userID).Collection(avatarID)
 
-	docs, err := query.Documents(ctx).GetAll()
+	docs, nextCursor, err := getChatPage(ctx, col, page)
 	if err != nil {
-		return nil, utils.WrapError(err)
+		return nil, "", utils.WrapError(err)
 	}
 
 	messages := make([]AvatarChatMessage, 0, len(docs))
@@ -70,5 +80,15 @@ func (a *avatarChatAdapter) GetAvatarChatMessages(ctx context.Context, userID st
 		})
 	}
 
-	return messages, nil
+	return messages, nextCursor, nil
+}
+
+func (a *avatarChatAdapter) CountAvatarMessages(ctx context.Context, userID string, avatarID string, after string) (int, error) {
+	col := a.client.Collection("avatar_chats").Doc(userID).Collection(avatarID)
+
+	count, err := countChatMessagesAfter(ctx, col.Where("sender_type", "==", string(models.SenderTypeAvatarAI)), after)
+	if err != nil {
+		return 0, utils.WrapError(err)
+	}
+	return count, nil
 }
@@ -0,0 +1,49 @@
+package adapter
+
+import (
+	"context"
+
+	"github.com/hackathon-20260110/api/models"
+)
+
+// inMemoryAvatarChatAdapter FLAVOR=local で使う、InMemoryStoreにメッセージを置くAvatarChatAdapter
+type inMemoryAvatarChatAdapter struct {
+	store *InMemoryStore
+}
+
+func NewInMemoryAvatarChatAdapter(store *InMemoryStore) AvatarChatAdapter {
+	return &inMemoryAvatarChatAdapter{store: store}
+}
+
+func avatarChatMessageID(message AvatarChatMessage) string {
+	return message.ID
+}
+
+func (a *inMemoryAvatarChatAdapter) CreateAvatarChatMessage(ctx context.Context, userID string, avatarID string, message AvatarChatMessage) error {
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	key := [2]string{userID, avatarID}
+	a.store.avatarChats[key] = insertByID(a.store.avatarChats[key], message, avatarChatMessageID)
+	// アバターとのチャットが一覧に出るのは話しかけた側のユーザーだけ
+	a.store.updateChatSummary(userID, models.ChatKindAvatar, avatarID, message.ID, message.Message, message.CreatedAt,
+		message.SenderType == models.SenderTypeAvatarAI, false)
+	return nil
+}
+
+func (a *inMemoryAvatarChatAdapter) GetAvatarChatMessages(ctx context.Context, userID string, avatarID string, page ChatPage) ([]AvatarChatMessage, string, error) {
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	messages, nextCursor := pageByID(a.store.avatarChats[[2]string{userID, avatarID}], avatarChatMessageID, page)
+	return messages, nextCursor, nil
+}
+
+func (a *inMemoryAvatarChatAdapter) CountAvatarMessages(ctx context.Context, userID string, avatarID string, after string) (int, error) {
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	return countAfterID(a.store.avatarChats[[2]string{userID, avatarID}], avatarChatMessageID, after, func(message AvatarChatMessage) bool {
+		return message.SenderType == models.SenderTypeAvatarAI
+	}), nil
+}
@@ -0,0 +1,37 @@
+package adapter
+
+import (
+	"errors"
+
+	"github.com/hackathon-20260110/api/models"
+	"gorm.io/gorm"
+)
+
+type AvatarChatMemoryAdapter interface {
+	// Get 記憶を返す。まだ無ければ空の記憶を返す
+	Get(userID string, avatarID string) (*models.AvatarChatMemory, error)
+	Save(memory *models.AvatarChatMemory) error
+}
+
+type avatarChatMemoryAdapter struct {
+	db *gorm.DB
+}
+
+func NewAvatarChatMemoryAdapter(db *gorm.DB) AvatarChatMemoryAdapter {
+	return &avatarChatMemoryAdapter{db: db}
+}
+
+func (a *avatarChatMemoryAdapter) Get(userID string, avatarID string) (*models.AvatarChatMemory, error) {
+	var memory models.AvatarChatMemory
+	if err := a.db.Where("user_id = ? AND avatar_id = ?", userID, avatarID).First(&memory).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &models.AvatarChatMemory{UserID: userID, AvatarID: avatarID}, nil
+		}
+		return nil, err
+	}
+	return &memory, nil
+}
+
+func (a *avatarChatMemoryAdapter) Save(memory *models.AvatarChatMemory) error {
+	return a.db.Save(memory).Error
+}
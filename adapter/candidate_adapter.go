@@ -0,0 +1,155 @@
+package adapter
+
+import (
+	"slices"
+	"time"
+
+	"github.com/hackathon-20260110/api/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CandidateFilter マッチング候補の絞り込み条件。
+// 自分の希望（Genders・年齢・自分のMaxDistanceKm）と、相手の希望（相手の恋愛対象・年齢・距離が自分を受け入れるか）の両方をSQLで絞り込む
+type CandidateFilter struct {
+	UserID  string
+	Genders []string
+	// BornOnOrBefore / BornAfter 年齢の範囲を生年月日の範囲に直したもの
+	BornOnOrBefore *time.Time
+	BornAfter      *time.Time
+	// Viewer / ViewerAge 相手の希望を判定するための自分の性別・年齢・位置。Viewer.MaxDistanceKmは自分の距離の希望
+	Viewer    models.User
+	ViewerAge int
+	// Limit 返す候補の上限。マッチングポイントの高い順に選ぶ
+	Limit int
+}
+
+// Candidate 候補ユーザーと、その人のアバター・公開済みのプロフィール・自分とのマッチングポイント
+type Candidate struct {
+	User          models.User
+	Avatar        models.Avatar
+	UserInfos     []*models.UserInfo
+	MatchingPoint int
+}
+
+type CandidateAdapter interface {
+	// FindCandidates 自分・マッチング済み・ブロック関係にあるユーザーと、お互いの希望を満たさないユーザーを除いた候補を、
+	// 自分とのマッチングポイントの高い順に最大filter.Limit人返す。アバターを持たない（オンボーディング未完了の）ユーザーは含めない
+	FindCandidates(filter CandidateFilter) ([]Candidate, error)
+}
+
+type candidateAdapter struct {
+	db *gorm.DB
+}
+
+func NewCandidateAdapter(db *gorm.DB) CandidateAdapter {
+	return &candidateAdapter{db: db}
+}
+
+func (a *candidateAdapter) FindCandidates(filter CandidateFilter) ([]Candidate, error) {
+	query := a.db.Model(&models.User{}).
+		Where("users.id <> ?", filter.UserID).
+		Where("EXISTS (SELECT 1 FROM avatars WHERE avatars.user_id = users.id)").
+		Where("NOT EXISTS (SELECT 1 FROM matchings WHERE (matchings.user1_id = ? AND matchings.user2_id = users.id) OR (matchings.user1_id = users.id AND matchings.user2_id = ?))", filter.UserID, filter.UserID).
+		Where("NOT EXISTS (SELECT 1 FROM user_blocks WHERE (user_blocks.blocker_user_id = ? AND user_blocks.blocked_user_id = users.id) OR (user_blocks.blocker_user_id = users.id AND user_blocks.blocked_user_id = ?))", filter.UserID, filter.UserID)
+	if len(filter.Genders) > 0 {
+		query = query.Where("users.gender IN ?", filter.Genders)
+	}
+	if filter.BornOnOrBefore != nil {
+		query = query.Where("users.birth_date <= ?", *filter.BornOnOrBefore)
+	}
+	if filter.BornAfter != nil {
+		query = query.Where("users.birth_date > ?", *filter.BornAfter)
+	}
+
+	// 相手の希望（models.User.Admits と同じ条件）。恋愛対象が未設定の相手は DefaultInterestedInGenders で判定する
+	viewer := filter.Viewer
+	defaultAdmittingGenders := make([]string, 0, len(models.Genders))
+	for _, gender := range models.Genders {
+		if slices.Contains(models.DefaultInterestedInGenders(gender), viewer.Gender) {
+			defaultAdmittingGenders = append(defaultAdmittingGenders, gender)
+		}
+	}
+	query = query.
+		Where("(users.interested_in_genders @> to_jsonb(?::text) OR (jsonb_array_length(users.interested_in_genders) = 0 AND users.gender IN ?))", viewer.Gender, defaultAdmittingGenders).
+		Where("(users.preferred_min_age = 0 OR users.preferred_min_age <= ?)", filter.ViewerAge).
+		Where("(users.preferred_max_age = 0 OR users.preferred_max_age >= ?)", filter.ViewerAge)
+
+	// 距離はどちらかが位置情報を登録していなければ判定しない（models.distanceKm と同じ大円距離）
+	if viewer.Latitude != nil && viewer.Longitude != nil {
+		distance := "2 * 6371.0 * asin(sqrt(power(sin(radians(users.latitude::float8 - ?::float8) / 2), 2) + cos(radians(?::float8)) * cos(radians(users.latitude::float8)) * power(sin(radians(users.longitude::float8 - ?::float8) / 2), 2)))"
+		distanceArgs := []interface{}{*viewer.Latitude, *viewer.Latitude, *viewer.Longitude}
+		if viewer.MaxDistanceKm != nil {
+			query = query.Where("(users.latitude IS NULL OR users.longitude IS NULL OR "+distance+" <= ?)", append(distanceArgs, *viewer.MaxDistanceKm)...)
+		}
+		query = query.Where("(users.max_distance_km IS NULL OR users.latitude IS NULL OR users.longitude IS NULL OR "+distance+" <= users.max_distance_km)", distanceArgs...)
+	}
+
+	// 全員を読み込まないよう、自分とのマッチングポイントの高い順に上限までに絞る
+	query = query.Order(clause.OrderBy{Expression: clause.Expr{
+		SQL:  "COALESCE((SELECT MAX(user_avatar_relations.matching_point) FROM user_avatar_relations JOIN avatars ON avatars.id = user_avatar_relations.avatar_id WHERE user_avatar_relations.user_id = ? AND avatars.user_id = users.id), 0) DESC, users.id",
+		Vars: []interface{}{filter.UserID},
+	}})
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var users []models.User
+	if err := query.Find(&users).Error; err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return []Candidate{}, nil
+	}
+
+	userIDs := make([]string, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.ID)
+	}
+
+	var avatars []models.Avatar
+	if err := a.db.Where("user_id IN ?", userIDs).Order("created_at").Find(&avatars).Error; err != nil {
+		return nil, err
+	}
+	avatarByUserID := make(map[string]models.Avatar, len(avatars))
+	avatarIDs := make([]string, 0, len(avatars))
+	for _, avatar := range avatars {
+		if _, ok := avatarByUserID[avatar.UserID]; ok {
+			continue
+		}
+		avatarByUserID[avatar.UserID] = avatar
+		avatarIDs = append(avatarIDs, avatar.ID)
+	}
+
+	// ミッション報酬の項目は相手にまだ見えていないため、相性計算にも使わない
+	var userInfos []*models.UserInfo
+	if err := a.db.Where("user_id IN ? AND info_type = ? AND is_mission_reward = ?", userIDs, models.UserInfoTypeText, false).
+		Find(&userInfos).Error; err != nil {
+		return nil, err
+	}
+	userInfosByUserID := make(map[string][]*models.UserInfo)
+	for _, info := range userInfos {
+		userInfosByUserID[info.UserID] = append(userInfosByUserID[info.UserID], info)
+	}
+
+	var relations []models.UserAvatarRelation
+	if err := a.db.Where("user_id = ? AND avatar_id IN ?", filter.UserID, avatarIDs).Find(&relations).Error; err != nil {
+		return nil, err
+	}
+	pointByAvatarID := make(map[string]int, len(relations))
+	for _, relation := range relations {
+		pointByAvatarID[relation.AvatarID] = relation.MatchingPoint
+	}
+
+	candidates := make([]Candidate, 0, len(users))
+	for _, user := range users {
+		avatar := avatarByUserID[user.ID]
+		candidates = append(candidates, Candidate{
+			User:          user,
+			Avatar:        avatar,
+			UserInfos:     userInfosByUserID[user.ID],
+			MatchingPoint: pointByAvatarID[avatar.ID],
+		})
+	}
+	return candidates, nil
+}
@@ -0,0 +1,90 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"cloud.google.com/go/firestore"
+	"cloud.google.com/go/firestore/apiv1/firestorepb"
+)
+
+// ChatPage チャット履歴の取得範囲。メッセージIDはULIDでドキュメントIDと同じなので、IDの順が送信順になる
+type ChatPage struct {
+	// Limit 取得件数。0なら全件
+	Limit int
+	// Before このIDより古いメッセージのうち、新しい側からLimit件を返す
+	Before string
+	// After このIDより新しいメッセージのうち、古い側からLimit件を返す
+	After string
+	// FromOldest Before/Afterが無いとき、最新ではなく最古からLimit件を返す
+	FromOldest bool
+}
+
+// getChatPage colからpageの範囲のドキュメントを古い順に返す。
+// 範囲の外にまだメッセージがあれば、次のページを取るためのカーソルを返す
+// （Afterを指定した場合は次のAfter、それ以外は次のBeforeに渡すID）
+func getChatPage(ctx context.Context, col *firestore.CollectionRef, page ChatPage) ([]*firestore.DocumentSnapshot, string, error) {
+	forward := page.After != "" || (page.FromOldest && page.Before == "")
+	direction := firestore.Desc
+	if forward || page.Limit == 0 {
+		direction = firestore.Asc
+	}
+
+	query := col.OrderBy(firestore.DocumentID, direction)
+	switch {
+	case page.After != "":
+		query = query.StartAfter(page.After)
+	case page.Before != "":
+		if page.Limit == 0 {
+			query = query.EndBefore(page.Before)
+		} else {
+			query = query.StartAfter(page.Before)
+		}
+	}
+	if page.Limit > 0 {
+		// 1件多く取って続きがあるかを判定する
+		query = query.Limit(page.Limit + 1)
+	}
+
+	docs, err := query.Documents(ctx).GetAll()
+	if err != nil {
+		return nil, "", err
+	}
+
+	hasMore := page.Limit > 0 && len(docs) > page.Limit
+	if hasMore {
+		docs = docs[:page.Limit]
+	}
+	if direction == firestore.Desc {
+		slices.Reverse(docs)
+	}
+
+	nextCursor := ""
+	if hasMore {
+		if forward {
+			nextCursor = docs[len(docs)-1].Ref.ID
+		} else {
+			nextCursor = docs[0].Ref.ID
+		}
+	}
+	return docs, nextCursor, nil
+}
+
+// countChatMessagesAfter queryに一致するメッセージのうち、afterより新しいものの件数を返す。afterが空なら全件を数える
+func countChatMessagesAfter(ctx context.Context, query firestore.Query, after string) (int, error) {
+	query = query.OrderBy(firestore.DocumentID, firestore.Asc)
+	if after != "" {
+		query = query.StartAfter(after)
+	}
+
+	result, err := query.NewAggregationQuery().WithCount("count").Get(ctx)
+	if err != nil {
+		return 0, err
+	}
+	count, ok := result["count"].(*firestorepb.Value)
+	if !ok {
+		return 0, fmt.Errorf("unexpected count aggregation result: %v", result["count"])
+	}
+	return int(count.GetIntegerValue()), nil
+}
@@ -0,0 +1,56 @@
+package adapter
+
+import (
+	"errors"
+
+	"github.com/hackathon-20260110/api/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ChatReadCursorAdapter interface {
+	// Get 既読位置を返す。まだ無ければ空の既読位置を返す
+	Get(userID string, kind models.ChatKind, partnerID string) (*models.ChatReadCursor, error)
+	// GetByUserID ユーザーの既読位置をすべて返す
+	GetByUserID(userID string) ([]models.ChatReadCursor, error)
+	// Advance 既読位置をcursor.LastReadMessageIDまで進める。既にそれより先まで読んでいれば何もしない
+	Advance(cursor *models.ChatReadCursor) error
+}
+
+type chatReadCursorAdapter struct {
+	db *gorm.DB
+}
+
+func NewChatReadCursorAdapter(db *gorm.DB) ChatReadCursorAdapter {
+	return &chatReadCursorAdapter{db: db}
+}
+
+func (a *chatReadCursorAdapter) Get(userID string, kind models.ChatKind, partnerID string) (*models.ChatReadCursor, error) {
+	var cursor models.ChatReadCursor
+	if err := a.db.Where("user_id = ? AND chat_kind = ? AND partner_id = ?", userID, kind, partnerID).First(&cursor).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &models.ChatReadCursor{UserID: userID, ChatKind: kind, PartnerID: partnerID}, nil
+		}
+		return nil, err
+	}
+	return &cursor, nil
+}
+
+func (a *chatReadCursorAdapter) GetByUserID(userID string) ([]models.ChatReadCursor, error) {
+	var cursors []models.ChatReadCursor
+	if err := a.db.Where("user_id = ?", userID).Find(&cursors).Error; err != nil {
+		return nil, err
+	}
+	return cursors, nil
+}
+
+func (a *chatReadCursorAdapter) Advance(cursor *models.ChatReadCursor) error {
+	// 複数端末から同時に既読にしても後退しないよう、新しい位置のほうが先のときだけ更新する
+	return a.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "chat_kind"}, {Name: "partner_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_read_message_id", "updated_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			gorm.Expr("chat_read_cursors.last_read_message_id < excluded.last_read_message_id"),
+		}},
+	}).Create(cursor).Error
+}
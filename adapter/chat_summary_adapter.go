@@ -0,0 +1,165 @@
+package adapter
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/utils"
+)
+
+// ChatSummary チャット一覧の1行分の集計。チャット一覧を1回のクエリで返せるよう、
+// メッセージを書き込むたびに同じトランザクションで一覧を見る側のユーザーごとに更新する
+type ChatSummary struct {
+	ChatKind  models.ChatKind `firestore:"chat_kind"`
+	PartnerID string          `firestore:"partner_id"`
+	// LastMessageID 最新のメッセージID。空ならまだメッセージが無い
+	LastMessageID string    `firestore:"last_message_id"`
+	LastMessage   string    `firestore:"last_message"`
+	LastMessageAt time.Time `firestore:"last_message_at"`
+	// UnreadCount 相手（アバターの場合はアバター）からの未読メッセージ数
+	UnreadCount int `firestore:"unread_count"`
+}
+
+type ChatSummaryAdapter interface {
+	// GetChatSummaries userIDのチャット一覧の集計をすべて返す
+	GetChatSummaries(ctx context.Context, userID string) ([]ChatSummary, error)
+	// CreateChatSummary 集計がまだ無いときだけ作る。集計を導入する前の会話を履歴から作り直すのに使う
+	CreateChatSummary(ctx context.Context, userID string, summary ChatSummary) error
+	// SetUnreadCount 既読にしたあとの未読件数を書き込む。集計がまだ無ければ何もしない
+	SetUnreadCount(ctx context.Context, userID string, kind models.ChatKind, partnerID string, count int) error
+}
+
+type chatSummaryAdapter struct {
+	client *firestore.Client
+}
+
+func NewChatSummaryAdapter(client *firestore.Client) ChatSummaryAdapter {
+	return &chatSummaryAdapter{client: client}
+}
+
+func chatSummaryRef(client *firestore.Client, userID string, kind models.ChatKind, partnerID string) *firestore.DocumentRef {
+	return client.Collection("chat_summaries").Doc(userID).Collection("chats").Doc(string(kind) + "_" + partnerID)
+}
+
+func (a *chatSummaryAdapter) GetChatSummaries(ctx context.Context, userID string) ([]ChatSummary, error) {
+	docs, err := a.client.Collection("chat_summaries").Doc(userID).Collection("chats").Documents(ctx).GetAll()
+	if err != nil {
+		return nil, utils.WrapError(err)
+	}
+
+	summaries := make([]ChatSummary, 0, len(docs))
+	for _, doc := range docs {
+		var summary ChatSummary
+		if err := doc.DataTo(&summary); err != nil {
+			return nil, utils.WrapError(err)
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+func (a *chatSummaryAdapter) CreateChatSummary(ctx context.Context, userID string, summary ChatSummary) error {
+	ref := chatSummaryRef(a.client, userID, summary.ChatKind, summary.PartnerID)
+	err := a.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snaps, err := tx.GetAll([]*firestore.DocumentRef{ref})
+		if err != nil {
+			return err
+		}
+		// 作り直している間に新しいメッセージで集計が作られていれば、そちらが新しい
+		if snaps[0].Exists() {
+			return nil
+		}
+		return tx.Create(ref, summary)
+	})
+	if err != nil {
+		return utils.WrapError(err)
+	}
+	return nil
+}
+
+func (a *chatSummaryAdapter) SetUnreadCount(ctx context.Context, userID string, kind models.ChatKind, partnerID string, count int) error {
+	ref := chatSummaryRef(a.client, userID, kind, partnerID)
+	err := a.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snaps, err := tx.GetAll([]*firestore.DocumentRef{ref})
+		if err != nil {
+			return err
+		}
+		if !snaps[0].Exists() {
+			return nil
+		}
+		return tx.Update(ref, []firestore.Update{{Path: "unread_count", Value: count}})
+	})
+	if err != nil {
+		return utils.WrapError(err)
+	}
+	return nil
+}
+
+// chatSummaryUpdate メッセージを1件書き込んだときの、一覧を見る側1人分の集計の更新内容
+type chatSummaryUpdate struct {
+	ref       *firestore.DocumentRef
+	kind      models.ChatKind
+	partnerID string
+	// unread 相手からのメッセージなので未読を1件増やす
+	unread bool
+	// read 送信した側なので未読を0にする（送信時に既読位置も進む）
+	read bool
+}
+
+// writeChatMessage メッセージと、一覧を見る側の集計をトランザクションでまとめて書き込む。
+// 集計の最新メッセージは、書き込みの順が前後しても新しいIDのものだけを残す
+func writeChatMessage(
+	ctx context.Context,
+	client *firestore.Client,
+	messageRef *firestore.DocumentRef,
+	message interface{},
+	messageID string,
+	text string,
+	createdAt time.Time,
+	updates []chatSummaryUpdate,
+) error {
+	return client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		refs := make([]*firestore.DocumentRef, len(updates))
+		for i, update := range updates {
+			refs[i] = update.ref
+		}
+		snaps, err := tx.GetAll(refs)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Set(messageRef, message); err != nil {
+			return err
+		}
+
+		for i, update := range updates {
+			data := map[string]interface{}{
+				"chat_kind":  string(update.kind),
+				"partner_id": update.partnerID,
+			}
+			lastMessageID := ""
+			if snaps[i].Exists() {
+				if id, ok := snaps[i].Data()["last_message_id"].(string); ok {
+					lastMessageID = id
+				}
+			}
+			if messageID > lastMessageID {
+				data["last_message_id"] = messageID
+				data["last_message"] = text
+				data["last_message_at"] = createdAt
+			}
+			switch {
+			case update.unread:
+				data["unread_count"] = firestore.Increment(1)
+			case update.read:
+				data["unread_count"] = 0
+			}
+			if err := tx.Set(update.ref, data, firestore.MergeAll); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
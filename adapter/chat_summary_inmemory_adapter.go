@@ -0,0 +1,59 @@
+package adapter
+
+import (
+	"context"
+
+	"github.com/hackathon-20260110/api/models"
+)
+
+// inMemoryChatSummaryAdapter FLAVOR=local で使う、InMemoryStoreの集計を読み書きするChatSummaryAdapter。
+// 集計はメッセージを書き込むInMemoryAvatarChatAdapter・InMemoryUserChatAdapterが更新する
+type inMemoryChatSummaryAdapter struct {
+	store *InMemoryStore
+}
+
+func NewInMemoryChatSummaryAdapter(store *InMemoryStore) ChatSummaryAdapter {
+	return &inMemoryChatSummaryAdapter{store: store}
+}
+
+func (a *inMemoryChatSummaryAdapter) GetChatSummaries(ctx context.Context, userID string) ([]ChatSummary, error) {
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	summaries := make([]ChatSummary, 0, len(a.store.summaries[userID]))
+	for _, summary := range a.store.summaries[userID] {
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+func (a *inMemoryChatSummaryAdapter) CreateChatSummary(ctx context.Context, userID string, summary ChatSummary) error {
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	summaries, ok := a.store.summaries[userID]
+	if !ok {
+		summaries = make(map[chatSummaryKey]ChatSummary)
+		a.store.summaries[userID] = summaries
+	}
+	key := chatSummaryKey{kind: summary.ChatKind, partnerID: summary.PartnerID}
+	if _, exists := summaries[key]; exists {
+		return nil
+	}
+	summaries[key] = summary
+	return nil
+}
+
+func (a *inMemoryChatSummaryAdapter) SetUnreadCount(ctx context.Context, userID string, kind models.ChatKind, partnerID string, count int) error {
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	key := chatSummaryKey{kind: kind, partnerID: partnerID}
+	summary, ok := a.store.summaries[userID][key]
+	if !ok {
+		return nil
+	}
+	summary.UnreadCount = count
+	a.store.summaries[userID][key] = summary
+	return nil
+}
@@ -0,0 +1,80 @@
+package adapter
+
+import (
+	"time"
+
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DeviceTokenAdapter interface {
+	// Register トークンを登録する。既に登録済みのトークンはuserIDとplatformを上書きする
+	Register(userID string, token string, platform models.DevicePlatform) (*models.DeviceToken, error)
+	GetByUserID(userID string) ([]models.DeviceToken, error)
+	// Delete 自分のトークンを削除する。見つからなければutils.ErrorRecordNotFound
+	Delete(userID string, token string) error
+	// DeleteByTokens FCMに無効と判定されたトークンをまとめて削除する
+	DeleteByTokens(tokens []string) error
+}
+
+type deviceTokenAdapter struct {
+	db *gorm.DB
+}
+
+func NewDeviceTokenAdapter(db *gorm.DB) DeviceTokenAdapter {
+	return &deviceTokenAdapter{db: db}
+}
+
+func (a *deviceTokenAdapter) Register(userID string, token string, platform models.DevicePlatform) (*models.DeviceToken, error) {
+	now := time.Now()
+	deviceToken := models.DeviceToken{
+		ID:        utils.GenerateULID(),
+		UserID:    userID,
+		Token:     token,
+		Platform:  platform,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := a.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "token"}},
+		DoUpdates: clause.AssignmentColumns([]string{"user_id", "platform", "updated_at"}),
+	}).Create(&deviceToken).Error; err != nil {
+		return nil, err
+	}
+
+	// 競合時はIDが既存の行と異なるため読み直す
+	var saved models.DeviceToken
+	if err := a.db.Where("token = ?", token).First(&saved).Error; err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+func (a *deviceTokenAdapter) GetByUserID(userID string) ([]models.DeviceToken, error) {
+	var deviceTokens []models.DeviceToken
+	if err := a.db.Where("user_id = ?", userID).Order("created_at").Find(&deviceTokens).Error; err != nil {
+		return nil, err
+	}
+	return deviceTokens, nil
+}
+
+func (a *deviceTokenAdapter) Delete(userID string, token string) error {
+	result := a.db.Where("user_id = ? AND token = ?", userID, token).Delete(&models.DeviceToken{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return utils.ErrorRecordNotFound
+	}
+	return nil
+}
+
+func (a *deviceTokenAdapter) DeleteByTokens(tokens []string) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+	return a.db.Where("token IN ?", tokens).Delete(&models.DeviceToken{}).Error
+}
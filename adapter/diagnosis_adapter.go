@@ -1,7 +1,8 @@
 package adapter
 
 import (
-	"github.com/hackathon-20260110/api/driver"
+	"time"
+
 	"github.com/hackathon-20260110/api/models"
 	"gorm.io/gorm"
 )
@@ -10,29 +11,22 @@ type DiagnosisAdapter interface {
 	// 診断履歴の保存（ポインタ渡しでGORMの自動設定値を反映）
 	CreateDiagnosisHistory(history *models.DiagnosisHistory) error
 
+	// sinceより後に、ユーザーが同じ相手のAvatarと行った診断の件数
+	CountDiagnosisHistoriesSince(userID, targetAvatarID string, since time.Time) (int64, error)
+
 	// ユーザーの診断履歴取得
 	GetDiagnosisHistoryByUserID(userID string) ([]models.DiagnosisHistory, error)
 
 	// 特定の診断履歴取得
 	GetDiagnosisHistoryByID(id string) (models.DiagnosisHistory, error)
 
-	// UserAvatarRelationのポイント更新
-	UpdateUserAvatarRelationPoints(userID, avatarID string, additionalPoints int) error
-
-	// UserAvatarRelationの取得（存在チェック用）
-	GetUserAvatarRelation(userID, avatarID string) (models.UserAvatarRelation, error)
-
-	// UserAvatarRelationの作成
-	CreateUserAvatarRelation(relation models.UserAvatarRelation) error
-
 	// Avatarの存在確認
 	GetAvatarByID(id string) (models.Avatar, error)
 
 	GetByUserID(userID string) (models.Avatar, error)
 }
 
-func NewDiagnosisAdapter() DiagnosisAdapter {
-	db := driver.NewPsql()
+func NewDiagnosisAdapter(db *gorm.DB) DiagnosisAdapter {
 	return &diagnosisAdapter{db: db}
 }
 
@@ -44,6 +38,14 @@ func (a *diagnosisAdapter) CreateDiagnosisHistory(history *models.DiagnosisHisto
 	return a.db.Create(history).Error
 }
 
+func (a *diagnosisAdapter) CountDiagnosisHistoriesSince(userID, targetAvatarID string, since time.Time) (int64, error) {
+	var count int64
+	err := a.db.Model(&models.DiagnosisHistory{}).
+		Where("user_id = ? AND target_avatar_id = ? AND created_at > ?", userID, targetAvatarID, since).
+		Count(&count).Error
+	return count, err
+}
+
 func (a *diagnosisAdapter) GetDiagnosisHistoryByUserID(userID string) ([]models.DiagnosisHistory, error) {
 	var histories []models.DiagnosisHistory
 	err := a.db.Where("user_id = ?", userID).
@@ -65,22 +67,6 @@ func (a *diagnosisAdapter) GetDiagnosisHistoryByID(id string) (models.DiagnosisH
 	return history, err
 }
 
-func (a *diagnosisAdapter) UpdateUserAvatarRelationPoints(userID, avatarID string, additionalPoints int) error {
-	return a.db.Model(&models.UserAvatarRelation{}).
-		Where("user_id = ? AND avatar_id = ?", userID, avatarID).
-		Update("matching_point", gorm.Expr("matching_point + ?", additionalPoints)).Error
-}
-
-func (a *diagnosisAdapter) GetUserAvatarRelation(userID, avatarID string) (models.UserAvatarRelation, error) {
-	var relation models.UserAvatarRelation
-	err := a.db.Where("user_id = ? AND avatar_id = ?", userID, avatarID).First(&relation).Error
-	return relation, err
-}
-
-func (a *diagnosisAdapter) CreateUserAvatarRelation(relation models.UserAvatarRelation) error {
-	return a.db.Create(&relation).Error
-}
-
 func (a *diagnosisAdapter) GetAvatarByID(id string) (models.Avatar, error) {
 	var avatar models.Avatar
 	err := a.db.Where("id = ?", id).First(&avatar).Error
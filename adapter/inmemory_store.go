@@ -0,0 +1,123 @@
+package adapter
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hackathon-20260110/api/models"
+)
+
+// InMemoryStore FLAVOR=local でFirestoreの代わりにチャット・一覧の集計・通知をメモリに持つ。
+// プロセスを止めると消えるので、ローカル開発専用
+type InMemoryStore struct {
+	mu sync.Mutex
+	// avatarChats ユーザーIDとアバターIDごとのメッセージ。IDの昇順に並べる
+	avatarChats map[[2]string][]AvatarChatMessage
+	// userChats 正規化した2人のユーザーIDごとのメッセージ。IDの昇順に並べる
+	userChats map[[2]string][]UserChatMessage
+	// onboardingChats ユーザーIDごとのオンボーディングのチャット。IDの昇順に並べる
+	onboardingChats map[string][]models.OnboardingChat
+	// summaries 一覧を見るユーザーIDごとのチャット一覧の集計
+	summaries map[string]map[chatSummaryKey]ChatSummary
+	// notifications ユーザーIDごとの通知
+	notifications map[string]map[string]models.Notification
+}
+
+type chatSummaryKey struct {
+	kind      models.ChatKind
+	partnerID string
+}
+
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{
+		avatarChats:     make(map[[2]string][]AvatarChatMessage),
+		userChats:       make(map[[2]string][]UserChatMessage),
+		onboardingChats: make(map[string][]models.OnboardingChat),
+		summaries:       make(map[string]map[chatSummaryKey]ChatSummary),
+		notifications:   make(map[string]map[string]models.Notification),
+	}
+}
+
+// updateChatSummary writeChatMessage と同じく、メッセージを1件書き込んだときの一覧を見る側1人分の集計を更新する。
+// 呼び出し側でロックを取っておく
+func (s *InMemoryStore) updateChatSummary(userID string, kind models.ChatKind, partnerID string, messageID string, text string, createdAt time.Time, unread bool, read bool) {
+	summaries, ok := s.summaries[userID]
+	if !ok {
+		summaries = make(map[chatSummaryKey]ChatSummary)
+		s.summaries[userID] = summaries
+	}
+	key := chatSummaryKey{kind: kind, partnerID: partnerID}
+	summary := summaries[key]
+	summary.ChatKind = kind
+	summary.PartnerID = partnerID
+	if messageID > summary.LastMessageID {
+		summary.LastMessageID = messageID
+		summary.LastMessage = text
+		summary.LastMessageAt = createdAt
+	}
+	switch {
+	case unread:
+		summary.UnreadCount++
+	case read:
+		summary.UnreadCount = 0
+	}
+	summaries[key] = summary
+}
+
+// insertByID IDの昇順を保ったままitemを入れる。同じIDがあれば上書きする（FirestoreのSetと同じ）
+func insertByID[T any](items []T, item T, id func(T) string) []T {
+	i := sort.Search(len(items), func(i int) bool { return id(items[i]) >= id(item) })
+	if i < len(items) && id(items[i]) == id(item) {
+		items[i] = item
+		return items
+	}
+	items = append(items, item)
+	copy(items[i+1:], items[i:])
+	items[i] = item
+	return items
+}
+
+// pageByID IDの昇順に並んだitemsから、getChatPage と同じ規則でpageの範囲を古い順に返す
+func pageByID[T any](items []T, id func(T) string, page ChatPage) ([]T, string) {
+	start, end := 0, len(items)
+	switch {
+	case page.After != "":
+		start = sort.Search(len(items), func(i int) bool { return id(items[i]) > page.After })
+	case page.Before != "":
+		end = sort.Search(len(items), func(i int) bool { return id(items[i]) >= page.Before })
+	}
+	window := items[start:end]
+
+	forward := page.After != "" || (page.FromOldest && page.Before == "")
+	hasMore := page.Limit > 0 && len(window) > page.Limit
+	if hasMore {
+		if forward {
+			window = window[:page.Limit]
+		} else {
+			window = window[len(window)-page.Limit:]
+		}
+	}
+	result := append([]T(nil), window...)
+
+	nextCursor := ""
+	if hasMore {
+		if forward {
+			nextCursor = id(result[len(result)-1])
+		} else {
+			nextCursor = id(result[0])
+		}
+	}
+	return result, nextCursor
+}
+
+// countAfterID IDの昇順に並んだitemsのうち、matchに一致しafterより新しいものの件数を返す。afterが空なら全件を数える
+func countAfterID[T any](items []T, id func(T) string, after string, match func(T) bool) int {
+	count := 0
+	for _, item := range items {
+		if id(item) > after && match(item) {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,161 @@
+package adapter
+
+import (
+	"time"
+
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DefaultJobMaxAttempts Enqueue時にMaxAttemptsが未指定の場合の試行回数
+const DefaultJobMaxAttempts = 5
+
+type JobAdapter interface {
+	// Enqueue ジョブを登録する。ID・Status・RunAt・MaxAttemptsが空なら既定値を入れる
+	Enqueue(job *models.Job) error
+	// EnqueueIfNotActive 同じ種類・対象の実行待ちか実行中のジョブがあれば登録せずfalseを返す。
+	// 判定はjobsの部分一意インデックス（idx_jobs_active_type_subject）に任せるので、そこに含めた種類でだけ使う
+	EnqueueIfNotActive(job *models.Job) (bool, error)
+	// Lease 実行可能なジョブを1件取り出してリースする。無ければnilを返す。
+	// リース期限切れのrunningジョブ（ワーカーが落ちた場合）も取り出し対象にする
+	Lease(workerID string, jobTypes []models.JobType, leaseDuration time.Duration) (*models.Job, error)
+	// Complete リースしているワーカーだけが成功にできる
+	Complete(jobID, workerID string) error
+	// Fail retryAtがnilならデッドレターにし、そうでなければその時刻に再実行する
+	Fail(jobID, workerID string, lastError string, retryAt *time.Time) error
+	GetLatestJobBySubject(jobType models.JobType, subjectID string) (*models.Job, error)
+}
+
+type jobAdapter struct {
+	db *gorm.DB
+}
+
+func NewJobAdapter(db *gorm.DB) JobAdapter {
+	return &jobAdapter{db: db}
+}
+
+func (a *jobAdapter) Enqueue(job *models.Job) error {
+	setJobDefaults(job)
+	return a.db.Create(job).Error
+}
+
+func (a *jobAdapter) EnqueueIfNotActive(job *models.Job) (bool, error) {
+	setJobDefaults(job)
+	res := a.db.Clauses(clause.OnConflict{DoNothing: true}).Create(job)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+// setJobDefaults ID・Status・RunAt・MaxAttempts・Payloadが空なら既定値を入れる
+func setJobDefaults(job *models.Job) {
+	if job.ID == "" {
+		job.ID = utils.GenerateULID()
+	}
+	if job.Status == "" {
+		job.Status = models.JobStatusQueued
+	}
+	if job.RunAt.IsZero() {
+		job.RunAt = time.Now()
+	}
+	if job.MaxAttempts <= 0 {
+		job.MaxAttempts = DefaultJobMaxAttempts
+	}
+	if job.Payload == "" {
+		job.Payload = "{}"
+	}
+}
+
+func (a *jobAdapter) Lease(workerID string, jobTypes []models.JobType, leaseDuration time.Duration) (*models.Job, error) {
+	var leased *models.Job
+	err := a.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		// 実行中に落ち続けるジョブが無限に再実行されないよう、回数を使い切った期限切れのジョブは先にデッドレターにする。
+		// 同じ呼び出しの中で続けて次のジョブを取り出すので、ワーカーが空振りして待つことはない
+		if err := tx.Model(&models.Job{}).
+			Where("type IN ? AND status = ? AND leased_until < ? AND attempts >= max_attempts",
+				jobTypes, models.JobStatusRunning, now).
+			Updates(map[string]interface{}{
+				"status":       models.JobStatusDead,
+				"leased_until": nil,
+				"last_error":   "lease expired",
+			}).Error; err != nil {
+			return err
+		}
+
+		// 複数ワーカーが同じジョブを取らないよう、ロック中の行は飛ばす
+		var jobs []models.Job
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("type IN ?", jobTypes).
+			Where("(status = ? AND run_at <= ?) OR (status = ? AND leased_until < ? AND attempts < max_attempts)",
+				models.JobStatusQueued, now, models.JobStatusRunning, now).
+			Order("run_at").
+			Limit(1).
+			Find(&jobs).Error; err != nil {
+			return err
+		}
+		if len(jobs) == 0 {
+			return nil
+		}
+		job := jobs[0]
+
+		leasedUntil := now.Add(leaseDuration)
+		if err := tx.Model(&job).Updates(map[string]interface{}{
+			"status":       models.JobStatusRunning,
+			"attempts":     job.Attempts + 1,
+			"leased_by":    workerID,
+			"leased_until": leasedUntil,
+		}).Error; err != nil {
+			return err
+		}
+		job.Status = models.JobStatusRunning
+		job.Attempts++
+		job.LeasedBy = workerID
+		job.LeasedUntil = &leasedUntil
+		leased = &job
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return leased, nil
+}
+
+func (a *jobAdapter) Complete(jobID, workerID string) error {
+	return a.db.Model(&models.Job{}).
+		Where("id = ? AND leased_by = ? AND status = ?", jobID, workerID, models.JobStatusRunning).
+		Updates(map[string]interface{}{
+			"status":       models.JobStatusSucceeded,
+			"leased_until": nil,
+			"last_error":   "",
+		}).Error
+}
+
+func (a *jobAdapter) Fail(jobID, workerID string, lastError string, retryAt *time.Time) error {
+	updates := map[string]interface{}{
+		"status":       models.JobStatusDead,
+		"leased_until": nil,
+		"last_error":   lastError,
+	}
+	if retryAt != nil {
+		updates["status"] = models.JobStatusQueued
+		updates["run_at"] = *retryAt
+	}
+	return a.db.Model(&models.Job{}).
+		Where("id = ? AND leased_by = ? AND status = ?", jobID, workerID, models.JobStatusRunning).
+		Updates(updates).Error
+}
+
+func (a *jobAdapter) GetLatestJobBySubject(jobType models.JobType, subjectID string) (*models.Job, error) {
+	var job models.Job
+	if err := a.db.Where("type = ? AND subject_id = ?", jobType, subjectID).
+		Order("created_at DESC, id DESC").
+		First(&job).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
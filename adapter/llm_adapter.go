@@ -3,8 +3,7 @@ package adapter
 import (
 	"context"
 
-	"github.com/hackathon-20260110/api/utils"
-	"google.golang.org/genai"
+	"github.com/hackathon-20260110/api/config"
 )
 
 type LLMModelType string
@@ -15,51 +14,71 @@ const (
 	LLM_MODEL_TYPE_GEMINI_2_5_FLASH_LITE LLMModelType = "gemini-2.5-flash-lite-preview-09-2025"
 )
 
-type LLMAdapter interface {
-	CreateChatCompletion(contents []*genai.Content, model LLMModelType) (string, error)
-	CreateChatCompletionJSON(contents []*genai.Content, model LLMModelType) (string, error)
-}
+// LLMProvider LLM_PROVIDER環境変数で選択するLLMの実装
+type LLMProvider string
 
-func NewLLMAdapter(genaiClient *genai.Client) LLMAdapter {
-	return &llmAdapter{client: genaiClient}
-}
+const (
+	LLMProviderGemini LLMProvider = config.LLMProviderGemini
+	// LLMProviderScripted ネットワークに出ずに決まった応答を返す。ローカル開発・結合テスト用
+	LLMProviderScripted LLMProvider = config.LLMProviderScripted
+)
 
-type llmAdapter struct {
-	client *genai.Client
-}
+type LLMRole string
 
-func (a *llmAdapter) CreateChatCompletion(contents []*genai.Content, model LLMModelType) (string, error) {
-	ctx := context.Background()
+const (
+	LLMRoleSystem LLMRole = "system"
+	LLMRoleUser   LLMRole = "user"
+	LLMRoleModel  LLMRole = "model"
+)
 
-	result, err := a.client.Models.GenerateContent(
-		ctx,
-		string(model),
-		contents,
-		nil,
-	)
-	if err != nil {
-		return "", utils.WrapError(err)
-	}
+// LLMTask リクエストの用途。プロバイダーは無視してよいが、scripted実装は用途ごとに応答を切り替える
+type LLMTask string
 
-	return result.Text(), nil
-}
+const (
+	LLMTaskGeneral               LLMTask = ""
+	LLMTaskOnboardingQuestion    LLMTask = "onboarding_question"
+	LLMTaskOnboardingJudge       LLMTask = "onboarding_judge"
+	LLMTaskOnboardingExtraction  LLMTask = "onboarding_extraction"
+	LLMTaskOnboardingPersona     LLMTask = "onboarding_persona"
+	LLMTaskAvatarChat            LLMTask = "avatar_chat"
+	LLMTaskAvatarChatStream      LLMTask = "avatar_chat_stream"
+	LLMTaskAvatarChatEvaluation  LLMTask = "avatar_chat_evaluation"
+	LLMTaskAvatarChatSummary     LLMTask = "avatar_chat_summary"
+	LLMTaskDiagnosisConversation LLMTask = "diagnosis_conversation"
+	LLMTaskDiagnosisEvaluation   LLMTask = "diagnosis_evaluation"
+)
 
-func (a *llmAdapter) CreateChatCompletionJSON(contents []*genai.Content, model LLMModelType) (string, error) {
-	ctx := context.Background()
+type LLMMessage struct {
+	Role LLMRole
+	Text string
+}
 
-	config := &genai.GenerateContentConfig{
-		ResponseMIMEType: "application/json",
-	}
+type LLMRequest struct {
+	Model    LLMModelType
+	Task     LLMTask
+	Messages []LLMMessage
+	// MaxRepairAttempts 構造化出力が不正なときの再プロンプト回数。0なら DefaultLLMRepairAttempts
+	MaxRepairAttempts int
+}
 
-	result, err := a.client.Models.GenerateContent(
-		ctx,
-		string(model),
-		contents,
-		config,
-	)
-	if err != nil {
-		return "", utils.WrapError(err)
+// NewLLMPromptRequest 1つのユーザープロンプトだけからなるリクエストを作る
+func NewLLMPromptRequest(model LLMModelType, task LLMTask, prompt string) LLMRequest {
+	return LLMRequest{
+		Model: model,
+		Task:  task,
+		Messages: []LLMMessage{
+			{Role: LLMRoleUser, Text: prompt},
+		},
 	}
+}
 
-	return result.Text(), nil
+type LLMAdapter interface {
+	CreateChatCompletion(ctx context.Context, req LLMRequest) (string, error)
+	CreateChatCompletionJSON(ctx context.Context, req LLMRequest) (string, error)
+	// CreateChatCompletionStream 生成されたテキストを断片ごとに onChunk へ渡し、最後に全文を返す。
+	// onChunk がエラーを返したら生成を打ち切る
+	CreateChatCompletionStream(ctx context.Context, req LLMRequest, onChunk func(chunk string) error) (string, error)
+	// CreateStructuredCompletion out のスキーマで生成・検証してデコードする。
+	// 修正を求めても不正なままなら *LLMStructuredOutputError を返す
+	CreateStructuredCompletion(ctx context.Context, req LLMRequest, out LLMStructuredOutput) error
 }
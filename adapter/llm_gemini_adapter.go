@@ -0,0 +1,154 @@
+package adapter
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hackathon-20260110/api/utils"
+	"google.golang.org/genai"
+)
+
+func NewGeminiLLMAdapter(genaiClient *genai.Client) LLMAdapter {
+	return &geminiLLMAdapter{client: genaiClient}
+}
+
+type geminiLLMAdapter struct {
+	client *genai.Client
+}
+
+func (a *geminiLLMAdapter) CreateChatCompletion(ctx context.Context, req LLMRequest) (string, error) {
+	contents, config := toGenAIContents(req)
+
+	result, err := a.client.Models.GenerateContent(
+		ctx,
+		string(req.Model),
+		contents,
+		config,
+	)
+	if err != nil {
+		return "", utils.WrapError(err)
+	}
+
+	return result.Text(), nil
+}
+
+func (a *geminiLLMAdapter) CreateChatCompletionJSON(ctx context.Context, req LLMRequest) (string, error) {
+	contents, config := toGenAIContents(req)
+	if config == nil {
+		config = &genai.GenerateContentConfig{}
+	}
+	config.ResponseMIMEType = "application/json"
+
+	result, err := a.client.Models.GenerateContent(
+		ctx,
+		string(req.Model),
+		contents,
+		config,
+	)
+	if err != nil {
+		return "", utils.WrapError(err)
+	}
+
+	return result.Text(), nil
+}
+
+func (a *geminiLLMAdapter) CreateChatCompletionStream(ctx context.Context, req LLMRequest, onChunk func(chunk string) error) (string, error) {
+	contents, config := toGenAIContents(req)
+
+	var full strings.Builder
+	for result, err := range a.client.Models.GenerateContentStream(
+		ctx,
+		string(req.Model),
+		contents,
+		config,
+	) {
+		if err != nil {
+			return "", utils.WrapError(err)
+		}
+		chunk := result.Text()
+		if chunk == "" {
+			continue
+		}
+		full.WriteString(chunk)
+		if err := onChunk(chunk); err != nil {
+			return "", err
+		}
+	}
+
+	return full.String(), nil
+}
+
+func (a *geminiLLMAdapter) CreateStructuredCompletion(ctx context.Context, req LLMRequest, out LLMStructuredOutput) error {
+	return createStructuredCompletion(ctx, req, out, a.generateWithSchema)
+}
+
+func (a *geminiLLMAdapter) generateWithSchema(ctx context.Context, req LLMRequest, schema *LLMSchema) (string, error) {
+	contents, config := toGenAIContents(req)
+	if config == nil {
+		config = &genai.GenerateContentConfig{}
+	}
+	config.ResponseMIMEType = "application/json"
+	config.ResponseSchema = toGenAISchema(schema)
+
+	result, err := a.client.Models.GenerateContent(
+		ctx,
+		string(req.Model),
+		contents,
+		config,
+	)
+	if err != nil {
+		return "", utils.WrapError(err)
+	}
+
+	return result.Text(), nil
+}
+
+func toGenAISchema(schema *LLMSchema) *genai.Schema {
+	if schema == nil {
+		return nil
+	}
+
+	out := &genai.Schema{
+		Type:        genai.Type(strings.ToUpper(string(schema.Type))),
+		Description: schema.Description,
+		Required:    schema.Required,
+		Items:       toGenAISchema(schema.Items),
+		Minimum:     schema.Minimum,
+		Maximum:     schema.Maximum,
+		MinLength:   schema.MinLength,
+		MinItems:    schema.MinItems,
+	}
+	if len(schema.Properties) > 0 {
+		out.Properties = make(map[string]*genai.Schema, len(schema.Properties))
+		for key, prop := range schema.Properties {
+			out.Properties[key] = toGenAISchema(prop)
+		}
+	}
+	return out
+}
+
+// toGenAIContents system メッセージはGeminiのSystemInstructionとして渡す
+func toGenAIContents(req LLMRequest) ([]*genai.Content, *genai.GenerateContentConfig) {
+	var config *genai.GenerateContentConfig
+	contents := make([]*genai.Content, 0, len(req.Messages))
+
+	for _, msg := range req.Messages {
+		if msg.Role == LLMRoleSystem {
+			if config == nil {
+				config = &genai.GenerateContentConfig{
+					SystemInstruction: &genai.Content{},
+				}
+			}
+			config.SystemInstruction.Parts = append(config.SystemInstruction.Parts, &genai.Part{Text: msg.Text})
+			continue
+		}
+
+		var role genai.Role = genai.RoleUser
+		if msg.Role == LLMRoleModel {
+			role = genai.RoleModel
+		}
+		contents = append(contents, genai.NewContentFromText(msg.Text, role))
+	}
+
+	return contents, config
+}
@@ -0,0 +1,205 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"hash/fnv"
+	"strings"
+	"sync"
+)
+
+// scriptedStreamChunkRunes ストリーミング時に1回で渡す文字数
+const scriptedStreamChunkRunes = 4
+
+// scriptedOnboardingJudgeMinAnswers オンボーディング完了と判定するユーザー回答数
+const scriptedOnboardingJudgeMinAnswers = 3
+
+var scriptedOnboardingQuestions = []string{
+	"はじめまして！休日はどんなふうに過ごすことが多いですか？",
+	"素敵ですね。最近ハマっていることや、気になっていることはありますか？",
+	"ありがとうございます。お付き合いする相手には、どんなことを大切にしてほしいですか？",
+	"なるほど。お仕事や普段の生活リズムについても教えてもらえますか？",
+	"いいですね！一緒に行ってみたい場所や、やってみたいことはありますか？",
+}
+
+var scriptedAvatarMessages = []string{
+	"そうなんですね！もう少し詳しく聞かせてもらえますか？",
+	"わかります、私もそういうの好きです。最近はどうですか？",
+	"面白いですね！ちなみに休日はどんなふうに過ごしていますか？",
+	"素敵な考え方ですね。私も大事にしたいと思っています。",
+}
+
+var scriptedDiagnosisTurns = []string{
+	"はじめまして！今日はお話できて嬉しいです。",
+	"こちらこそ！最近はどんなことにハマっていますか？",
+	"最近は休日に散歩しながらカフェ巡りをしています。",
+	"いいですね、私もカフェが好きなので今度おすすめを教えてください。",
+}
+
+// NewScriptedLLMAdapter ネットワークに出ず、LLMTaskごとのルールで決定的に応答するLLMAdapterを作る
+func NewScriptedLLMAdapter() LLMAdapter {
+	return NewScriptedLLMAdapterWithScripts(nil)
+}
+
+// NewScriptedLLMAdapterWithScripts タスクごとの応答を差し替えたscripted実装を作る。
+// 同じタスクが複数回呼ばれた場合は順に返し、尽きたら最後の応答を繰り返す。
+func NewScriptedLLMAdapterWithScripts(scripts map[LLMTask][]string) LLMAdapter {
+	return &scriptedLLMAdapter{
+		scripts: scripts,
+		calls:   make(map[LLMTask]int),
+	}
+}
+
+type scriptedLLMAdapter struct {
+	mu      sync.Mutex
+	scripts map[LLMTask][]string
+	calls   map[LLMTask]int
+}
+
+func (a *scriptedLLMAdapter) CreateChatCompletion(ctx context.Context, req LLMRequest) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if resp, ok := a.nextScript(req.Task); ok {
+		return resp, nil
+	}
+	return scriptedResponse(req), nil
+}
+
+func (a *scriptedLLMAdapter) CreateChatCompletionJSON(ctx context.Context, req LLMRequest) (string, error) {
+	return a.CreateChatCompletion(ctx, req)
+}
+
+func (a *scriptedLLMAdapter) CreateChatCompletionStream(ctx context.Context, req LLMRequest, onChunk func(chunk string) error) (string, error) {
+	resp, err := a.CreateChatCompletion(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	runes := []rune(resp)
+	for start := 0; start < len(runes); start += scriptedStreamChunkRunes {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		end := start + scriptedStreamChunkRunes
+		if end > len(runes) {
+			end = len(runes)
+		}
+		if err := onChunk(string(runes[start:end])); err != nil {
+			return "", err
+		}
+	}
+
+	return resp, nil
+}
+
+func (a *scriptedLLMAdapter) CreateStructuredCompletion(ctx context.Context, req LLMRequest, out LLMStructuredOutput) error {
+	return createStructuredCompletion(ctx, req, out, func(ctx context.Context, req LLMRequest, _ *LLMSchema) (string, error) {
+		return a.CreateChatCompletion(ctx, req)
+	})
+}
+
+func (a *scriptedLLMAdapter) nextScript(task LLMTask) (string, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	responses := a.scripts[task]
+	if len(responses) == 0 {
+		return "", false
+	}
+
+	i := a.calls[task]
+	a.calls[task]++
+	if i >= len(responses) {
+		i = len(responses) - 1
+	}
+	return responses[i], true
+}
+
+func scriptedResponse(req LLMRequest) string {
+	prompt := ""
+	for _, msg := range req.Messages {
+		prompt += msg.Text
+	}
+	seed := scriptedSeed(prompt)
+
+	switch req.Task {
+	case LLMTaskOnboardingQuestion:
+		return scriptedOnboardingQuestions[seed%uint32(len(scriptedOnboardingQuestions))]
+	case LLMTaskOnboardingJudge:
+		if strings.Count(prompt, "ユーザー: 「") >= scriptedOnboardingJudgeMinAnswers {
+			return "TRUE"
+		}
+		return "FALSE"
+	case LLMTaskOnboardingExtraction:
+		return mustMarshalScripted(map[string]interface{}{
+			"items": []map[string]string{
+				{"key": "趣味", "value": "カフェ巡り、散歩"},
+				{"key": "性格", "value": "穏やかで聞き上手"},
+				{"key": "恋愛観", "value": "お互いを尊重できる関係を大切にしたい"},
+			},
+		})
+	case LLMTaskOnboardingPersona:
+		return mustMarshalScripted(map[string]interface{}{
+			"persona_prompt": "穏やかで聞き上手な話し方をします。語尾は柔らかく、相手の話に共感してから自分の話をします。" +
+				"お互いを尊重できる関係を大切にしています。過去の恋愛の詳細や収入の話題は避けます。",
+			"traits": map[string]interface{}{
+				"big_five": map[string]int{
+					"openness":          4,
+					"conscientiousness": 3,
+					"extraversion":      3,
+					"agreeableness":     5,
+					"neuroticism":       2,
+				},
+				"interests":       []string{"カフェ巡り", "散歩"},
+				"dating_values":   []string{"お互いを尊重する", "誠実さ"},
+				"speaking_style":  "丁寧で柔らかい口調",
+				"topics_to_avoid": []string{"過去の恋愛の詳細", "収入"},
+			},
+		})
+	case LLMTaskAvatarChat:
+		return mustMarshalScripted(map[string]interface{}{
+			"message":      scriptedAvatarMessages[seed%uint32(len(scriptedAvatarMessages))],
+			"point_change": 3 + int(seed%5),
+			"reason":       "会話が続いているため（scripted）",
+		})
+	case LLMTaskAvatarChatStream:
+		return scriptedAvatarMessages[seed%uint32(len(scriptedAvatarMessages))]
+	case LLMTaskAvatarChatEvaluation:
+		return mustMarshalScripted(map[string]interface{}{
+			"point_change": 3 + int(seed%5),
+			"reason":       "会話が続いているため（scripted）",
+		})
+	case LLMTaskAvatarChatSummary:
+		return mustMarshalScripted(map[string]interface{}{
+			"summary": "お互いの趣味や休日の過ごし方について話している（scripted）",
+			"facts":   []string{"休日はカフェ巡りをしている"},
+		})
+	case LLMTaskDiagnosisConversation:
+		return scriptedDiagnosisTurns[seed%uint32(len(scriptedDiagnosisTurns))]
+	case LLMTaskDiagnosisEvaluation:
+		return mustMarshalScripted(map[string]interface{}{
+			"score":                   3 + int(seed%3),
+			"reason":                  "共通の話題で会話が弾んでいます（scripted）",
+			"compatibility_factors":   []string{"共通の趣味", "会話のテンポ"},
+			"improvement_suggestions": []string{"お互いの価値観についても話してみましょう"},
+		})
+	default:
+		return "これはscripted LLMによる応答です。"
+	}
+}
+
+// scriptedSeed 同じプロンプトには同じ応答を返しつつ、会話が進むと応答が変わるようにする
+func scriptedSeed(prompt string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(prompt))
+	return h.Sum32()
+}
+
+func mustMarshalScripted(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
@@ -0,0 +1,234 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// DefaultLLMRepairAttempts 構造化出力が不正だったときに再プロンプトする回数の既定値
+const DefaultLLMRepairAttempts = 2
+
+type LLMSchemaType string
+
+const (
+	LLMSchemaTypeObject  LLMSchemaType = "object"
+	LLMSchemaTypeArray   LLMSchemaType = "array"
+	LLMSchemaTypeString  LLMSchemaType = "string"
+	LLMSchemaTypeInteger LLMSchemaType = "integer"
+	LLMSchemaTypeNumber  LLMSchemaType = "number"
+	LLMSchemaTypeBoolean LLMSchemaType = "boolean"
+)
+
+// LLMSchema プロバイダー非依存のJSON Schemaのサブセット。
+// Geminiには ResponseSchema として渡し、応答の検証にも同じ定義を使う
+type LLMSchema struct {
+	Type        LLMSchemaType
+	Description string
+	Properties  map[string]*LLMSchema
+	Required    []string
+	Items       *LLMSchema
+	Minimum     *float64
+	Maximum     *float64
+	MinLength   *int64
+	MinItems    *int64
+}
+
+// LLMStructuredOutput 構造化出力の受け皿。json.Unmarshal できるポインタで実装する
+type LLMStructuredOutput interface {
+	LLMSchema() *LLMSchema
+}
+
+// LLMOutputValidator スキーマでは表せない制約を検証したい出力型が実装する
+type LLMOutputValidator interface {
+	Validate() error
+}
+
+var ErrLLMInvalidStructuredOutput = errors.New("llm structured output is invalid")
+
+// LLMStructuredOutputError 再プロンプトしても正しい出力が得られなかった
+type LLMStructuredOutputError struct {
+	Task         LLMTask
+	Attempts     int
+	LastResponse string
+	Err          error
+}
+
+func (e *LLMStructuredOutputError) Error() string {
+	return fmt.Sprintf("llm structured output for task %q is invalid after %d attempts: %v", e.Task, e.Attempts, e.Err)
+}
+
+func (e *LLMStructuredOutputError) Unwrap() error {
+	return e.Err
+}
+
+func (e *LLMStructuredOutputError) Is(target error) bool {
+	return target == ErrLLMInvalidStructuredOutput
+}
+
+// LLMSchemaBound Minimum/Maximum 用のポインタを作る
+func LLMSchemaBound(v float64) *float64 {
+	return &v
+}
+
+// LLMSchemaCount MinLength/MinItems 用のポインタを作る
+func LLMSchemaCount(n int64) *int64 {
+	return &n
+}
+
+type llmJSONGenerator func(ctx context.Context, req LLMRequest, schema *LLMSchema) (string, error)
+
+// createStructuredCompletion 生成→スキーマ検証→デコード→Validateを行い、
+// 失敗したら直前の応答とエラー内容を会話に足して再生成させる
+func createStructuredCompletion(ctx context.Context, req LLMRequest, out LLMStructuredOutput, generate llmJSONGenerator) error {
+	schema := out.LLMSchema()
+	maxRepairs := req.MaxRepairAttempts
+	if maxRepairs <= 0 {
+		maxRepairs = DefaultLLMRepairAttempts
+	}
+
+	messages := append([]LLMMessage(nil), req.Messages...)
+	var lastResp string
+	var lastErr error
+	attempts := 0
+	for attempts <= maxRepairs {
+		attempts++
+		attemptReq := req
+		attemptReq.Messages = messages
+
+		resp, err := generate(ctx, attemptReq, schema)
+		if err != nil {
+			// 通信エラーなどは修正プロンプトで直らないのでそのまま返す
+			return err
+		}
+		lastResp = resp
+
+		lastErr = decodeStructuredOutput(resp, schema, out)
+		if lastErr == nil {
+			return nil
+		}
+
+		messages = append(messages,
+			LLMMessage{Role: LLMRoleModel, Text: resp},
+			LLMMessage{Role: LLMRoleUser, Text: fmt.Sprintf(
+				"直前の出力は次の理由で不正でした: %s\n指定されたJSONスキーマに従って、JSONのみを出力し直してください。", lastErr)},
+		)
+	}
+
+	return &LLMStructuredOutputError{
+		Task:         req.Task,
+		Attempts:     attempts,
+		LastResponse: lastResp,
+		Err:          lastErr,
+	}
+}
+
+func decodeStructuredOutput(resp string, schema *LLMSchema, out LLMStructuredOutput) error {
+	raw := extractJSONText(resp)
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		return fmt.Errorf("JSONとして解釈できません: %w", err)
+	}
+	if err := validateLLMSchema(value, schema, "$"); err != nil {
+		return err
+	}
+	if err := json.Unmarshal([]byte(raw), out); err != nil {
+		return fmt.Errorf("出力の型が一致しません: %w", err)
+	}
+	if validator, ok := out.(LLMOutputValidator); ok {
+		if err := validator.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extractJSONText スキーマ指定に対応しないプロバイダーがコードフェンスで囲んで返すことがある
+func extractJSONText(resp string) string {
+	resp = strings.TrimSpace(resp)
+	if strings.HasPrefix(resp, "```") {
+		resp = strings.TrimPrefix(resp, "```json")
+		resp = strings.TrimPrefix(resp, "```")
+		resp = strings.TrimSuffix(resp, "```")
+		resp = strings.TrimSpace(resp)
+	}
+	return resp
+}
+
+func validateLLMSchema(value interface{}, schema *LLMSchema, path string) error {
+	if schema == nil {
+		return nil
+	}
+
+	switch schema.Type {
+	case LLMSchemaTypeObject:
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s はオブジェクトである必要があります", path)
+		}
+		for _, key := range schema.Required {
+			if v, ok := obj[key]; !ok || v == nil {
+				return fmt.Errorf("%s.%s は必須です", path, key)
+			}
+		}
+		keys := make([]string, 0, len(schema.Properties))
+		for key := range schema.Properties {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			v, ok := obj[key]
+			if !ok || v == nil {
+				continue
+			}
+			if err := validateLLMSchema(v, schema.Properties[key], path+"."+key); err != nil {
+				return err
+			}
+		}
+	case LLMSchemaTypeArray:
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s は配列である必要があります", path)
+		}
+		if schema.MinItems != nil && int64(len(items)) < *schema.MinItems {
+			return fmt.Errorf("%s は%d件以上必要です", path, *schema.MinItems)
+		}
+		for i, item := range items {
+			if err := validateLLMSchema(item, schema.Items, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case LLMSchemaTypeString:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s は文字列である必要があります", path)
+		}
+		if schema.MinLength != nil && int64(len([]rune(strings.TrimSpace(s)))) < *schema.MinLength {
+			return fmt.Errorf("%s は%d文字以上必要です", path, *schema.MinLength)
+		}
+	case LLMSchemaTypeInteger, LLMSchemaTypeNumber:
+		n, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("%s は数値である必要があります", path)
+		}
+		if schema.Type == LLMSchemaTypeInteger && n != math.Trunc(n) {
+			return fmt.Errorf("%s は整数である必要があります", path)
+		}
+		if schema.Minimum != nil && n < *schema.Minimum {
+			return fmt.Errorf("%s は%v以上である必要があります（実際: %v）", path, *schema.Minimum, n)
+		}
+		if schema.Maximum != nil && n > *schema.Maximum {
+			return fmt.Errorf("%s は%v以下である必要があります（実際: %v）", path, *schema.Maximum, n)
+		}
+	case LLMSchemaTypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s は真偽値である必要があります", path)
+		}
+	}
+	return nil
+}
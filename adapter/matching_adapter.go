@@ -6,8 +6,8 @@ import (
 )
 
 type MatchingAdapter interface {
+	GetByID(id string) (*models.Matching, error)
 	GetMatchingByUsers(user1ID string, user2ID string) (*models.Matching, error)
-	CreateMatching(matching models.Matching) error
 	GetMatchingsByUserID(userID string) ([]models.Matching, error)
 }
 
@@ -19,16 +19,20 @@ func NewMatchingAdapter(db *gorm.DB) MatchingAdapter {
 	return &matchingAdapter{db: db}
 }
 
-func (a *matchingAdapter) GetMatchingByUsers(user1ID string, user2ID string) (*models.Matching, error) {
+func (a *matchingAdapter) GetByID(id string) (*models.Matching, error) {
 	var matching models.Matching
-	if err := a.db.Where("(user1_id = ? AND user2_id = ?) OR (user1_id = ? AND user2_id = ?)", user1ID, user2ID, user2ID, user1ID).First(&matching).Error; err != nil {
+	if err := a.db.Where("id = ?", id).First(&matching).Error; err != nil {
 		return nil, err
 	}
 	return &matching, nil
 }
 
-func (a *matchingAdapter) CreateMatching(matching models.Matching) error {
-	return a.db.Create(&matching).Error
+func (a *matchingAdapter) GetMatchingByUsers(user1ID string, user2ID string) (*models.Matching, error) {
+	var matching models.Matching
+	if err := a.db.Where("(user1_id = ? AND user2_id = ?) OR (user1_id = ? AND user2_id = ?)", user1ID, user2ID, user2ID, user1ID).First(&matching).Error; err != nil {
+		return nil, err
+	}
+	return &matching, nil
 }
 
 func (a *matchingAdapter) GetMatchingsByUserID(userID string) ([]models.Matching, error) {
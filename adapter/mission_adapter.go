@@ -9,7 +9,6 @@ type MissionAdapter interface {
 	GetMissionsByOwnerUserID(ownerUserID string) ([]models.Mission, error)
 	GetMissionUnlocksByUserID(userID string) ([]models.MissionUnlock, error)
 	GetMissionUnlock(missionID string, userID string) (*models.MissionUnlock, error)
-	CreateMissionUnlock(missionUnlock models.MissionUnlock) error
 }
 
 type missionAdapter struct {
@@ -43,7 +42,3 @@ func (a *missionAdapter) GetMissionUnlock(missionID string, userID string) (*mod
 	}
 	return &missionUnlock, nil
 }
-
-func (a *missionAdapter) CreateMissionUnlock(missionUnlock models.MissionUnlock) error {
-	return a.db.Create(&missionUnlock).Error
-}
@@ -2,25 +2,37 @@ package adapter
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"cloud.google.com/go/firestore"
+	"cloud.google.com/go/firestore/apiv1/firestorepb"
 	"github.com/hackathon-20260110/api/models"
 	"github.com/hackathon-20260110/api/utils"
 )
 
 type FirestoreNotification struct {
-	ID        string    `firestore:"id"`
-	UserID    string    `firestore:"user_id"`
-	Title     string    `firestore:"title"`
-	Message   string    `firestore:"message"`
-	HasRead   bool      `firestore:"has_read"`
-	CreatedAt time.Time `firestore:"created_at"`
+	ID        string            `firestore:"id"`
+	UserID    string            `firestore:"user_id"`
+	Type      string            `firestore:"type"`
+	Title     string            `firestore:"title"`
+	Message   string            `firestore:"message"`
+	DeepLink  string            `firestore:"deep_link"`
+	Payload   map[string]string `firestore:"payload"`
+	HasRead   bool              `firestore:"has_read"`
+	CreatedAt time.Time         `firestore:"created_at"`
 }
 
 type NotificationAdapter interface {
 	CreateNotification(ctx context.Context, userID string, notification models.Notification) error
 	MarkAsRead(ctx context.Context, userID string, notificationID string) error
+	// ListNotifications 新しい順にlimit件返す。cursorは前のページの最後の通知ID（空なら先頭から）。
+	// 続きがあればその最後の通知IDをnextCursorとして返す
+	ListNotifications(ctx context.Context, userID string, cursor string, limit int) (notifications []models.Notification, nextCursor string, err error)
+	CountUnread(ctx context.Context, userID string) (int64, error)
+	// MarkAllAsRead 未読の通知をすべて既読にし、更新した件数を返す
+	MarkAllAsRead(ctx context.Context, userID string) (int, error)
+	DeleteNotification(ctx context.Context, userID string, notificationID string) error
 }
 
 type notificationAdapter struct {
@@ -33,15 +45,26 @@ func NewNotificationAdapter(client *firestore.Client) NotificationAdapter {
 	}
 }
 
+func (a *notificationAdapter) collection(userID string) *firestore.CollectionRef {
+	return a.client.Collection("notifications").Doc(userID).Collection("notification")
+}
+
 func (a *notificationAdapter) CreateNotification(ctx context.Context, userID string, notification models.Notification) error {
-	col := a.client.Collection("notifications").Doc(userID).Collection("notification")
-	doc := col.Doc(notification.ID)
+	doc := a.collection(userID).Doc(notification.ID)
+
+	notificationType := notification.Type
+	if notificationType == "" {
+		notificationType = models.NotificationTypeSystem
+	}
 
 	firestoreNotification := FirestoreNotification{
 		ID:        notification.ID,
 		UserID:    notification.UserID,
+		Type:      string(notificationType),
 		Title:     notification.Title,
 		Message:   notification.Message,
+		DeepLink:  notification.DeepLink,
+		Payload:   notification.Payload,
 		HasRead:   false,
 		CreatedAt: notification.CreatedAt,
 	}
@@ -55,7 +78,7 @@ func (a *notificationAdapter) CreateNotification(ctx context.Context, userID str
 }
 
 func (a *notificationAdapter) MarkAsRead(ctx context.Context, userID string, notificationID string) error {
-	doc := a.client.Collection("notifications").Doc(userID).Collection("notification").Doc(notificationID)
+	doc := a.collection(userID).Doc(notificationID)
 
 	_, err := doc.Update(ctx, []firestore.Update{
 		{Path: "has_read", Value: true},
@@ -66,3 +89,123 @@ func (a *notificationAdapter) MarkAsRead(ctx context.Context, userID string, not
 
 	return nil
 }
+
+func (a *notificationAdapter) ListNotifications(ctx context.Context, userID string, cursor string, limit int) ([]models.Notification, string, error) {
+	col := a.collection(userID)
+	// 同時刻の通知でも順序が揺れないようIDでも並べる
+	query := col.OrderBy("created_at", firestore.Desc).OrderBy(firestore.DocumentID, firestore.Desc)
+	if cursor != "" {
+		cursorDoc, err := col.Doc(cursor).Get(ctx)
+		if err != nil {
+			// 存在しない場合もerrが返るが、スナップショットはExists()=falseで返ってくる
+			if cursorDoc != nil && !cursorDoc.Exists() {
+				return nil, "", utils.ErrorRecordNotFound
+			}
+			return nil, "", utils.WrapError(err)
+		}
+		query = query.StartAfter(cursorDoc)
+	}
+
+	// 1件多く取って続きがあるかを判定する
+	docs, err := query.Limit(limit + 1).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, "", utils.WrapError(err)
+	}
+
+	nextCursor := ""
+	if len(docs) > limit {
+		docs = docs[:limit]
+		nextCursor = docs[len(docs)-1].Ref.ID
+	}
+
+	notifications := make([]models.Notification, 0, len(docs))
+	for _, doc := range docs {
+		var n FirestoreNotification
+		if err := doc.DataTo(&n); err != nil {
+			return nil, "", utils.WrapError(err)
+		}
+		notificationType := models.NotificationType(n.Type)
+		if notificationType == "" {
+			// type導入前に作られた通知
+			notificationType = models.NotificationTypeSystem
+		}
+		notifications = append(notifications, models.Notification{
+			ID:        doc.Ref.ID,
+			UserID:    n.UserID,
+			Type:      notificationType,
+			Title:     n.Title,
+			Message:   n.Message,
+			DeepLink:  n.DeepLink,
+			Payload:   n.Payload,
+			HasRead:   n.HasRead,
+			CreatedAt: n.CreatedAt,
+		})
+	}
+
+	return notifications, nextCursor, nil
+}
+
+func (a *notificationAdapter) CountUnread(ctx context.Context, userID string) (int64, error) {
+	query := a.collection(userID).Where("has_read", "==", false)
+	result, err := query.NewAggregationQuery().WithCount("unread").Get(ctx)
+	if err != nil {
+		return 0, utils.WrapError(err)
+	}
+
+	count, ok := result["unread"].(*firestorepb.Value)
+	if !ok {
+		return 0, utils.WrapError(fmt.Errorf("unexpected aggregation result: %T", result["unread"]))
+	}
+	return count.GetIntegerValue(), nil
+}
+
+func (a *notificationAdapter) MarkAllAsRead(ctx context.Context, userID string) (int, error) {
+	docs, err := a.collection(userID).Where("has_read", "==", false).Documents(ctx).GetAll()
+	if err != nil {
+		return 0, utils.WrapError(err)
+	}
+	if len(docs) == 0 {
+		return 0, nil
+	}
+
+	writer := a.client.BulkWriter(ctx)
+	jobs := make([]*firestore.BulkWriterJob, 0, len(docs))
+	for _, doc := range docs {
+		job, err := writer.Update(doc.Ref, []firestore.Update{
+			{Path: "has_read", Value: true},
+		})
+		if err != nil {
+			writer.End()
+			return 0, utils.WrapError(err)
+		}
+		jobs = append(jobs, job)
+	}
+	writer.End()
+
+	updated := 0
+	for _, job := range jobs {
+		if _, err := job.Results(); err != nil {
+			return updated, utils.WrapError(err)
+		}
+		updated++
+	}
+
+	return updated, nil
+}
+
+func (a *notificationAdapter) DeleteNotification(ctx context.Context, userID string, notificationID string) error {
+	doc := a.collection(userID).Doc(notificationID)
+	snapshot, err := doc.Get(ctx)
+	if err != nil {
+		if snapshot != nil && !snapshot.Exists() {
+			return utils.ErrorRecordNotFound
+		}
+		return utils.WrapError(err)
+	}
+
+	if _, err := snapshot.Ref.Delete(ctx); err != nil {
+		return utils.WrapError(err)
+	}
+
+	return nil
+}
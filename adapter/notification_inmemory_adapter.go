@@ -0,0 +1,127 @@
+package adapter
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/utils"
+)
+
+// inMemoryNotificationAdapter FLAVOR=local で使う、InMemoryStoreに通知を置くNotificationAdapter
+type inMemoryNotificationAdapter struct {
+	store *InMemoryStore
+}
+
+func NewInMemoryNotificationAdapter(store *InMemoryStore) NotificationAdapter {
+	return &inMemoryNotificationAdapter{store: store}
+}
+
+func (a *inMemoryNotificationAdapter) CreateNotification(ctx context.Context, userID string, notification models.Notification) error {
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	if notification.Type == "" {
+		notification.Type = models.NotificationTypeSystem
+	}
+	notification.HasRead = false
+
+	notifications, ok := a.store.notifications[userID]
+	if !ok {
+		notifications = make(map[string]models.Notification)
+		a.store.notifications[userID] = notifications
+	}
+	notifications[notification.ID] = notification
+	return nil
+}
+
+func (a *inMemoryNotificationAdapter) MarkAsRead(ctx context.Context, userID string, notificationID string) error {
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	notification, ok := a.store.notifications[userID][notificationID]
+	if !ok {
+		return utils.ErrorRecordNotFound
+	}
+	notification.HasRead = true
+	a.store.notifications[userID][notificationID] = notification
+	return nil
+}
+
+func (a *inMemoryNotificationAdapter) ListNotifications(ctx context.Context, userID string, cursor string, limit int) ([]models.Notification, string, error) {
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	// Firestoreの実装と同じく新しい順、同時刻ならIDの降順に並べる
+	notifications := make([]models.Notification, 0, len(a.store.notifications[userID]))
+	for _, notification := range a.store.notifications[userID] {
+		notifications = append(notifications, notification)
+	}
+	sort.Slice(notifications, func(i, j int) bool {
+		if !notifications[i].CreatedAt.Equal(notifications[j].CreatedAt) {
+			return notifications[i].CreatedAt.After(notifications[j].CreatedAt)
+		}
+		return notifications[i].ID > notifications[j].ID
+	})
+
+	if cursor != "" {
+		index := -1
+		for i, notification := range notifications {
+			if notification.ID == cursor {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return nil, "", utils.ErrorRecordNotFound
+		}
+		notifications = notifications[index+1:]
+	}
+
+	nextCursor := ""
+	if len(notifications) > limit {
+		notifications = notifications[:limit]
+		nextCursor = notifications[len(notifications)-1].ID
+	}
+	return notifications, nextCursor, nil
+}
+
+func (a *inMemoryNotificationAdapter) CountUnread(ctx context.Context, userID string) (int64, error) {
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	var count int64
+	for _, notification := range a.store.notifications[userID] {
+		if !notification.HasRead {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (a *inMemoryNotificationAdapter) MarkAllAsRead(ctx context.Context, userID string) (int, error) {
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	updated := 0
+	for id, notification := range a.store.notifications[userID] {
+		if notification.HasRead {
+			continue
+		}
+		notification.HasRead = true
+		a.store.notifications[userID][id] = notification
+		updated++
+	}
+	return updated, nil
+}
+
+func (a *inMemoryNotificationAdapter) DeleteNotification(ctx context.Context, userID string, notificationID string) error {
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	if _, ok := a.store.notifications[userID][notificationID]; !ok {
+		return utils.ErrorRecordNotFound
+	}
+	delete(a.store.notifications[userID], notificationID)
+	return nil
+}
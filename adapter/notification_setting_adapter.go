@@ -0,0 +1,69 @@
+package adapter
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationSettingAdapter interface {
+	// GetByUserID 通知設定を返す。未設定ならmodels.DefaultNotificationSettingを返す
+	GetByUserID(userID string) (*models.NotificationSetting, error)
+	Save(setting models.NotificationSetting) (*models.NotificationSetting, error)
+	AddSuppressedPush(push models.SuppressedPush) error
+	// TakeSuppressedPushes 止めていたプッシュ通知を古い順に取り出して削除する
+	TakeSuppressedPushes(userID string) ([]models.SuppressedPush, error)
+}
+
+type notificationSettingAdapter struct {
+	db *gorm.DB
+}
+
+func NewNotificationSettingAdapter(db *gorm.DB) NotificationSettingAdapter {
+	return &notificationSettingAdapter{db: db}
+}
+
+func (a *notificationSettingAdapter) GetByUserID(userID string) (*models.NotificationSetting, error) {
+	var setting models.NotificationSetting
+	if err := a.db.Where("user_id = ?", userID).First(&setting).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			setting = models.DefaultNotificationSetting(userID)
+			return &setting, nil
+		}
+		return nil, err
+	}
+	return &setting, nil
+}
+
+func (a *notificationSettingAdapter) Save(setting models.NotificationSetting) (*models.NotificationSetting, error) {
+	// boolのfalseも書き込むため、Saveで全カラムを更新する
+	if err := a.db.Save(&setting).Error; err != nil {
+		return nil, err
+	}
+	return &setting, nil
+}
+
+func (a *notificationSettingAdapter) AddSuppressedPush(push models.SuppressedPush) error {
+	if push.ID == "" {
+		push.ID = utils.GenerateULID()
+	}
+	return a.db.Create(&push).Error
+}
+
+func (a *notificationSettingAdapter) TakeSuppressedPushes(userID string) ([]models.SuppressedPush, error) {
+	var pushes []models.SuppressedPush
+	// 同時に実行されたダイジェストで二重に送らないよう、削除した行だけを受け取る
+	if err := a.db.Clauses(clause.Returning{}).
+		Where("user_id = ?", userID).
+		Delete(&pushes).Error; err != nil {
+		return nil, err
+	}
+	sort.Slice(pushes, func(i, j int) bool {
+		return pushes[i].CreatedAt.Before(pushes[j].CreatedAt)
+	})
+	return pushes, nil
+}
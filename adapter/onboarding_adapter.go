@@ -12,7 +12,8 @@ import (
 
 type OnboardingAdapter interface {
 	CreateOnboardingChat(ctx context.Context, userID string, chat models.OnboardingChat) error
-	GetOnboardingChats(ctx context.Context, userID string) ([]models.OnboardingChat, error)
+	// GetOnboardingChats pageの範囲のチャットを古い順に返す。続きがあれば次のカーソルを返す
+	GetOnboardingChats(ctx context.Context, userID string, page ChatPage) (chats []models.OnboardingChat, nextCursor string, err error)
 }
 
 type onboardingAdapter struct {
@@ -40,13 +41,12 @@ func (a *onboardingAdapter) CreateOnboardingChat(ctx context.Context, userID str
 	return nil
 }
 
-func (a *onboardingAdapter) GetOnboardingChats(ctx context.Context, userID string) ([]models.OnboardingChat, error) {
-	query := a.client.Collection("onboarding_chats").Doc(userID).Collection("chats").
-		OrderBy("created_at", firestore.Asc)
+func (a *onboardingAdapter) GetOnboardingChats(ctx context.Context, userID string, page ChatPage) ([]models.OnboardingChat, string, error) {
+	col := a.client.Collection("onboarding_chats").Doc(userID).Collection("chats")
 
-	docs, err := query.Documents(ctx).GetAll()
+	docs, nextCursor, err := getChatPage(ctx, col, page)
 	if err != nil {
-		return nil, utils.WrapError(err)
+		return nil, "", utils.WrapError(err)
 	}
 
 	chats := make([]models.OnboardingChat, 0, len(docs))
@@ -68,5 +68,5 @@ func (a *onboardingAdapter) GetOnboardingChats(ctx context.Context, userID strin
 		})
 	}
 
-	return chats, nil
+	return chats, nextCursor, nil
 }
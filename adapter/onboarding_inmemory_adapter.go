@@ -0,0 +1,36 @@
+package adapter
+
+import (
+	"context"
+
+	"github.com/hackathon-20260110/api/models"
+)
+
+// inMemoryOnboardingAdapter FLAVOR=local で使う、InMemoryStoreにチャットを置くOnboardingAdapter
+type inMemoryOnboardingAdapter struct {
+	store *InMemoryStore
+}
+
+func NewInMemoryOnboardingAdapter(store *InMemoryStore) OnboardingAdapter {
+	return &inMemoryOnboardingAdapter{store: store}
+}
+
+func onboardingChatID(chat models.OnboardingChat) string {
+	return chat.ID
+}
+
+func (a *inMemoryOnboardingAdapter) CreateOnboardingChat(ctx context.Context, userID string, chat models.OnboardingChat) error {
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	a.store.onboardingChats[userID] = insertByID(a.store.onboardingChats[userID], chat, onboardingChatID)
+	return nil
+}
+
+func (a *inMemoryOnboardingAdapter) GetOnboardingChats(ctx context.Context, userID string, page ChatPage) ([]models.OnboardingChat, string, error) {
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	chats, nextCursor := pageByID(a.store.onboardingChats[userID], onboardingChatID, page)
+	return chats, nextCursor, nil
+}
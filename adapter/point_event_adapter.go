@@ -0,0 +1,292 @@
+package adapter
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PointEventAdapter interface {
+	// ApplyPointChange ポイント更新・point_eventsへの記録・マッチング成立・ミッション解禁を
+	// 1つのトランザクションで行う。Relationが無ければ作成し、ポイントは0〜MaxMatchingPointに収める
+	ApplyPointChange(userID, avatarID string, event models.PointEvent) (*PointChangeResult, error)
+	// ApplyAdminAdjustment ApplyPointChangeと同じ更新に加え、同じトランザクションで監査ログを書く
+	ApplyAdminAdjustment(adminUserID, userID, avatarID string, event models.PointEvent) (*PointChangeResult, *models.PointAdjustmentAudit, error)
+	// ApplyDiagnosisResult 診断履歴の保存とポイント加算を1つのトランザクションで行う。
+	// cooldownSinceより後に同じ相手との診断があればErrRecentDiagnosisExistsを返し、何も書き込まない。
+	// event.Deltaが0ならポイントは動かさず、結果にはRelationだけを入れる
+	ApplyDiagnosisResult(history *models.DiagnosisHistory, cooldownSince time.Time, event models.PointEvent) (*PointChangeResult, error)
+	GetPointEventsByRelationID(relationID string, limit, offset int) ([]models.PointEvent, int64, error)
+	SumDeltaBySource(relationID string) (map[models.PointEventSource]int, error)
+}
+
+// ErrRecentDiagnosisExists ApplyDiagnosisResultで、同じ相手との診断が間隔をあけずに行われた
+var ErrRecentDiagnosisExists = errors.New("point event: diagnosis with the same avatar already exists in the cooldown period")
+
+// PointChangeResult ApplyPointChangeのトランザクション内で確定した結果
+type PointChangeResult struct {
+	Relation models.UserAvatarRelation
+	Event    models.PointEvent
+	// IsMatched 今回を含め、ユーザーとアバター所有者のマッチングが成立済みか
+	IsMatched bool
+	// NewMatching 今回のポイント変更で新たに成立した場合のみ非nil。通知はこれを見て1回だけ送る
+	NewMatching *models.Matching
+	// UnlockedMissions 今回のポイント変更で新たに解禁されたミッション
+	UnlockedMissions []models.Mission
+}
+
+type pointEventAdapter struct {
+	db *gorm.DB
+}
+
+func NewPointEventAdapter(db *gorm.DB) PointEventAdapter {
+	return &pointEventAdapter{db: db}
+}
+
+func (a *pointEventAdapter) ApplyPointChange(userID, avatarID string, event models.PointEvent) (*PointChangeResult, error) {
+	var result *PointChangeResult
+	err := a.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		result, err = applyPointChange(tx, userID, avatarID, event)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (a *pointEventAdapter) ApplyAdminAdjustment(adminUserID, userID, avatarID string, event models.PointEvent) (*PointChangeResult, *models.PointAdjustmentAudit, error) {
+	var result *PointChangeResult
+	var audit models.PointAdjustmentAudit
+	err := a.db.Transaction(func(tx *gorm.DB) error {
+		requestedDelta := event.Delta
+		var err error
+		result, err = applyPointChange(tx, userID, avatarID, event)
+		if err != nil {
+			return err
+		}
+
+		audit = models.PointAdjustmentAudit{
+			ID:             utils.GenerateULID(),
+			AdminUserID:    adminUserID,
+			UserID:         userID,
+			AvatarID:       avatarID,
+			PointEventID:   result.Event.ID,
+			RequestedDelta: requestedDelta,
+			Reason:         event.Reason,
+		}
+		return tx.Create(&audit).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, &audit, nil
+}
+
+func (a *pointEventAdapter) ApplyDiagnosisResult(history *models.DiagnosisHistory, cooldownSince time.Time, event models.PointEvent) (*PointChangeResult, error) {
+	var result *PointChangeResult
+	err := a.db.Transaction(func(tx *gorm.DB) error {
+		// 同じ相手との診断が同時に来ても1件だけ通るよう、Relationの行ロックで直列化してから確かめる
+		relation, err := lockRelation(tx, history.UserID, history.TargetAvatarID)
+		if err != nil {
+			return err
+		}
+		var recent int64
+		if err := tx.Model(&models.DiagnosisHistory{}).
+			Where("user_id = ? AND target_avatar_id = ? AND created_at > ?", history.UserID, history.TargetAvatarID, cooldownSince).
+			Count(&recent).Error; err != nil {
+			return err
+		}
+		if recent > 0 {
+			return ErrRecentDiagnosisExists
+		}
+
+		if err := tx.Create(history).Error; err != nil {
+			return err
+		}
+		if event.Delta == 0 {
+			result = &PointChangeResult{Relation: relation}
+			return nil
+		}
+		result, err = applyPointChange(tx, history.UserID, history.TargetAvatarID, event)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// lockRelation Relationが無ければ作成し、行ロックを取って読み直す。同じRelationへの更新はこれで直列化する
+func lockRelation(tx *gorm.DB, userID, avatarID string) (models.UserAvatarRelation, error) {
+	// 同時に初回メッセージが来てもRelationが1つになるよう、作成は一意制約に任せる
+	newRelation := models.UserAvatarRelation{
+		ID:       utils.GenerateULID(),
+		UserID:   userID,
+		AvatarID: avatarID,
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&newRelation).Error; err != nil {
+		return models.UserAvatarRelation{}, err
+	}
+
+	var relation models.UserAvatarRelation
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND avatar_id = ?", userID, avatarID).
+		First(&relation).Error; err != nil {
+		return models.UserAvatarRelation{}, err
+	}
+	return relation, nil
+}
+
+func applyPointChange(tx *gorm.DB, userID, avatarID string, event models.PointEvent) (*PointChangeResult, error) {
+	var avatar models.Avatar
+	if err := tx.Select("id", "user_id").Where("id = ?", avatarID).First(&avatar).Error; err != nil {
+		return nil, err
+	}
+
+	relation, err := lockRelation(tx, userID, avatarID)
+	if err != nil {
+		return nil, err
+	}
+
+	newPoint := clampMatchingPoint(relation.MatchingPoint + event.Delta)
+	event.Delta = newPoint - relation.MatchingPoint
+	if err := tx.Model(&relation).Update("matching_point", newPoint).Error; err != nil {
+		return nil, err
+	}
+	relation.MatchingPoint = newPoint
+
+	if event.ID == "" {
+		event.ID = utils.GenerateULID()
+	}
+	event.RelationID = relation.ID
+	event.MatchingPointAfter = newPoint
+	if err := tx.Create(&event).Error; err != nil {
+		return nil, err
+	}
+
+	result := &PointChangeResult{
+		Relation: relation,
+		Event:    event,
+	}
+	if err := applyMatching(tx, userID, avatar.UserID, newPoint, result); err != nil {
+		return nil, err
+	}
+	if err := applyMissionUnlocks(tx, userID, avatar.UserID, newPoint, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// applyMatching 上限に達していればマッチングを作成する。一意制約により二重には作られない
+func applyMatching(tx *gorm.DB, userID, avatarOwnerUserID string, newPoint int, result *PointChangeResult) error {
+	// Goの文字列比較はバイト順。DBの制約も COLLATE "C" で同じ順に比較している
+	user1ID, user2ID := userID, avatarOwnerUserID
+	if user1ID > user2ID {
+		user1ID, user2ID = user2ID, user1ID
+	}
+
+	if newPoint >= models.MaxMatchingPoint {
+		matching := models.Matching{
+			ID:      utils.GenerateULID(),
+			User1ID: user1ID,
+			User2ID: user2ID,
+		}
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&matching)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 1 {
+			result.NewMatching = &matching
+		}
+		result.IsMatched = true
+		return nil
+	}
+
+	var count int64
+	if err := tx.Model(&models.Matching{}).
+		Where("user1_id = ? AND user2_id = ?", user1ID, user2ID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	result.IsMatched = count > 0
+	return nil
+}
+
+// applyMissionUnlocks 閾値に達したミッションを解禁する。解禁済みのものは一意制約でスキップされる
+func applyMissionUnlocks(tx *gorm.DB, userID, avatarOwnerUserID string, newPoint int, result *PointChangeResult) error {
+	var missions []models.Mission
+	if err := tx.Where("mission_owner_user_id = ? AND threshold_point_condition IS NOT NULL AND threshold_point_condition <= ?", avatarOwnerUserID, newPoint).
+		Order("id").
+		Find(&missions).Error; err != nil {
+		return err
+	}
+
+	for _, mission := range missions {
+		unlock := models.MissionUnlock{
+			ID:             utils.GenerateULID(),
+			MissionID:      mission.ID,
+			UnlockedUserID: userID,
+		}
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&unlock)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 1 {
+			result.UnlockedMissions = append(result.UnlockedMissions, mission)
+		}
+	}
+	return nil
+}
+
+func (a *pointEventAdapter) GetPointEventsByRelationID(relationID string, limit, offset int) ([]models.PointEvent, int64, error) {
+	var total int64
+	if err := a.db.Model(&models.PointEvent{}).Where("relation_id = ?", relationID).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var events []models.PointEvent
+	if err := a.db.Where("relation_id = ?", relationID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&events).Error; err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+func (a *pointEventAdapter) SumDeltaBySource(relationID string) (map[models.PointEventSource]int, error) {
+	var rows []struct {
+		Source models.PointEventSource
+		Total  int
+	}
+	if err := a.db.Model(&models.PointEvent{}).
+		Select("source, COALESCE(SUM(delta), 0) AS total").
+		Where("relation_id = ?", relationID).
+		Group("source").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	sums := make(map[models.PointEventSource]int, len(rows))
+	for _, row := range rows {
+		sums[row.Source] = row.Total
+	}
+	return sums, nil
+}
+
+func clampMatchingPoint(point int) int {
+	if point < 0 {
+		return 0
+	}
+	if point > models.MaxMatchingPoint {
+		return models.MaxMatchingPoint
+	}
+	return point
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/hackathon-20260110/api/driver"
 	"github.com/hackathon-20260110/api/models"
 	"gorm.io/gorm"
 )
@@ -33,8 +32,7 @@ type ProfileAdapter interface {
 	GetMatchingScore(ctx context.Context, userID string, partnerUserID string) (int, error)
 }
 
-func NewProfileAdapter() ProfileAdapter {
-	db := driver.NewPsql()
+func NewProfileAdapter(db *gorm.DB) ProfileAdapter {
 	return &profileAdapter{db: db}
 }
 
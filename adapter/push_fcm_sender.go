@@ -0,0 +1,51 @@
+package adapter
+
+import (
+	"context"
+
+	"firebase.google.com/go/v4/messaging"
+	"github.com/hackathon-20260110/api/utils"
+)
+
+// fcmMulticastLimit SendEachForMulticastで一度に送れるトークン数の上限
+const fcmMulticastLimit = 500
+
+type fcmPushSender struct {
+	client *messaging.Client
+}
+
+func NewFCMPushSender(client *messaging.Client) PushSender {
+	return &fcmPushSender{client: client}
+}
+
+func (s *fcmPushSender) Send(ctx context.Context, tokens []string, message PushMessage) ([]string, error) {
+	var invalidTokens []string
+	for start := 0; start < len(tokens); start += fcmMulticastLimit {
+		end := min(start+fcmMulticastLimit, len(tokens))
+		batch := tokens[start:end]
+
+		res, err := s.client.SendEachForMulticast(ctx, &messaging.MulticastMessage{
+			Tokens: batch,
+			Data:   message.Data,
+			Notification: &messaging.Notification{
+				Title: message.Title,
+				Body:  message.Body,
+			},
+		})
+		if err != nil {
+			return invalidTokens, utils.WrapError(err)
+		}
+
+		for i, r := range res.Responses {
+			if r.Success {
+				continue
+			}
+			// アプリの削除やトークンの更新で使えなくなったもの。
+			// INVALID_ARGUMENTはメッセージ側の不備でも返るため、トークンの削除には使わない
+			if messaging.IsUnregistered(r.Error) || messaging.IsSenderIDMismatch(r.Error) {
+				invalidTokens = append(invalidTokens, batch[i])
+			}
+		}
+	}
+	return invalidTokens, nil
+}
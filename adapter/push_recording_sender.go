@@ -0,0 +1,61 @@
+package adapter
+
+import (
+	"context"
+	"sync"
+)
+
+// RecordedPush RecordingPushSenderが記録した1回分の送信
+type RecordedPush struct {
+	Tokens  []string
+	Message PushMessage
+}
+
+// RecordingPushSender 実際には送信せず、送信内容をメモリに記録するPushSender
+type RecordingPushSender struct {
+	mu            sync.Mutex
+	sent          []RecordedPush
+	invalidTokens map[string]bool
+}
+
+func NewRecordingPushSender() *RecordingPushSender {
+	return &RecordingPushSender{invalidTokens: make(map[string]bool)}
+}
+
+// NewRecordingPushSenderAsPushSender DIコンテナにPushSenderとして登録するためのコンストラクタ
+func NewRecordingPushSenderAsPushSender() PushSender {
+	return NewRecordingPushSender()
+}
+
+func (s *RecordingPushSender) Send(ctx context.Context, tokens []string, message PushMessage) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var invalidTokens []string
+	var delivered []string
+	for _, token := range tokens {
+		if s.invalidTokens[token] {
+			invalidTokens = append(invalidTokens, token)
+			continue
+		}
+		delivered = append(delivered, token)
+	}
+	if len(delivered) > 0 {
+		s.sent = append(s.sent, RecordedPush{Tokens: delivered, Message: message})
+	}
+	return invalidTokens, nil
+}
+
+// MarkInvalid 以降の送信でtokenをFCMが無効と判定したものとして扱う
+func (s *RecordingPushSender) MarkInvalid(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.invalidTokens[token] = true
+}
+
+// Sent これまでに記録した送信内容
+func (s *RecordingPushSender) Sent() []RecordedPush {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]RecordedPush(nil), s.sent...)
+}
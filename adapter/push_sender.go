@@ -0,0 +1,29 @@
+package adapter
+
+import (
+	"context"
+
+	"github.com/hackathon-20260110/api/config"
+)
+
+// PushProvider PUSH_PROVIDER環境変数で選択するプッシュ通知の実装
+type PushProvider string
+
+const (
+	PushProviderFCM PushProvider = config.PushProviderFCM
+	// PushProviderRecording 送信せずメモリに記録する。ローカル開発・結合テスト用
+	PushProviderRecording PushProvider = config.PushProviderRecording
+)
+
+// PushMessage 端末に届ける通知の内容。Dataはアプリが遷移先を決めるのに使う
+type PushMessage struct {
+	Title string
+	Body  string
+	Data  map[string]string
+}
+
+type PushSender interface {
+	// Send tokensの端末にmessageを送る。送信先として無効になっていたトークンをinvalidTokensで返す。
+	// 一部の端末への送信失敗はerrにせず、全体として送れなかった場合のみerrを返す
+	Send(ctx context.Context, tokens []string, message PushMessage) (invalidTokens []string, err error)
+}
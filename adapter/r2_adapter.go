@@ -5,27 +5,30 @@ import (
 	"context"
 	"errors"
 	"net/http"
-	"os"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/hackathon-20260110/api/config"
 	"github.com/hackathon-20260110/api/utils"
 )
 
 const R2BucketName = "hackathon-20260110"
 
+var ErrR2CredentialsNotSet = errors.New("r2: R2_ACCESS_KEY_ID, R2_SECRET_ACCESS_KEY and R2_ENDPOINT are required")
+
 type R2Adapter interface {
 	UploadImage(image []byte, path string, contentType string) (string, error)
 }
 
-func NewR2Adapter(s3Client *s3.Client) R2Adapter {
-	return &r2Adapter{client: s3Client}
+func NewR2Adapter(s3Client *s3.Client, cfg *config.Config) R2Adapter {
+	return &r2Adapter{client: s3Client, publicBaseURL: cfg.R2.PublicBaseURL}
 }
 
 type r2Adapter struct {
-	client *s3.Client
+	client        *s3.Client
+	publicBaseURL string
 }
 
 func (a *r2Adapter) UploadImage(image []byte, path string, contentType string) (string, error) {
@@ -49,7 +52,7 @@ func (a *r2Adapter) UploadImage(image []byte, path string, contentType string) (
 		return "", utils.WrapError(err)
 	}
 
-	return BuildPublicURL(objectKey), nil
+	return BuildPublicURL(a.publicBaseURL, objectKey), nil
 }
 
 // NormalizeObjectKey normalizes the object key by removing leading slashes
@@ -68,18 +71,20 @@ func NormalizeObjectKey(path string) (string, error) {
 	return key, nil
 }
 
-// BuildPublicURL constructs the public URL for an object key using the
-// R2_PUBLIC_BASE_URL environment variable.
-func BuildPublicURL(objectKey string) string {
-	baseURL := os.Getenv("R2_PUBLIC_BASE_URL")
+// BuildPublicURL constructs the public URL for an object key from the
+// public base URL of the bucket (R2_PUBLIC_BASE_URL).
+func BuildPublicURL(baseURL string, objectKey string) string {
 	return baseURL + "/" + objectKey
 }
 
-// NewR2ClientFromEnv creates a new S3 client for R2 using environment variables.
-func NewR2ClientFromEnv() (*s3.Client, error) {
-	accessKeyID := os.Getenv("R2_ACCESS_KEY_ID")
-	secretAccessKey := os.Getenv("R2_SECRET_ACCESS_KEY")
-	endpoint := os.Getenv("R2_ENDPOINT")
+// NewR2Client creates a new S3 client for R2 from the R2 settings in the config.
+func NewR2Client(cfg *config.Config) (*s3.Client, error) {
+	accessKeyID := cfg.R2.AccessKeyID
+	secretAccessKey := cfg.R2.SecretAccessKey
+	endpoint := cfg.R2.Endpoint
+	if accessKeyID == "" || secretAccessKey == "" || endpoint == "" {
+		return nil, ErrR2CredentialsNotSet
+	}
 
 	client := s3.New(s3.Options{
 		Region: "auto",
@@ -0,0 +1,41 @@
+package adapter
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/hackathon-20260110/api/config"
+	"github.com/hackathon-20260110/api/utils"
+)
+
+// LocalAssetsPath FLAVOR=local でlocalR2Adapterが保存した画像を配信するパス
+const LocalAssetsPath = "/local-assets"
+
+// localR2Adapter FLAVOR=local でR2の代わりに画像をディレクトリへ保存するR2Adapter。
+// 保存したファイルはAPIサーバーが LocalAssetsPath で配信する
+type localR2Adapter struct {
+	dir           string
+	publicBaseURL string
+}
+
+func NewLocalR2Adapter(cfg *config.Config) R2Adapter {
+	return &localR2Adapter{dir: cfg.Local.AssetsDir, publicBaseURL: cfg.R2.PublicBaseURL}
+}
+
+func (a *localR2Adapter) UploadImage(image []byte, path string, contentType string) (string, error) {
+	objectKey, err := NormalizeObjectKey(path)
+	if err != nil {
+		return "", utils.WrapError(err)
+	}
+
+	// 配信時のContent-Typeは拡張子から決まるので、contentTypeは保存しない
+	filePath := filepath.Join(a.dir, filepath.FromSlash(objectKey))
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return "", utils.WrapError(err)
+	}
+	if err := os.WriteFile(filePath, image, 0o644); err != nil {
+		return "", utils.WrapError(err)
+	}
+
+	return BuildPublicURL(a.publicBaseURL, objectKey), nil
+}
@@ -0,0 +1,50 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hackathon-20260110/api/config"
+)
+
+// AuthProvider AUTH_PROVIDER環境変数で選択するIDトークンの発行・検証の実装
+type AuthProvider string
+
+const (
+	AuthProviderFirebase AuthProvider = config.AuthProviderFirebase
+	// AuthProviderLocal このAPIが署名したトークンを使う。オフラインでの開発・結合テスト用
+	AuthProviderLocal AuthProvider = config.AuthProviderLocal
+)
+
+// ErrInvalidIDToken 署名・有効期限・形式のいずれかが不正なIDトークン
+var ErrInvalidIDToken = errors.New("auth: invalid ID token")
+
+// ErrTokenIssuerNotConfigured IDトークンの発行に必要な鍵（RS256の秘密鍵）が無い
+var ErrTokenIssuerNotConfigured = errors.New("auth: token issuer is not configured")
+
+// VerifiedToken 検証したIDトークンのユーザー。Roleはカスタムクレームのrole（運営ならadmin）
+type VerifiedToken struct {
+	UserID string
+	Email  string
+	Role   string
+}
+
+// IssuedToken 発行したIDトークン。ExpiresInは有効期限までの秒数
+type IssuedToken struct {
+	IDToken   string
+	ExpiresIn string
+}
+
+// TokenVerifier Authorizationヘッダーで送られたIDトークンを検証する
+type TokenVerifier interface {
+	VerifyIDToken(ctx context.Context, idToken string) (*VerifiedToken, error)
+}
+
+// TokenIssuer 開発・テスト用に、任意のユーザーとしてTokenVerifierを通るIDトークンを発行する（/debug/id-token）。
+// AUTH_PROVIDER=local の実装だけが満たす。Firebaseのトークンやroleは、APIからは発行しない
+type TokenIssuer interface {
+	// IssueIDToken userIDのIDトークンをroleクレーム無しで発行する
+	IssueIDToken(ctx context.Context, userID string) (*IssuedToken, error)
+	// IssueIDTokenWithRole roleが空でなければroleクレームを付けて発行する
+	IssueIDTokenWithRole(ctx context.Context, userID string, role string) (*IssuedToken, error)
+}
@@ -0,0 +1,34 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+
+	"firebase.google.com/go/v4/auth"
+)
+
+// FirebaseTokenAuth Firebase AuthenticationのIDトークンを検証する。トークンの発行はクライアントのFirebase SDKが行う
+type FirebaseTokenAuth struct {
+	client *auth.Client
+}
+
+func NewFirebaseTokenAuth(client *auth.Client) *FirebaseTokenAuth {
+	return &FirebaseTokenAuth{client: client}
+}
+
+// VerifyIDToken roleはFirebase Admin SDKのSetCustomUserClaimsで {"role": "admin"} のように付与する
+func (a *FirebaseTokenAuth) VerifyIDToken(ctx context.Context, idToken string) (*VerifiedToken, error) {
+	decodedToken, err := a.client.VerifyIDToken(ctx, idToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	verified := &VerifiedToken{UserID: decodedToken.UID}
+	if email, ok := decodedToken.Claims["email"].(string); ok {
+		verified.Email = email
+	}
+	if role, ok := decodedToken.Claims["role"].(string); ok {
+		verified.Role = role
+	}
+	return verified, nil
+}
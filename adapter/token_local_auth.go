@@ -0,0 +1,226 @@
+package adapter
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hackathon-20260110/api/config"
+	"github.com/hackathon-20260110/api/utils"
+)
+
+// localTokenIssuer LocalTokenAuthが発行するトークンのissクレーム。Firebaseのトークンと取り違えないよう検証時にも確認する
+const localTokenIssuer = "hackathon-api-local"
+
+type localTokenHeader struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+}
+
+type localTokenClaims struct {
+	Issuer    string `json:"iss"`
+	Subject   string `json:"sub"`
+	Email     string `json:"email,omitempty"`
+	Role      string `json:"role,omitempty"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// LocalTokenAuth AUTH_PROVIDER=local で使う、このAPIが署名したJWTを発行・検証する実装。
+// HS256は共有の鍵、RS256はPEMの鍵で署名する。RS256で公開鍵しか無い場合は検証だけできる
+type LocalTokenAuth struct {
+	algorithm  string
+	secret     []byte
+	privateKey *rsa.PrivateKey
+	publicKey  *rsa.PublicKey
+	ttl        time.Duration
+}
+
+func NewLocalTokenAuth(cfg *config.Config) (*LocalTokenAuth, error) {
+	local := cfg.Auth.Local
+	a := &LocalTokenAuth{algorithm: local.Algorithm, ttl: local.TokenTTL}
+
+	switch local.Algorithm {
+	case config.LocalAuthHS256:
+		if local.Secret == "" {
+			return nil, errors.New("local auth: LOCAL_AUTH_SECRET is required for HS256")
+		}
+		a.secret = []byte(local.Secret)
+	case config.LocalAuthRS256:
+		if local.PrivateKey != "" {
+			privateKey, err := parseRSAPrivateKey(local.PrivateKey)
+			if err != nil {
+				return nil, fmt.Errorf("local auth: invalid LOCAL_AUTH_PRIVATE_KEY: %w", err)
+			}
+			a.privateKey = privateKey
+			a.publicKey = &privateKey.PublicKey
+		} else {
+			publicKey, err := parseRSAPublicKey(local.PublicKey)
+			if err != nil {
+				return nil, fmt.Errorf("local auth: invalid LOCAL_AUTH_PUBLIC_KEY: %w", err)
+			}
+			a.publicKey = publicKey
+		}
+	default:
+		return nil, fmt.Errorf("local auth: unknown algorithm %q", local.Algorithm)
+	}
+	return a, nil
+}
+
+func (a *LocalTokenAuth) IssueIDToken(ctx context.Context, userID string) (*IssuedToken, error) {
+	return a.IssueIDTokenWithRole(ctx, userID, "")
+}
+
+func (a *LocalTokenAuth) IssueIDTokenWithRole(ctx context.Context, userID string, role string) (*IssuedToken, error) {
+	if a.algorithm == config.LocalAuthRS256 && a.privateKey == nil {
+		return nil, ErrTokenIssuerNotConfigured
+	}
+	if userID == "" {
+		return nil, errors.New("local auth: user ID is empty")
+	}
+
+	now := time.Now()
+	header, err := json.Marshal(localTokenHeader{Algorithm: a.algorithm, Type: "JWT"})
+	if err != nil {
+		return nil, utils.WrapError(err)
+	}
+	claims, err := json.Marshal(localTokenClaims{
+		Issuer:    localTokenIssuer,
+		Subject:   userID,
+		Role:      role,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(a.ttl).Unix(),
+	})
+	if err != nil {
+		return nil, utils.WrapError(err)
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	signature, err := a.sign(signingInput)
+	if err != nil {
+		return nil, utils.WrapError(err)
+	}
+	return &IssuedToken{
+		IDToken:   signingInput + "." + base64.RawURLEncoding.EncodeToString(signature),
+		ExpiresIn: strconv.FormatInt(int64(a.ttl/time.Second), 10),
+	}, nil
+}
+
+func (a *LocalTokenAuth) VerifyIDToken(ctx context.Context, idToken string) (*VerifiedToken, error) {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidIDToken
+	}
+
+	var header localTokenHeader
+	if err := decodeTokenPart(parts[0], &header); err != nil {
+		return nil, ErrInvalidIDToken
+	}
+	// algはトークン側ではなく設定で決める（"none"や別方式へのすり替えを受け付けない）
+	if header.Algorithm != a.algorithm {
+		return nil, ErrInvalidIDToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidIDToken
+	}
+	if !a.verifySignature(parts[0]+"."+parts[1], signature) {
+		return nil, ErrInvalidIDToken
+	}
+
+	var claims localTokenClaims
+	if err := decodeTokenPart(parts[1], &claims); err != nil {
+		return nil, ErrInvalidIDToken
+	}
+	if claims.Issuer != localTokenIssuer || claims.Subject == "" || time.Now().Unix() >= claims.ExpiresAt {
+		return nil, ErrInvalidIDToken
+	}
+	return &VerifiedToken{UserID: claims.Subject, Email: claims.Email, Role: claims.Role}, nil
+}
+
+func (a *LocalTokenAuth) sign(signingInput string) ([]byte, error) {
+	if a.algorithm == config.LocalAuthHS256 {
+		mac := hmac.New(sha256.New, a.secret)
+		mac.Write([]byte(signingInput))
+		return mac.Sum(nil), nil
+	}
+	digest := sha256.Sum256([]byte(signingInput))
+	return rsa.SignPKCS1v15(nil, a.privateKey, crypto.SHA256, digest[:])
+}
+
+func (a *LocalTokenAuth) verifySignature(signingInput string, signature []byte) bool {
+	if a.algorithm == config.LocalAuthHS256 {
+		expected, _ := a.sign(signingInput)
+		return hmac.Equal(signature, expected)
+	}
+	digest := sha256.Sum256([]byte(signingInput))
+	return rsa.VerifyPKCS1v15(a.publicKey, crypto.SHA256, digest[:], signature) == nil
+}
+
+func decodeTokenPart(part string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// decodePEM 環境変数では改行を \n と書くことが多いので、実際の改行に戻してから読む
+func decodePEM(value string) (*pem.Block, error) {
+	block, _ := pem.Decode([]byte(strings.ReplaceAll(value, `\n`, "\n")))
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	return block, nil
+}
+
+// parseRSAPrivateKey PKCS#1（BEGIN RSA PRIVATE KEY）とPKCS#8（BEGIN PRIVATE KEY）のどちらも受け付ける
+func parseRSAPrivateKey(value string) (*rsa.PrivateKey, error) {
+	block, err := decodePEM(value)
+	if err != nil {
+		return nil, err
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("not an RSA private key")
+	}
+	return rsaKey, nil
+}
+
+// parseRSAPublicKey PKIX（BEGIN PUBLIC KEY）とPKCS#1（BEGIN RSA PUBLIC KEY）のどちらも受け付ける
+func parseRSAPublicKey(value string) (*rsa.PublicKey, error) {
+	block, err := decodePEM(value)
+	if err != nil {
+		return nil, err
+	}
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("not an RSA public key")
+	}
+	return rsaKey, nil
+}
@@ -1,7 +1,6 @@
 package adapter
 
 import (
-	"github.com/hackathon-20260110/api/driver"
 	"github.com/hackathon-20260110/api/utils"
 
 	"github.com/hackathon-20260110/api/models"
@@ -10,12 +9,13 @@ import (
 
 type UserAdapter interface {
 	GetByID(id string) (models.User, error)
+	// GetByIDs idsのユーザーを返す。見つからないIDは結果に含まれない。順序は保証しない
+	GetByIDs(ids []string) ([]models.User, error)
 	Create(user models.User) (models.User, error)
 	Update(user models.User) (models.User, error)
 }
 
-func NewUserAdapter() UserAdapter {
-	db := driver.NewPsql()
+func NewUserAdapter(db *gorm.DB) UserAdapter {
 	return &userAdapter{db: db}
 }
 
@@ -31,6 +31,17 @@ func (a *userAdapter) GetByID(id string) (models.User, error) {
 	return user, nil
 }
 
+func (a *userAdapter) GetByIDs(ids []string) ([]models.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var users []models.User
+	if err := a.db.Where("id IN ?", ids).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
 func (a *userAdapter) Create(user models.User) (models.User, error) {
 	if err := a.db.Create(&user).Error; err != nil {
 		return models.User{}, err
@@ -19,7 +19,10 @@ type UserChatMessage struct {
 
 type UserChatAdapter interface {
 	CreateUserChatMessage(ctx context.Context, user1ID string, user2ID string, message UserChatMessage) error
-	GetUserChatMessages(ctx context.Context, user1ID string, user2ID string) ([]UserChatMessage, error)
+	// GetUserChatMessages pageの範囲のメッセージを古い順に返す。続きがあれば次のカーソルを返す
+	GetUserChatMessages(ctx context.Context, user1ID string, user2ID string, page ChatPage) (messages []UserChatMessage, nextCursor string, err error)
+	// CountMessagesFrom senderIDが送ったメッセージのうち、afterより新しいものの件数を返す
+	CountMessagesFrom(ctx context.Context, user1ID string, user2ID string, senderID string, after string) (int, error)
 }
 
 type userChatAdapter struct {
@@ -46,7 +49,15 @@ func (a *userChatAdapter) CreateUserChatMessage(ctx context.Context, user1ID str
 
 	doc := col.Doc(message.ID)
 
-	_, err := doc.Set(ctx, message)
+	recipientID := user1ID
+	if message.SenderID == user1ID {
+		recipientID = user2ID
+	}
+
+	err := writeChatMessage(ctx, a.client, doc, message, message.ID, message.Message, message.CreatedAt, []chatSummaryUpdate{
+		{ref: chatSummaryRef(a.client, message.SenderID, models.ChatKindUser, recipientID), kind: models.ChatKindUser, partnerID: recipientID, read: true},
+		{ref: chatSummaryRef(a.client, recipientID, models.ChatKindUser, message.SenderID), kind: models.ChatKindUser, partnerID: message.SenderID, unread: true},
+	})
 	if err != nil {
 		return utils.WrapError(err)
 	}
@@ -54,15 +65,14 @@ func (a *userChatAdapter) CreateUserChatMessage(ctx context.Context, user1ID str
 	return nil
 }
 
-func (a *userChatAdapter) GetUserChatMessages(ctx context.Context, user1ID string, user2ID string) ([]UserChatMessage, error) {
+func (a *userChatAdapter) GetUserChatMessages(ctx context.Context, user1ID string, user2ID string, page ChatPage) ([]UserChatMessage, string, error) {
 	normalizedUser1, normalizedUser2 := normalizeUserIDs(user1ID, user2ID)
 
-	query := a.client.Collection("user_chat").Doc(normalizedUser1).Collection(normalizedUser2).
-		OrderBy("created_at", firestore.Asc)
+	col := a.client.Collection("user_chat").Doc(normalizedUser1).Collection(normalizedUser2)
 
-	docs, err := query.Documents(ctx).GetAll()
+	docs, nextCursor, err := getChatPage(ctx, col, page)
 	if err != nil {
-		return nil, utils.WrapError(err)
+		return nil, "", utils.WrapError(err)
 	}
 
 	messages := make([]UserChatMessage, 0, len(docs))
@@ -84,5 +94,17 @@ func (a *userChatAdapter) GetUserChatMessages(ctx context.Context, user1ID strin
 		})
 	}
 
-	return messages, nil
+	return messages, nextCursor, nil
+}
+
+func (a *userChatAdapter) CountMessagesFrom(ctx context.Context, user1ID string, user2ID string, senderID string, after string) (int, error) {
+	normalizedUser1, normalizedUser2 := normalizeUserIDs(user1ID, user2ID)
+
+	col := a.client.Collection("user_chat").Doc(normalizedUser1).Collection(normalizedUser2)
+
+	count, err := countChatMessagesAfter(ctx, col.Where("sender_id", "==", senderID), after)
+	if err != nil {
+		return 0, utils.WrapError(err)
+	}
+	return count, nil
 }
@@ -0,0 +1,58 @@
+package adapter
+
+import (
+	"context"
+
+	"github.com/hackathon-20260110/api/models"
+)
+
+// inMemoryUserChatAdapter FLAVOR=local で使う、InMemoryStoreにメッセージを置くUserChatAdapter
+type inMemoryUserChatAdapter struct {
+	store *InMemoryStore
+}
+
+func NewInMemoryUserChatAdapter(store *InMemoryStore) UserChatAdapter {
+	return &inMemoryUserChatAdapter{store: store}
+}
+
+func userChatMessageID(message UserChatMessage) string {
+	return message.ID
+}
+
+func userChatKey(user1ID string, user2ID string) [2]string {
+	normalizedUser1, normalizedUser2 := normalizeUserIDs(user1ID, user2ID)
+	return [2]string{normalizedUser1, normalizedUser2}
+}
+
+func (a *inMemoryUserChatAdapter) CreateUserChatMessage(ctx context.Context, user1ID string, user2ID string, message UserChatMessage) error {
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	key := userChatKey(user1ID, user2ID)
+	a.store.userChats[key] = insertByID(a.store.userChats[key], message, userChatMessageID)
+
+	recipientID := user1ID
+	if message.SenderID == user1ID {
+		recipientID = user2ID
+	}
+	a.store.updateChatSummary(message.SenderID, models.ChatKindUser, recipientID, message.ID, message.Message, message.CreatedAt, false, true)
+	a.store.updateChatSummary(recipientID, models.ChatKindUser, message.SenderID, message.ID, message.Message, message.CreatedAt, true, false)
+	return nil
+}
+
+func (a *inMemoryUserChatAdapter) GetUserChatMessages(ctx context.Context, user1ID string, user2ID string, page ChatPage) ([]UserChatMessage, string, error) {
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	messages, nextCursor := pageByID(a.store.userChats[userChatKey(user1ID, user2ID)], userChatMessageID, page)
+	return messages, nextCursor, nil
+}
+
+func (a *inMemoryUserChatAdapter) CountMessagesFrom(ctx context.Context, user1ID string, user2ID string, senderID string, after string) (int, error) {
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	return countAfterID(a.store.userChats[userChatKey(user1ID, user2ID)], userChatMessageID, after, func(message UserChatMessage) bool {
+		return message.SenderID == senderID
+	}), nil
+}
@@ -0,0 +1,441 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// Flavor 実行環境。DBの接続先と、外部サービスの既定の実装を決める
+type Flavor string
+
+const (
+	// FlavorLocal 手元のPCやdocker composeでの開発用。DBはdevと同じくDB_HOSTなどで指定し、
+	// Firebase・Firestore・Gemini・R2の代わりにプロセス内の実装を使うのでネットワークに出ない
+	FlavorLocal Flavor = "local"
+	// FlavorDev devcontainerなどの開発環境。DBはDB_HOSTなどで指定する
+	FlavorDev Flavor = "dev"
+	// FlavorPrd 本番環境。DBはNeonのDATABASE_URLで指定する
+	FlavorPrd Flavor = "prd"
+)
+
+// LLM_PROVIDER・PUSH_PROVIDER に指定できる値
+const (
+	LLMProviderGemini     = "gemini"
+	LLMProviderScripted   = "scripted"
+	PushProviderFCM       = "fcm"
+	PushProviderRecording = "recording"
+)
+
+// AUTH_PROVIDER・LOCAL_AUTH_ALGORITHM に指定できる値
+const (
+	AuthProviderFirebase = "firebase"
+	AuthProviderLocal    = "local"
+	LocalAuthHS256       = "HS256"
+	LocalAuthRS256       = "RS256"
+)
+
+// Config 環境変数から読んだ設定。起動時に1度だけ読み、検証してから各ドライバー・アダプターに渡す
+type Config struct {
+	Flavor Flavor
+	// Port APIサーバーが待ち受けるポート（PORT、デフォルト8080）
+	Port     string
+	Database DatabaseConfig
+	Firebase FirebaseConfig
+	Auth     AuthConfig
+	GenAI    GenAIConfig
+	R2       R2Config
+	// LLMProvider LLMの実装（LLM_PROVIDER、localではscripted、それ以外ではgeminiがデフォルト）
+	LLMProvider string
+	// PushProvider プッシュ通知の実装（PUSH_PROVIDER、localではrecording、それ以外ではfcmがデフォルト）
+	PushProvider string
+	// JobWorkerCount バックグラウンドジョブのワーカー数（JOB_WORKER_COUNT、デフォルト2）
+	JobWorkerCount int
+	Local          LocalConfig
+}
+
+// LocalConfig FLAVOR=local でだけ使う設定
+type LocalConfig struct {
+	// AssetsDir R2の代わりに画像を保存するディレクトリ（LOCAL_ASSETS_DIR、デフォルト tmp/local-assets）。/local-assets で配信する
+	AssetsDir string
+}
+
+// AuthConfig IDトークンの発行・検証の設定
+type AuthConfig struct {
+	// Provider IDトークンの実装（AUTH_PROVIDER、localではlocal、それ以外ではfirebaseがデフォルト）。
+	// localはこのAPIが署名したトークンを受け付けるので、prdでは選べない
+	Provider string
+	Local    LocalAuthConfig
+}
+
+// LocalAuthConfig AUTH_PROVIDER=local でトークンを署名・検証する鍵
+type LocalAuthConfig struct {
+	// Algorithm 署名方式（LOCAL_AUTH_ALGORITHM、HS256かRS256、デフォルトHS256）
+	Algorithm string
+	// Secret HS256の鍵（LOCAL_AUTH_SECRET、FLAVOR=localではデフォルトあり）
+	Secret string
+	// PrivateKey RS256で発行と検証に使うPEMの秘密鍵（LOCAL_AUTH_PRIVATE_KEY）
+	PrivateKey string
+	// PublicKey RS256で検証だけするときのPEMの公開鍵（LOCAL_AUTH_PUBLIC_KEY）。秘密鍵があれば不要
+	PublicKey string
+	// TokenTTL 発行するトークンの有効期限（LOCAL_AUTH_TOKEN_TTL、デフォルト1h）
+	TokenTTL time.Duration
+}
+
+// DatabaseConfig PostgreSQLの接続先とコネクションプールの設定
+type DatabaseConfig struct {
+	Host     string
+	Port     string
+	User     string
+	Password string
+	Name     string
+	// URL prdで使う接続文字列（DATABASE_URL）
+	URL string
+
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+// FirebaseConfig 認証・Firestore・FCMで共有するFirebaseの設定
+type FirebaseConfig struct {
+	ServiceAccount ServiceAccount
+}
+
+// ServiceAccount FIREBASE_SA_* から組み立てるサービスアカウントの認証情報。JSONにしてFirebase Admin SDKに渡す
+type ServiceAccount struct {
+	Type                    string `json:"type"`
+	ProjectID               string `json:"project_id"`
+	PrivateKeyID            string `json:"private_key_id"`
+	PrivateKey              string `json:"private_key"`
+	ClientEmail             string `json:"client_email"`
+	ClientID                string `json:"client_id"`
+	AuthURI                 string `json:"auth_uri"`
+	TokenURI                string `json:"token_uri"`
+	AuthProviderX509CertURL string `json:"auth_provider_x509_cert_url"`
+	ClientX509CertURL       string `json:"client_x509_cert_url"`
+	UniverseDomain          string `json:"universe_domain"`
+}
+
+// GenAIConfig Gemini APIの設定
+type GenAIConfig struct {
+	// APIKey GOOGLE_API_KEY、無ければ GEMINI_API_KEY
+	APIKey string
+}
+
+// R2Config 画像をアップロードするCloudflare R2の設定
+type R2Config struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	Endpoint        string
+	// PublicBaseURL アップロードした画像の公開URLの前半（R2_PUBLIC_BASE_URL）。
+	// localでは /local-assets を指すURLがデフォルト
+	PublicBaseURL string
+}
+
+// Load .env（あれば）と環境変数から設定を読み、APIサーバーの起動に必要な値をすべて検証する。
+// 足りない値・不正な値はまとめて1つのエラーで返す
+func Load() (*Config, error) {
+	cfg, errs := read()
+	errs = append(errs, cfg.validate()...)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid configuration:\n%w", errors.Join(errs...))
+	}
+	return cfg, nil
+}
+
+// LoadDatabase Load と同じように読むが、DBの設定だけを検証する。マイグレーションやシードなどのツール用
+func LoadDatabase() (*Config, error) {
+	cfg, errs := read()
+	errs = append(errs, cfg.validateDatabase()...)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid configuration:\n%w", errors.Join(errs...))
+	}
+	return cfg, nil
+}
+
+// read .envと環境変数を読む。既に設定されている環境変数は.envで上書きしない
+func read() (*Config, []error) {
+	var errs []error
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		errs = append(errs, fmt.Errorf("failed to load .env: %w", err))
+	}
+
+	flavor := Flavor(os.Getenv("FLAVOR"))
+	port := envString("PORT", "8080")
+	cfg := &Config{
+		Flavor: flavor,
+		Port:   port,
+		Database: DatabaseConfig{
+			Host:            os.Getenv("DB_HOST"),
+			Port:            os.Getenv("DB_PORT"),
+			User:            os.Getenv("DB_USER"),
+			Password:        os.Getenv("DB_PASSWORD"),
+			Name:            os.Getenv("DB_NAME"),
+			URL:             os.Getenv("DATABASE_URL"),
+			MaxOpenConns:    envInt("DB_MAX_OPEN_CONNS", 10, &errs),
+			MaxIdleConns:    envInt("DB_MAX_IDLE_CONNS", 5, &errs),
+			ConnMaxLifetime: envDuration("DB_CONN_MAX_LIFETIME", 30*time.Minute, &errs),
+			ConnMaxIdleTime: envDuration("DB_CONN_MAX_IDLE_TIME", 5*time.Minute, &errs),
+		},
+		Firebase: FirebaseConfig{
+			ServiceAccount: ServiceAccount{
+				Type:                    os.Getenv("FIREBASE_SA_TYPE"),
+				ProjectID:               os.Getenv("FIREBASE_SA_PROJECT_ID"),
+				PrivateKeyID:            os.Getenv("FIREBASE_SA_PRIVATE_KEY_ID"),
+				PrivateKey:              os.Getenv("FIREBASE_SA_PRIVATE_KEY"),
+				ClientEmail:             os.Getenv("FIREBASE_SA_CLIENT_EMAIL"),
+				ClientID:                os.Getenv("FIREBASE_SA_CLIENT_ID"),
+				AuthURI:                 os.Getenv("FIREBASE_SA_AUTH_URI"),
+				TokenURI:                os.Getenv("FIREBASE_SA_TOKEN_URI"),
+				AuthProviderX509CertURL: os.Getenv("FIREBASE_SA_AUTH_PROVIDER_X509_CERT_URL"),
+				ClientX509CertURL:       os.Getenv("FIREBASE_SA_CLIENT_X509_CERT_URL"),
+				UniverseDomain:          os.Getenv("FIREBASE_SA_UNIVERSE_DOMAIN"),
+			},
+		},
+		Auth: AuthConfig{
+			Provider: envString("AUTH_PROVIDER", defaultAuthProvider(flavor)),
+			Local: LocalAuthConfig{
+				Algorithm:  envString("LOCAL_AUTH_ALGORITHM", LocalAuthHS256),
+				Secret:     envString("LOCAL_AUTH_SECRET", defaultLocalAuthSecret(flavor)),
+				PrivateKey: os.Getenv("LOCAL_AUTH_PRIVATE_KEY"),
+				PublicKey:  os.Getenv("LOCAL_AUTH_PUBLIC_KEY"),
+				TokenTTL:   envDuration("LOCAL_AUTH_TOKEN_TTL", time.Hour, &errs),
+			},
+		},
+		GenAI: GenAIConfig{
+			APIKey: envString("GOOGLE_API_KEY", os.Getenv("GEMINI_API_KEY")),
+		},
+		R2: R2Config{
+			AccessKeyID:     os.Getenv("R2_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("R2_SECRET_ACCESS_KEY"),
+			Endpoint:        os.Getenv("R2_ENDPOINT"),
+			PublicBaseURL:   envString("R2_PUBLIC_BASE_URL", defaultPublicBaseURL(flavor, port)),
+		},
+		LLMProvider:    envString("LLM_PROVIDER", defaultLLMProvider(flavor)),
+		PushProvider:   envString("PUSH_PROVIDER", defaultPushProvider(flavor)),
+		JobWorkerCount: envInt("JOB_WORKER_COUNT", 2, &errs),
+		Local: LocalConfig{
+			AssetsDir: envString("LOCAL_ASSETS_DIR", "tmp/local-assets"),
+		},
+	}
+	return cfg, errs
+}
+
+// IsLocal Firebase・Firestore・Gemini・R2の代わりにプロセス内の実装を使うか
+func (c *Config) IsLocal() bool {
+	return c.Flavor == FlavorLocal
+}
+
+// localでは外部サービスに出ない実装をデフォルトにする
+func defaultLLMProvider(flavor Flavor) string {
+	if flavor == FlavorLocal {
+		return LLMProviderScripted
+	}
+	return LLMProviderGemini
+}
+
+func defaultPushProvider(flavor Flavor) string {
+	if flavor == FlavorLocal {
+		return PushProviderRecording
+	}
+	return PushProviderFCM
+}
+
+func defaultAuthProvider(flavor Flavor) string {
+	if flavor == FlavorLocal {
+		return AuthProviderLocal
+	}
+	return AuthProviderFirebase
+}
+
+func defaultPublicBaseURL(flavor Flavor, port string) string {
+	if flavor == FlavorLocal {
+		return "http://localhost:" + port + "/local-assets"
+	}
+	return ""
+}
+
+// defaultLocalAuthSecret localの開発用トークンは手元でしか受け付けないので、鍵を設定しなくても起動できるようにする
+func defaultLocalAuthSecret(flavor Flavor) string {
+	if flavor == FlavorLocal {
+		return "local-dev-secret"
+	}
+	return ""
+}
+
+func (c *Config) validate() []error {
+	errs := c.validateDatabase()
+
+	if port, err := strconv.Atoi(c.Port); err != nil || port < 1 || port > 65535 {
+		errs = append(errs, fmt.Errorf("PORT: %q is not a valid port", c.Port))
+	}
+
+	if c.IsLocal() {
+		errs = appendRequired(errs, map[string]string{
+			"LOCAL_ASSETS_DIR": c.Local.AssetsDir,
+		})
+	} else {
+		sa := c.Firebase.ServiceAccount
+		errs = appendRequired(errs, map[string]string{
+			"FIREBASE_SA_PROJECT_ID":   sa.ProjectID,
+			"FIREBASE_SA_PRIVATE_KEY":  sa.PrivateKey,
+			"FIREBASE_SA_CLIENT_EMAIL": sa.ClientEmail,
+		})
+		errs = appendRequired(errs, map[string]string{
+			"R2_ACCESS_KEY_ID":     c.R2.AccessKeyID,
+			"R2_SECRET_ACCESS_KEY": c.R2.SecretAccessKey,
+			"R2_ENDPOINT":          c.R2.Endpoint,
+		})
+	}
+	errs = appendRequired(errs, map[string]string{
+		"R2_PUBLIC_BASE_URL": c.R2.PublicBaseURL,
+	})
+
+	errs = append(errs, c.validateAuth()...)
+
+	switch c.LLMProvider {
+	case LLMProviderGemini:
+		if c.GenAI.APIKey == "" {
+			errs = append(errs, errors.New("GOOGLE_API_KEY or GEMINI_API_KEY: required when LLM_PROVIDER is gemini"))
+		}
+	case LLMProviderScripted:
+	default:
+		errs = append(errs, fmt.Errorf("LLM_PROVIDER: unknown provider %q (expected 'gemini' or 'scripted')", c.LLMProvider))
+	}
+
+	switch c.PushProvider {
+	case PushProviderFCM:
+		// FCMはFirebaseのサービスアカウントで送るので、Firebaseを使わないlocalでは選べない
+		if c.IsLocal() {
+			errs = append(errs, errors.New("PUSH_PROVIDER: fcm is not available in local flavor (use 'recording')"))
+		}
+	case PushProviderRecording:
+	default:
+		errs = append(errs, fmt.Errorf("PUSH_PROVIDER: unknown provider %q (expected 'fcm' or 'recording')", c.PushProvider))
+	}
+
+	if c.JobWorkerCount < 1 {
+		errs = append(errs, fmt.Errorf("JOB_WORKER_COUNT: must be at least 1, got %d", c.JobWorkerCount))
+	}
+	return errs
+}
+
+func (c *Config) validateAuth() []error {
+	var errs []error
+	switch c.Auth.Provider {
+	case AuthProviderFirebase:
+		if c.IsLocal() {
+			errs = append(errs, errors.New("AUTH_PROVIDER: firebase is not available in local flavor (use 'local')"))
+		}
+	case AuthProviderLocal:
+		// 鍵を知っていれば誰にでもなりすませるので、本番では使わせない
+		if c.Flavor == FlavorPrd {
+			errs = append(errs, errors.New("AUTH_PROVIDER: local is not allowed in prd flavor"))
+		}
+		local := c.Auth.Local
+		switch local.Algorithm {
+		case LocalAuthHS256:
+			errs = appendRequired(errs, map[string]string{
+				"LOCAL_AUTH_SECRET": local.Secret,
+			})
+		case LocalAuthRS256:
+			if local.PrivateKey == "" && local.PublicKey == "" {
+				errs = append(errs, errors.New("LOCAL_AUTH_PRIVATE_KEY or LOCAL_AUTH_PUBLIC_KEY: required when LOCAL_AUTH_ALGORITHM is RS256"))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("LOCAL_AUTH_ALGORITHM: unknown algorithm %q (expected 'HS256' or 'RS256')", local.Algorithm))
+		}
+		if local.TokenTTL <= 0 {
+			errs = append(errs, fmt.Errorf("LOCAL_AUTH_TOKEN_TTL: must be positive, got %s", local.TokenTTL))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("AUTH_PROVIDER: unknown provider %q (expected 'firebase' or 'local')", c.Auth.Provider))
+	}
+	return errs
+}
+
+func (c *Config) validateDatabase() []error {
+	var errs []error
+	switch c.Flavor {
+	case FlavorLocal, FlavorDev:
+		errs = appendRequired(errs, map[string]string{
+			"DB_HOST": c.Database.Host,
+			"DB_PORT": c.Database.Port,
+			"DB_USER": c.Database.User,
+			"DB_NAME": c.Database.Name,
+		})
+	case FlavorPrd:
+		errs = appendRequired(errs, map[string]string{
+			"DATABASE_URL": c.Database.URL,
+		})
+	default:
+		errs = append(errs, fmt.Errorf("FLAVOR: unknown flavor %q (expected 'local', 'dev' or 'prd')", c.Flavor))
+	}
+
+	if c.Database.MaxOpenConns < 0 {
+		errs = append(errs, fmt.Errorf("DB_MAX_OPEN_CONNS: must not be negative, got %d", c.Database.MaxOpenConns))
+	}
+	if c.Database.MaxIdleConns < 0 {
+		errs = append(errs, fmt.Errorf("DB_MAX_IDLE_CONNS: must not be negative, got %d", c.Database.MaxIdleConns))
+	}
+	return errs
+}
+
+// appendRequired 空の値を環境変数名の順にエラーとして足す
+func appendRequired(errs []error, values map[string]string) []error {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if values[key] == "" {
+			errs = append(errs, fmt.Errorf("%s: required", key))
+		}
+	}
+	return errs
+}
+
+// envString 環境変数を読む。未設定ならdefaultValueを返す
+func envString(key string, defaultValue string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return defaultValue
+}
+
+// envInt 環境変数を整数として読む。未設定ならdefaultValueを返し、不正な値ならerrsに足す
+func envInt(key string, defaultValue int, errs *[]error) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return defaultValue
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("%s: %q is not an integer", key, v))
+		return defaultValue
+	}
+	return n
+}
+
+// envDuration 環境変数を "30m" のような時間として読む。未設定ならdefaultValueを返し、不正な値ならerrsに足す
+func envDuration(key string, defaultValue time.Duration, errs *[]error) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("%s: %q is not a duration such as 30m", key, v))
+		return defaultValue
+	}
+	return d
+}
@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/hackathon-20260110/api/middleware"
+	"github.com/hackathon-20260110/api/requests"
+	"github.com/hackathon-20260110/api/response"
+	"github.com/hackathon-20260110/api/service"
+	"github.com/hackathon-20260110/api/utils"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type AdminController struct {
+	adminService *service.AdminService
+}
+
+func NewAdminController(adminService *service.AdminService) *AdminController {
+	return &AdminController{
+		adminService: adminService,
+	}
+}
+
+// @Summary マッチングポイント調整（運営用）
+// @Tags admin
+// @Description 指定ユーザーと指定アバターのマッチングポイントを運営が増減させる。roleクレームがadminのトークンのみ実行でき、理由とともに監査ログに記録される
+// @Security Bearer
+// @Param userId path string true "ポイントを調整するユーザーID"
+// @Param avatarId path string true "アバターID（ULID）"
+// @Param body body requests.AdjustMatchingPointRequest true "増減させるポイントと理由"
+// @Success 200 {object} response.AdjustMatchingPointResponse "マッチングポイント調整成功"
+// @Failure 400 {object} response.ErrorResponse "リクエストが不正"
+// @Failure 401 {object} response.ErrorResponse "認証されていない、またはトークンが不正"
+// @Failure 403 {object} response.ErrorResponse "運営権限がない"
+// @Failure 404 {object} response.ErrorResponse "ユーザーまたはアバターが見つからない"
+// @Router /admin/users/{userId}/avatars/{avatarId}/matching-points [post]
+func (c AdminController) AdjustMatchingPoint(ctx echo.Context) error {
+	adminUserID := middleware.GetFirebaseUID(ctx)
+	if adminUserID == "" {
+		return ctx.JSON(http.StatusUnauthorized, &response.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "認証されていない、またはトークンが不正",
+		})
+	}
+	userID := ctx.Param("userId")
+	avatarID := ctx.Param("avatarId")
+
+	var req requests.AdjustMatchingPointRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+			Error:   "bad_request",
+			Message: "リクエストが不正です",
+		})
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Points == 0 {
+		return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+			Error:   "bad_request",
+			Message: "pointsには0以外の値を指定してください",
+		})
+	}
+	if req.Reason == "" {
+		return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+			Error:   "bad_request",
+			Message: "reasonは必須です",
+		})
+	}
+
+	result, err := c.adminService.AdjustMatchingPoint(ctx.Request().Context(), adminUserID, userID, avatarID, req.Points, req.Reason)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, utils.ErrorRecordNotFound) {
+			return ctx.JSON(http.StatusNotFound, &response.ErrorResponse{
+				Error:   "not_found",
+				Message: "ユーザーまたはアバターが見つかりません",
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "マッチングポイントの調整に失敗しました",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, result)
+}
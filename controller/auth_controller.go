@@ -6,15 +6,16 @@ import (
 	"github.com/hackathon-20260110/api/response"
 	"github.com/hackathon-20260110/api/service"
 	"github.com/labstack/echo/v4"
-	"go.uber.org/dig"
 )
 
 type AuthController struct {
-	container *dig.Container
+	userService *service.UserService
 }
 
-func NewAuthController(container *dig.Container) *AuthController {
-	return &AuthController{container: container}
+func NewAuthController(userService *service.UserService) *AuthController {
+	return &AuthController{
+		userService: userService,
+	}
 }
 
 // @Summary ログイン中ユーザー取得
@@ -34,8 +35,7 @@ func (c *AuthController) Me(ctx echo.Context) error {
 		})
 	}
 
-	s := service.NewUserService(c.container)
-	u, err := s.GetUserByID(userID)
+	u, err := c.userService.GetUserByID(userID)
 	if err != nil {
 		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
 			Error:   "internal_server_error",
@@ -1,21 +1,27 @@
 package controller
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 
+	"github.com/hackathon-20260110/api/adapter"
 	"github.com/hackathon-20260110/api/requests"
 	"github.com/hackathon-20260110/api/response"
 	"github.com/hackathon-20260110/api/service"
+	"github.com/hackathon-20260110/api/utils"
 	"github.com/labstack/echo/v4"
-	"go.uber.org/dig"
 )
 
 type AvatarChatController struct {
-	container *dig.Container
+	avatarChatService *service.AvatarChatService
 }
 
-func NewAvatarChatController(container *dig.Container) *AvatarChatController {
-	return &AvatarChatController{container: container}
+func NewAvatarChatController(avatarChatService *service.AvatarChatService) *AvatarChatController {
+	return &AvatarChatController{
+		avatarChatService: avatarChatService,
+	}
 }
 
 // @Summary アバターチャットメッセージ送信
@@ -27,6 +33,7 @@ func NewAvatarChatController(container *dig.Container) *AvatarChatController {
 // @Success 200 {object} response.SendAvatarChatMessageResponse "メッセージ送信成功"
 // @Failure 400 {object} response.ErrorResponse "リクエストが不正"
 // @Failure 401 {object} response.ErrorResponse "認証されていない、またはトークンが不正"
+// @Failure 502 {object} response.ErrorResponse "AIの応答が不正"
 // @Router /avatar-chats/{avatar_id}/messages [post]
 func (c *AvatarChatController) SendMessage(ctx echo.Context) error {
 	userID, ok := ctx.Get("userID").(string)
@@ -60,15 +67,98 @@ func (c *AvatarChatController) SendMessage(ctx echo.Context) error {
 		})
 	}
 
-	s := service.NewAvatarChatService(c.container)
-	result, err := s.SendMessage(ctx.Request().Context(), userID, avatarID, req.Content)
+	result, err := c.avatarChatService.SendMessage(ctx.Request().Context(), userID, avatarID, req.Content)
 	if err != nil {
+		if errors.Is(err, adapter.ErrLLMInvalidStructuredOutput) {
+			return ctx.JSON(http.StatusBadGateway, &response.ErrorResponse{
+				Error:   "bad_gateway",
+				Message: "AIの応答を生成できませんでした。時間をおいて再度お試しください",
+			})
+		}
 		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
 			Error:   "internal_server_error",
 			Message: "メッセージ送信に失敗しました",
 		})
 	}
 
+	return ctx.JSON(http.StatusOK, toSendAvatarChatMessageResponse(result))
+}
+
+// @Summary アバターチャットメッセージ送信（ストリーミング）
+// @Tags avatar-chat
+// @Description アバターにメッセージを送信し、応答をServer-Sent Eventsで受け取る。
+// @Description token イベントで返信の断片（response.AvatarChatStreamChunk）を順に送り、保存完了後に done イベントで送信結果を送る。
+// @Description 途中で失敗した場合は error イベント（response.ErrorResponse）を送って終了する。
+// @Description done イベントの avatar_response.message が保存された正式な本文なので、表示はこれで置き換えること。
+// @Description EventSourceは自動で再接続してメッセージを送り直してしまうため、fetchでPOSTしてレスポンスのストリームを読むこと。
+// @Security Bearer
+// @Accept json
+// @Produce text/event-stream
+// @Param avatar_id path string true "アバターID"
+// @Param request body requests.SendAvatarChatMessageRequest true "メッセージ送信リクエスト"
+// @Success 200 {object} response.SendAvatarChatMessageResponse "done イベントのデータ"
+// @Failure 400 {object} response.ErrorResponse "リクエストが不正"
+// @Failure 401 {object} response.ErrorResponse "認証されていない、またはトークンが不正"
+// @Router /avatar-chats/{avatar_id}/messages/stream [post]
+func (c *AvatarChatController) StreamMessage(ctx echo.Context) error {
+	userID, ok := ctx.Get("userID").(string)
+	if !ok {
+		return ctx.JSON(http.StatusUnauthorized, &response.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "認証されていない、またはトークンが不正",
+		})
+	}
+
+	avatarID := ctx.Param("avatar_id")
+	if avatarID == "" {
+		return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+			Error:   "bad_request",
+			Message: "アバターIDが必要です",
+		})
+	}
+
+	var req requests.SendAvatarChatMessageRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+			Error:   "bad_request",
+			Message: "リクエストが不正です",
+		})
+	}
+
+	if req.Content == "" {
+		return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+			Error:   "bad_request",
+			Message: "メッセージ内容が必要です",
+		})
+	}
+
+	result, err := c.avatarChatService.SendMessageStream(ctx.Request().Context(), userID, avatarID, req.Content, func(chunk string) error {
+		return writeSSEEvent(ctx, "token", &response.AvatarChatStreamChunk{Text: chunk})
+	})
+	if err != nil {
+		errResp := &response.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "メッセージ送信に失敗しました",
+		}
+		status := http.StatusInternalServerError
+		if errors.Is(err, adapter.ErrLLMInvalidStructuredOutput) {
+			errResp = &response.ErrorResponse{
+				Error:   "bad_gateway",
+				Message: "AIの応答を生成できませんでした。時間をおいて再度お試しください",
+			}
+			status = http.StatusBadGateway
+		}
+		// まだ何も送っていなければ通常のエラーレスポンスで返せる
+		if !ctx.Response().Committed {
+			return ctx.JSON(status, errResp)
+		}
+		return writeSSEEvent(ctx, "error", errResp)
+	}
+
+	return writeSSEEvent(ctx, "done", toSendAvatarChatMessageResponse(result))
+}
+
+func toSendAvatarChatMessageResponse(result *service.SendMessageResult) *response.SendAvatarChatMessageResponse {
 	unlockedMissions := make([]response.UnlockedUserInfo, 0, len(result.UnlockedMissions))
 	for _, m := range result.UnlockedMissions {
 		unlockedMissions = append(unlockedMissions, response.UnlockedUserInfo{
@@ -78,7 +168,7 @@ func (c *AvatarChatController) SendMessage(ctx echo.Context) error {
 		})
 	}
 
-	return ctx.JSON(http.StatusOK, &response.SendAvatarChatMessageResponse{
+	return &response.SendAvatarChatMessageResponse{
 		Message: "メッセージを送信しました",
 		AvatarResponse: response.AvatarChatMessage{
 			ID:         result.AvatarResponse.ID,
@@ -90,14 +180,40 @@ func (c *AvatarChatController) SendMessage(ctx echo.Context) error {
 		PointChange:      result.PointChange,
 		IsMatched:        result.IsMatched,
 		UnlockedMissions: unlockedMissions,
-	})
+	}
+}
+
+// writeSSEEvent 最初の書き込みでSSEのヘッダーを確定させ、1イベントを送ってフラッシュする
+func writeSSEEvent(ctx echo.Context, event string, data interface{}) error {
+	res := ctx.Response()
+	if !res.Committed {
+		res.Header().Set(echo.HeaderContentType, "text/event-stream")
+		res.Header().Set(echo.HeaderCacheControl, "no-cache")
+		res.Header().Set(echo.HeaderConnection, "keep-alive")
+		// リバースプロキシでバッファされるとストリーミングにならない
+		res.Header().Set("X-Accel-Buffering", "no")
+		res.WriteHeader(http.StatusOK)
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(res, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+		return err
+	}
+	res.Flush()
+	return nil
 }
 
 // @Summary アバターチャットメッセージ取得
 // @Tags avatar-chat
-// @Description アバターとのチャット履歴を取得する
+// @Description アバターとのチャット履歴を古い順に取得する。before/afterにメッセージIDを渡して前後のページを取得できる
 // @Security Bearer
 // @Param avatar_id path string true "アバターID"
+// @Param limit query int false "取得件数（最大100）" default(20)
+// @Param before query string false "このメッセージIDより古いものを取得する。未指定なら最新から"
+// @Param after query string false "このメッセージIDより新しいものを取得する。beforeとは同時に指定できない"
 // @Success 200 {object} response.GetAvatarChatMessagesResponse "チャット履歴取得成功"
 // @Failure 400 {object} response.ErrorResponse "リクエストが不正"
 // @Failure 401 {object} response.ErrorResponse "認証されていない、またはトークンが不正"
@@ -119,8 +235,15 @@ func (c *AvatarChatController) GetMessages(ctx echo.Context) error {
 		})
 	}
 
-	s := service.NewAvatarChatService(c.container)
-	messages, matchingPoint, isMatched, err := s.GetMessages(ctx.Request().Context(), userID, avatarID)
+	page, err := bindChatPage(ctx)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+			Error:   "bad_request",
+			Message: err.Error(),
+		})
+	}
+
+	messages, nextCursor, matchingPoint, isMatched, err := c.avatarChatService.GetMessages(ctx.Request().Context(), userID, avatarID, page)
 	if err != nil {
 		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
 			Error:   "internal_server_error",
@@ -140,11 +263,71 @@ func (c *AvatarChatController) GetMessages(ctx echo.Context) error {
 
 	return ctx.JSON(http.StatusOK, &response.GetAvatarChatMessagesResponse{
 		Messages:      responseMessages,
+		NextCursor:    nextCursor,
 		MatchingPoint: matchingPoint,
 		IsMatched:     isMatched,
 	})
 }
 
+// @Summary アバターチャット既読
+// @Tags avatar-chat
+// @Description アバターとのチャットを既読にし、チャット一覧の未読件数を減らす
+// @Security Bearer
+// @Param avatar_id path string true "アバターID"
+// @Param request body requests.MarkChatAsReadRequest false "既読にする位置。未指定なら最新のメッセージまで"
+// @Success 200 {object} response.MarkChatAsReadResponse "既読にした"
+// @Failure 400 {object} response.ErrorResponse "リクエストが不正"
+// @Failure 401 {object} response.ErrorResponse "認証されていない、またはトークンが不正"
+// @Failure 404 {object} response.ErrorResponse "アバターが見つからない"
+// @Router /avatar-chats/{avatar_id}/read [post]
+func (c *AvatarChatController) MarkAsRead(ctx echo.Context) error {
+	userID, ok := ctx.Get("userID").(string)
+	if !ok {
+		return ctx.JSON(http.StatusUnauthorized, &response.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "認証されていない、またはトークンが不正",
+		})
+	}
+
+	avatarID := ctx.Param("avatar_id")
+	if avatarID == "" {
+		return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+			Error:   "bad_request",
+			Message: "アバターIDが必要です",
+		})
+	}
+
+	var req requests.MarkChatAsReadRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+			Error:   "bad_request",
+			Message: "リクエストが不正です",
+		})
+	}
+	if err := req.Validate(); err != nil {
+		return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+			Error:   "bad_request",
+			Message: err.Error(),
+		})
+	}
+
+	result, err := c.avatarChatService.MarkAsRead(ctx.Request().Context(), userID, avatarID, req.LastReadMessageID)
+	if err != nil {
+		if errors.Is(err, utils.ErrorRecordNotFound) {
+			return ctx.JSON(http.StatusNotFound, &response.ErrorResponse{
+				Error:   "not_found",
+				Message: "アバターが見つかりません",
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "既読にできませんでした",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, result)
+}
+
 // @Summary アバターチャットステータス取得
 // @Tags avatar-chat
 // @Description アバターとのマッチングステータスを取得する
@@ -171,8 +354,7 @@ func (c *AvatarChatController) GetStatus(ctx echo.Context) error {
 		})
 	}
 
-	s := service.NewAvatarChatService(c.container)
-	matchingPoint, isMatched, unlockedMissions, err := s.GetStatus(ctx.Request().Context(), userID, avatarID)
+	matchingPoint, isMatched, unlockedMissions, err := c.avatarChatService.GetStatus(ctx.Request().Context(), userID, avatarID)
 	if err != nil {
 		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
 			Error:   "internal_server_error",
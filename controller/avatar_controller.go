@@ -7,16 +7,17 @@ import (
 	"github.com/hackathon-20260110/api/response"
 	"github.com/hackathon-20260110/api/service"
 	"github.com/labstack/echo/v4"
-	"go.uber.org/dig"
 	"gorm.io/gorm"
 )
 
 type AvatarController struct {
-	container *dig.Container
+	avatarService *service.AvatarService
 }
 
-func NewAvatarController(container *dig.Container) *AvatarController {
-	return &AvatarController{container: container}
+func NewAvatarController(avatarService *service.AvatarService) *AvatarController {
+	return &AvatarController{
+		avatarService: avatarService,
+	}
 }
 
 // @Summary アバター一覧取得（マッチングポイント付き）
@@ -34,8 +35,7 @@ func (c AvatarController) GetAvatarList(ctx echo.Context) error {
 		})
 	}
 
-	s := service.NewAvatarService(c.container)
-	avatars, err := s.GetAvatarList(userID)
+	avatars, err := c.avatarService.GetAvatarList(userID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return ctx.JSON(http.StatusNotFound, &response.ErrorResponse{
@@ -52,18 +52,24 @@ func (c AvatarController) GetAvatarList(ctx echo.Context) error {
 	})
 }
 
-// @Summary マッチングポイント更新
+const (
+	defaultPointHistoryLimit = 20
+	maxPointHistoryLimit     = 100
+)
+
+// @Summary マッチングポイント履歴取得
 // @Tags avatars
-// @Description 指定されたアバターとのマッチングポイントを更新する
+// @Description 指定されたアバターとのマッチングポイントが、いつ・なぜ増減したかを新しい順に取得する
 // @Security Bearer
 // @Param avatarId path string true "アバターID（ULID）"
-// @Param body body requests.UpdateMatchingPointRequest true "更新するポイント数"
-// @Success 200 {object} response.UpdateMatchingPointResponse "マッチングポイント更新成功"
+// @Param limit query int false "取得件数（デフォルト20、最大100）"
+// @Param offset query int false "オフセット"
+// @Success 200 {object} response.PointHistoryResponse "ポイント履歴取得成功"
 // @Failure 400 {object} response.ErrorResponse "リクエストが不正"
 // @Failure 401 {object} response.ErrorResponse "認証されていない、またはトークンが不正"
 // @Failure 404 {object} response.ErrorResponse "アバターが見つからない"
-// @Router /avatars/{avatarId}/matching-points [post]
-func (c AvatarController) UpdateMatchingPoint(ctx echo.Context) error {
+// @Router /avatars/{avatarId}/point-history [get]
+func (c AvatarController) GetPointHistory(ctx echo.Context) error {
 	userID, ok := ctx.Get("userID").(string)
 	if !ok {
 		return ctx.JSON(http.StatusUnauthorized, &response.ErrorResponse{
@@ -72,15 +78,20 @@ func (c AvatarController) UpdateMatchingPoint(ctx echo.Context) error {
 	}
 	avatarID := ctx.Param("avatarId")
 
-	var req requests.UpdateMatchingPointRequest
-	if err := ctx.Bind(&req); err != nil {
+	var req requests.GetPointHistoryRequest
+	if err := ctx.Bind(&req); err != nil || req.Limit < 0 || req.Offset < 0 {
 		return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
 			Message: "リクエストが不正です",
 		})
 	}
+	if req.Limit == 0 {
+		req.Limit = defaultPointHistoryLimit
+	}
+	if req.Limit > maxPointHistoryLimit {
+		req.Limit = maxPointHistoryLimit
+	}
 
-	s := service.NewAvatarService(c.container)
-	result, err := s.UpdateMatchingPoint(userID, avatarID, req.Points)
+	result, err := c.avatarService.GetPointHistory(userID, avatarID, req.Limit, req.Offset)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return ctx.JSON(http.StatusNotFound, &response.ErrorResponse{
@@ -88,7 +99,7 @@ func (c AvatarController) UpdateMatchingPoint(ctx echo.Context) error {
 			})
 		}
 		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
-			Message: "マッチングポイントの更新に失敗しました",
+			Message: "ポイント履歴の取得に失敗しました",
 		})
 	}
 
@@ -1,21 +1,24 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/hackathon-20260110/api/middleware"
 	"github.com/hackathon-20260110/api/response"
 	"github.com/hackathon-20260110/api/service"
 	"github.com/labstack/echo/v4"
-	"go.uber.org/dig"
+	"gorm.io/gorm"
 )
 
 type ChatController struct {
-	container *dig.Container
+	chatService *service.ChatService
 }
 
-func NewChatController(container *dig.Container) *ChatController {
-	return &ChatController{container: container}
+func NewChatController(chatService *service.ChatService) *ChatController {
+	return &ChatController{
+		chatService: chatService,
+	}
 }
 
 // @Summary 新しいチャット開始
@@ -71,8 +74,7 @@ func (c *ChatController) CreateChat(ctx echo.Context) error {
 func (c *ChatController) GetChats(ctx echo.Context) error {
 	userID := middleware.GetFirebaseUID(ctx)
 
-	chatService := service.NewChatService(c.container)
-	result, err := chatService.GetChats(ctx.Request().Context(), userID)
+	result, err := c.chatService.GetChats(ctx.Request().Context(), userID)
 	if err != nil {
 		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
 			Error:   "internal_error",
@@ -193,25 +195,21 @@ func (c *ChatController) GetChatScore(ctx echo.Context) error {
 	userID := middleware.GetFirebaseUID(ctx)
 	partnerUserID := ctx.Param("partnerUserId")
 
-	// TODO: 相手のユーザーIDからチャットが存在するか確認
-	// TODO: ユーザーがこのチャットにアクセスする権限があるか確認
-	// TODO: マッチングスコアを取得（Firestoreから）
-
-	_ = userID        // TODO: 権限チェックで使用
-	_ = partnerUserID // TODO: チャット情報取得で使用
+	score, err := c.chatService.GetChatScore(userID, partnerUserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ctx.JSON(http.StatusNotFound, &response.ErrorResponse{
+				Error:   "not_found",
+				Message: "相手が見つかりません",
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "マッチングポイントの取得に失敗しました",
+		})
+	}
 
 	return ctx.JSON(http.StatusOK, &response.GetChatScoreResponse{
-		Score: response.ChatScore{
-			ChatID:       "01ARZ3NDEKTSV4RRFFQ69G5FAV",
-			CurrentScore: 75,
-			MaxScore:     100,
-			ScoreBreakdown: map[string]int{
-				"conversation_quality": 30,
-				"common_interests":     25,
-				"engagement":           20,
-			},
-			UnlockThreshold: 80,
-			UpdatedAt:       "2024-01-01T12:00:00Z",
-		},
+		Score: *score,
 	})
 }
@@ -0,0 +1,35 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/requests"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	defaultChatHistoryLimit = 20
+	maxChatHistoryLimit     = 100
+)
+
+// bindChatPage クエリパラメータからチャット履歴の取得範囲を組み立てる。
+// 返すエラーのメッセージはそのままクライアントに返せる
+func bindChatPage(ctx echo.Context) (adapter.ChatPage, error) {
+	var req requests.ChatHistoryRequest
+	if err := ctx.Bind(&req); err != nil {
+		return adapter.ChatPage{}, errors.New("リクエストが不正です")
+	}
+	if err := req.Validate(); err != nil {
+		return adapter.ChatPage{}, err
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultChatHistoryLimit
+	}
+	if limit > maxChatHistoryLimit {
+		limit = maxChatHistoryLimit
+	}
+	return adapter.ChatPage{Limit: limit, Before: req.Before, After: req.After}, nil
+}
@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/response"
+	"github.com/labstack/echo/v4"
+)
+
+// DebugTokenController 任意のユーザーとしてのIDトークンを発行する。誰でも呼べるので AUTH_PROVIDER=local のときだけルーティングする
+type DebugTokenController struct {
+	tokenIssuer adapter.TokenIssuer
+}
+
+func NewDebugTokenController(tokenIssuer adapter.TokenIssuer) *DebugTokenController {
+	return &DebugTokenController{tokenIssuer: tokenIssuer}
+}
+
+// IDToken generates an ID token for testing purposes
+// @Summary ID Tokenを生成
+// @Description 指定したユーザーのID token（IDトークン）を直接生成します。このトークンはテスト・開発用途で使用できます。
+// @Description このトークンはBearerトークンとしてAuthorizationヘッダーで直接使用できます。
+// @Description AUTH_PROVIDER=local（FLAVOR=local を含む）のときだけ登録され、APIが署名したJWTを返します。dev・prdのFirebase認証では使えません。
+// @Tags debug
+// @Param userId query string true "User ID (UID)"
+// @Param role query string false "トークンに付けるroleクレーム（運営用APIを試すなら admin）"
+// @Success 200 {object} response.DebugIDTokenResponse "ID token generated successfully"
+// @Failure 400 {object} response.ErrorResponse "userId parameter is missing or empty"
+// @Failure 500 {object} response.ErrorResponse "Failed to generate ID token"
+// @Failure 503 {object} response.ErrorResponse "Token issuer not configured"
+// @Router /debug/id-token [get]
+func (c *DebugTokenController) IDToken(ctx echo.Context) error {
+	// Extract userId from query parameter
+	userID := ctx.QueryParam("userId")
+	if userID == "" {
+		return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+			Error:   "missing_parameter",
+			Message: "userIdパラメータが必要です",
+		})
+	}
+
+	issued, err := c.tokenIssuer.IssueIDTokenWithRole(ctx.Request().Context(), userID, ctx.QueryParam("role"))
+	if err != nil {
+		// Check for specific error: missing signing key
+		if errors.Is(err, adapter.ErrTokenIssuerNotConfigured) {
+			return ctx.JSON(http.StatusServiceUnavailable, &response.ErrorResponse{
+				Error:   "api_key_not_configured",
+				Message: "IDトークンの発行に必要な鍵が設定されていません。管理者に連絡してください",
+			})
+		}
+
+		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
+			Error:   "token_generation_failed",
+			Message: "IDトークンの生成に失敗しました",
+		})
+	}
+
+	// Return success response with ID token
+	return ctx.JSON(http.StatusOK, &response.DebugIDTokenResponse{
+		IDToken:   issued.IDToken,
+		ExpiresIn: issued.ExpiresIn,
+		UserID:    userID,
+		TokenType: "Bearer",
+	})
+}
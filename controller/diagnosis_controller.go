@@ -1,13 +1,16 @@
 package controller
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
+	"github.com/hackathon-20260110/api/adapter"
 	"github.com/hackathon-20260110/api/middleware"
 	"github.com/hackathon-20260110/api/requests"
 	"github.com/hackathon-20260110/api/response"
 	"github.com/hackathon-20260110/api/service"
+	"github.com/hackathon-20260110/api/utils"
 	"github.com/labstack/echo/v4"
 )
 
@@ -23,14 +26,17 @@ func NewDiagnosisController(diagnosisService service.DiagnosisService) *Diagnosi
 
 // @Summary Avatar間診断実行
 // @Tags diagnosis
-// @Description 自分のAvatarと相手のAvatarの会話を分析して相性診断を実行し、マッチングポイントを加算する
+// @Description 自分のAvatarと相手のAvatarの会話をサーバー側で生成・分析して相性診断を実行し、マッチングポイントを加算する。
+// @Description 同じ相手との診断は24時間に1回まで
 // @Security Bearer
 // @Param body body requests.ExecuteDiagnosisRequest true "診断リクエスト"
 // @Success 200 {object} response.DiagnosisResult "診断実行成功"
 // @Failure 400 {object} response.ErrorResponse "リクエストが不正"
 // @Failure 401 {object} response.ErrorResponse "認証されていない、またはトークンが不正"
 // @Failure 404 {object} response.ErrorResponse "Avatarが見つからない"
+// @Failure 429 {object} response.ErrorResponse "同じ相手と24時間以内に診断済み"
 // @Failure 500 {object} response.ErrorResponse "サーバーエラー"
+// @Failure 502 {object} response.ErrorResponse "AIの診断結果が不正"
 // @Router /diagnosis/execute [post]
 func (c *DiagnosisController) ExecuteDiagnosis(ctx echo.Context) error {
 	userID, ok := ctx.Get("userID").(string)
@@ -47,15 +53,58 @@ func (c *DiagnosisController) ExecuteDiagnosis(ctx echo.Context) error {
 		})
 	}
 
-	fmt.Println("req", req)
+	if req.TargetAvatarID == "" {
+		return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+			Error:   "bad_request",
+			Message: "target_avatar_idが必要です",
+		})
+	}
 
 	result, err := c.diagnosisService.ExecuteDiagnosis(
+		ctx.Request().Context(),
 		userID,
 		req.TargetAvatarID,
-		req.ConversationData,
+		req.TurnCount,
+		req.Topic,
 	)
 
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidDiagnosisTurnCount) {
+			return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+				Error:   "bad_request",
+				Message: "turn_countが不正です",
+			})
+		}
+		if errors.Is(err, service.ErrInvalidDiagnosisTopic) {
+			return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+				Error:   "bad_request",
+				Message: fmt.Sprintf("topicは%d文字以内で指定してください", service.MaxDiagnosisTopicRunes),
+			})
+		}
+		if errors.Is(err, service.ErrDiagnosisCooldown) {
+			return ctx.JSON(http.StatusTooManyRequests, &response.ErrorResponse{
+				Error:   "too_many_requests",
+				Message: "同じ相手との診断は24時間に1回までです",
+			})
+		}
+		if errors.Is(err, utils.ErrorRecordNotFound) {
+			return ctx.JSON(http.StatusNotFound, &response.ErrorResponse{
+				Error:   "not_found",
+				Message: "Avatarが見つかりません",
+			})
+		}
+		if errors.Is(err, service.ErrSelfDiagnosis) {
+			return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+				Error:   "bad_request",
+				Message: "自分のAvatarとは診断できません",
+			})
+		}
+		if errors.Is(err, adapter.ErrLLMInvalidStructuredOutput) {
+			return ctx.JSON(http.StatusBadGateway, &response.ErrorResponse{
+				Error:   "bad_gateway",
+				Message: "AIによる診断結果を取得できませんでした。時間をおいて再度お試しください",
+			})
+		}
 		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
 			Message: "診断に失敗しました",
 		})
@@ -6,15 +6,12 @@ import (
 	"github.com/hackathon-20260110/api/middleware"
 	"github.com/hackathon-20260110/api/response"
 	"github.com/labstack/echo/v4"
-	"go.uber.org/dig"
 )
 
-type MatchController struct {
-	container *dig.Container
-}
+type MatchController struct{}
 
-func NewMatchController(container *dig.Container) *MatchController {
-	return &MatchController{container: container}
+func NewMatchController() *MatchController {
+	return &MatchController{}
 }
 
 // @Summary アンロック状態取得
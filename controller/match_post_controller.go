@@ -1,63 +1,103 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/hackathon-20260110/api/middleware"
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/requests"
 	"github.com/hackathon-20260110/api/response"
+	"github.com/hackathon-20260110/api/service"
+	"github.com/hackathon-20260110/api/utils"
 	"github.com/labstack/echo/v4"
-	"go.uber.org/dig"
 )
 
 type MatchPostController struct {
-	container *dig.Container
+	candidateService *service.CandidateService
+	userChatService  *service.UserChatService
 }
 
-func NewMatchPostController(container *dig.Container) *MatchPostController {
-	return &MatchPostController{container: container}
+func NewMatchPostController(candidateService *service.CandidateService, userChatService *service.UserChatService) *MatchPostController {
+	return &MatchPostController{
+		candidateService: candidateService,
+		userChatService:  userChatService,
+	}
 }
 
+const (
+	defaultCandidateLimit = 20
+	maxCandidateLimit     = 100
+)
+
 // @Summary 相手候補一覧取得
 // @Tags matches
-// @Description マッチング前の相手候補の一覧を取得する（基本情報のみ）
+// @Description マッチング前の相手候補の一覧を相性スコアの高い順に取得する（基本情報のみ）。自分・マッチング済み・ブロック関係にあるユーザーと、お互いの希望（性別・年齢・距離）を満たさないユーザーは含まない。
+// @Description 並べ替えるのは条件に合う中からマッチングポイントの高い順に200人まで。超えた場合は truncated が true になり、total はその200人から数え、200件目より後ろのオフセットは空になる
 // @Security Bearer
-// @Param limit query int false "取得件数" default(20)
+// @Param limit query int false "取得件数（最大100）" default(20)
 // @Param offset query int false "オフセット" default(0)
+// @Param min_age query int false "最小年齢。未指定なら自分の希望年齢"
+// @Param max_age query int false "最大年齢。未指定なら自分の希望年齢"
+// @Param gender query []string false "性別（male, female, other）。複数指定可、未指定なら自分の恋愛対象" collectionFormat(multi)
 // @Success 200 {object} response.GetPartnersResponse "相手候補一覧取得成功"
+// @Failure 400 {object} response.ErrorResponse "リクエストが不正"
 // @Failure 401 {object} response.ErrorResponse "認証されていない、またはトークンが不正"
 // @Router /matches/candidates [get]
 func (c *MatchPostController) GetCandidates(ctx echo.Context) error {
 	// ミドルウェアで検証済みのFirebase UIDを取得
 	userID := middleware.GetFirebaseUID(ctx)
 
-	// TODO: ユーザーIDから相手候補一覧を取得
-	// TODO: フィルタリング（年齢、性別など）
-
-	_ = userID // TODO: 候補取得で使用
+	var req requests.GetCandidatesRequest
+	if err := ctx.Bind(&req); err != nil || req.Limit < 0 || req.Offset < 0 {
+		return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+			Error:   "bad_request",
+			Message: "リクエストが不正です",
+		})
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultCandidateLimit
+	}
+	if req.Limit > maxCandidateLimit {
+		req.Limit = maxCandidateLimit
+	}
+	for _, gender := range req.Gender {
+		if !models.IsValidGender(gender) {
+			return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+				Error:   "bad_request",
+				Message: "genderにはmale, female, otherのいずれかを指定してください",
+			})
+		}
+	}
 
-	return ctx.JSON(http.StatusOK, &response.GetPartnersResponse{
-		Partners: []response.Partner{
-			{
-				ID:              "01ARZ3NDEKTSV4RRFFQ69G5FBV",
-				DisplayName:     "佐藤花子",
-				Age:             24,
-				Gender:          "female",
-				ProfileImageURL: "https://example.com/images/profile2.jpg",
-				Bio:             "よろしくお願いします！",
-			},
-			{
-				ID:              "01ARZ3NDEKTSV4RRFFQ69G5FCV",
-				DisplayName:     "鈴木一郎",
-				Age:             28,
-				Gender:          "male",
-				ProfileImageURL: "https://example.com/images/profile3.jpg",
-				Bio:             "はじめまして！",
-			},
-		},
-		Total:  50,
-		Limit:  20,
-		Offset: 0,
+	result, err := c.candidateService.GetCandidates(userID, service.CandidateQuery{
+		Limit:   req.Limit,
+		Offset:  req.Offset,
+		MinAge:  req.MinAge,
+		MaxAge:  req.MaxAge,
+		Genders: req.Gender,
 	})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCandidateQuery) {
+			return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+				Error:   "bad_request",
+				Message: "年齢の範囲が不正です",
+			})
+		}
+		if errors.Is(err, utils.ErrorRecordNotFound) {
+			return ctx.JSON(http.StatusNotFound, &response.ErrorResponse{
+				Error:   "not_found",
+				Message: "ユーザーが見つかりません",
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "相手候補の取得に失敗しました",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, result)
 }
 
 // @Summary マッチ一覧取得
@@ -139,7 +179,7 @@ func (c *MatchPostController) GetSuggestions(ctx echo.Context) error {
 
 // @Summary 本人とのメッセージ送信
 // @Tags matches
-// @Description マッチした相手（本人）にメッセージを送信する
+// @Description マッチした相手（本人）にメッセージを送信する。相手には新着メッセージとして通知する
 // @Security Bearer
 // @Param id path string true "マッチID"
 // @Param request body requests.SendMatchMessageRequest true "メッセージ送信リクエスト"
@@ -154,42 +194,32 @@ func (c *MatchPostController) SendMatchMessage(ctx echo.Context) error {
 	userID := middleware.GetFirebaseUID(ctx)
 	matchID := ctx.Param("id")
 
-	// TODO: リクエストボディをパース
-	// var req requests.SendMatchMessageRequest
-	// if err := ctx.Bind(&req); err != nil {
-	//     return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
-	//         Error:   "invalid_request",
-	//         Message: "リクエストが不正です",
-	//     })
-	// }
+	var req requests.SendMatchMessageRequest
+	if err := ctx.Bind(&req); err != nil || strings.TrimSpace(req.Content) == "" {
+		return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+			Error:   "bad_request",
+			Message: "リクエストが不正です",
+		})
+	}
 
-	// TODO: マッチが存在するか確認
-	// TODO: ユーザーがこのマッチにアクセスする権限があるか確認
-	// TODO: メッセージを保存
-	// TODO: 相手ユーザーに通知を送信（必要に応じて）
-
-	_ = userID  // TODO: 権限チェックで使用
-	_ = matchID // TODO: マッチ情報取得で使用
+	result, err := c.userChatService.SendMatchMessage(ctx.Request().Context(), userID, matchID, req.Content)
+	if err != nil {
+		return matchMessageErrorResponse(ctx, err, "メッセージの送信に失敗しました")
+	}
 
-	return ctx.JSON(http.StatusOK, &response.SendMatchMessageResponse{
-		Message: response.MatchMessage{
-			ID:        "match_msg_123456",
-			MatchID:   matchID,
-			SenderID:  userID,
-			Content:   "こんにちは！マッチできて嬉しいです。",
-			CreatedAt: "2024-01-01T12:00:00Z",
-		},
-	})
+	return ctx.JSON(http.StatusOK, result)
 }
 
 // @Summary 本人とのメッセージ履歴取得
 // @Tags matches
-// @Description マッチした相手（本人）とのメッセージ履歴を取得する
+// @Description マッチした相手（本人）とのメッセージ履歴を古い順に取得する。before/afterにメッセージIDを渡して前後のページを取得できる
 // @Security Bearer
 // @Param id path string true "マッチID"
-// @Param limit query int false "取得件数" default(20)
-// @Param offset query int false "オフセット" default(0)
+// @Param limit query int false "取得件数（最大100）" default(20)
+// @Param before query string false "このメッセージIDより古いものを取得する。未指定なら最新から"
+// @Param after query string false "このメッセージIDより新しいものを取得する。beforeとは同時に指定できない"
 // @Success 200 {object} response.GetMatchMessagesResponse "メッセージ履歴取得成功"
+// @Failure 400 {object} response.ErrorResponse "リクエストが不正"
 // @Failure 401 {object} response.ErrorResponse "認証されていない、またはトークンが不正"
 // @Failure 403 {object} response.ErrorResponse "このマッチにアクセスする権限がない"
 // @Failure 404 {object} response.ErrorResponse "マッチが見つからない"
@@ -199,33 +229,39 @@ func (c *MatchPostController) GetMatchMessages(ctx echo.Context) error {
 	userID := middleware.GetFirebaseUID(ctx)
 	matchID := ctx.Param("id")
 
-	// TODO: マッチが存在するか確認
-	// TODO: ユーザーがこのマッチにアクセスする権限があるか確認
-	// TODO: メッセージ履歴を取得（ページネーション対応）
+	page, err := bindChatPage(ctx)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+			Error:   "bad_request",
+			Message: err.Error(),
+		})
+	}
 
-	_ = userID  // TODO: 権限チェックで使用
-	_ = matchID // TODO: マッチ情報取得で使用
+	result, err := c.userChatService.GetMatchMessages(ctx.Request().Context(), userID, matchID, page)
+	if err != nil {
+		return matchMessageErrorResponse(ctx, err, "メッセージ履歴の取得に失敗しました")
+	}
 
-	return ctx.JSON(http.StatusOK, &response.GetMatchMessagesResponse{
-		Messages: []response.MatchMessage{
-			{
-				ID:        "match_msg_123456",
-				MatchID:   matchID,
-				SenderID:  userID,
-				Content:   "こんにちは！マッチできて嬉しいです。",
-				CreatedAt: "2024-01-01T12:00:00Z",
-			},
-			{
-				ID:        "match_msg_123457",
-				MatchID:   matchID,
-				SenderID:  "01ARZ3NDEKTSV4RRFFQ69G5FBV",
-				Content:   "こちらこそ！よろしくお願いします。",
-				CreatedAt: "2024-01-01T12:05:00Z",
-			},
-		},
-		Total:  2,
-		Limit:  20,
-		Offset: 0,
+	return ctx.JSON(http.StatusOK, result)
+}
+
+// matchMessageErrorResponse マッチ後のメッセージAPIで共通のエラーレスポンスを返す
+func matchMessageErrorResponse(ctx echo.Context, err error, internalMessage string) error {
+	if errors.Is(err, utils.ErrorRecordNotFound) {
+		return ctx.JSON(http.StatusNotFound, &response.ErrorResponse{
+			Error:   "not_found",
+			Message: "マッチが見つかりません",
+		})
+	}
+	if errors.Is(err, service.ErrNotMatchingMember) {
+		return ctx.JSON(http.StatusForbidden, &response.ErrorResponse{
+			Error:   "forbidden",
+			Message: "このマッチにアクセスする権限がありません",
+		})
+	}
+	return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
+		Error:   "internal_server_error",
+		Message: internalMessage,
 	})
 }
 
@@ -1,20 +1,195 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 
+	"github.com/hackathon-20260110/api/requests"
 	"github.com/hackathon-20260110/api/response"
 	"github.com/hackathon-20260110/api/service"
+	"github.com/hackathon-20260110/api/utils"
 	"github.com/labstack/echo/v4"
-	"go.uber.org/dig"
 )
 
 type NotificationController struct {
-	container *dig.Container
+	notificationService *service.NotificationService
 }
 
-func NewNotificationController(container *dig.Container) *NotificationController {
-	return &NotificationController{container: container}
+func NewNotificationController(notificationService *service.NotificationService) *NotificationController {
+	return &NotificationController{
+		notificationService: notificationService,
+	}
+}
+
+const (
+	defaultNotificationLimit = 20
+	maxNotificationLimit     = 100
+)
+
+// ListNotifications godoc
+// @Summary 通知一覧を取得する
+// @Description 自分宛ての通知を新しい順に取得します。続きはレスポンスのnext_cursorをcursorに指定して取得します
+// @Tags notification
+// @Produce json
+// @Param cursor query string false "前のページのnext_cursor"
+// @Param limit query int false "取得件数（最大100）" default(20)
+// @Success 200 {object} response.NotificationListResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /notification [get]
+func (c *NotificationController) ListNotifications(ctx echo.Context) error {
+	userID, ok := ctx.Get("userID").(string)
+	if !ok {
+		return ctx.JSON(http.StatusUnauthorized, &response.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "認証されていない、またはトークンが不正",
+		})
+	}
+
+	var req requests.ListNotificationsRequest
+	if err := ctx.Bind(&req); err != nil || req.Limit < 0 {
+		return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+			Error:   "bad_request",
+			Message: "リクエストが不正です",
+		})
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultNotificationLimit
+	}
+	if req.Limit > maxNotificationLimit {
+		req.Limit = maxNotificationLimit
+	}
+
+	result, err := c.notificationService.ListNotifications(ctx.Request().Context(), userID, req.Cursor, req.Limit)
+	if err != nil {
+		if errors.Is(err, utils.ErrorRecordNotFound) {
+			return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+				Error:   "bad_request",
+				Message: "cursorが不正です",
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "通知の取得に失敗しました",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, result)
+}
+
+// GetUnreadCount godoc
+// @Summary 未読の通知件数を取得する
+// @Description 自分宛ての未読通知の件数を返します
+// @Tags notification
+// @Produce json
+// @Success 200 {object} response.UnreadCountResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /notification/unread-count [get]
+func (c *NotificationController) GetUnreadCount(ctx echo.Context) error {
+	userID, ok := ctx.Get("userID").(string)
+	if !ok {
+		return ctx.JSON(http.StatusUnauthorized, &response.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "認証されていない、またはトークンが不正",
+		})
+	}
+
+	count, err := c.notificationService.GetUnreadCount(ctx.Request().Context(), userID)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "未読件数の取得に失敗しました",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, &response.UnreadCountResponse{
+		UnreadCount: count,
+	})
+}
+
+// MarkAllAsRead godoc
+// @Summary すべての通知を既読にする
+// @Description 自分宛ての未読通知をすべて既読状態にします
+// @Tags notification
+// @Produce json
+// @Success 200 {object} response.MarkAllAsReadResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /notification/read-all [post]
+func (c *NotificationController) MarkAllAsRead(ctx echo.Context) error {
+	userID, ok := ctx.Get("userID").(string)
+	if !ok {
+		return ctx.JSON(http.StatusUnauthorized, &response.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "認証されていない、またはトークンが不正",
+		})
+	}
+
+	updated, err := c.notificationService.MarkAllAsRead(ctx.Request().Context(), userID)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "通知の既読処理に失敗しました",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, &response.MarkAllAsReadResponse{
+		Message:      "すべての通知を既読にしました",
+		UpdatedCount: updated,
+	})
+}
+
+// DeleteNotification godoc
+// @Summary 通知を削除する
+// @Description 指定された通知IDの通知を削除します
+// @Tags notification
+// @Produce json
+// @Param id path string true "通知ID"
+// @Success 200 {object} response.DeleteNotificationResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Security Bearer
+// @Router /notification/{id} [delete]
+func (c *NotificationController) DeleteNotification(ctx echo.Context) error {
+	userID, ok := ctx.Get("userID").(string)
+	if !ok {
+		return ctx.JSON(http.StatusUnauthorized, &response.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "認証されていない、またはトークンが不正",
+		})
+	}
+
+	notificationID := ctx.Param("id")
+	if notificationID == "" {
+		return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+			Error:   "bad_request",
+			Message: "通知IDが必要です",
+		})
+	}
+
+	if err := c.notificationService.DeleteNotification(ctx.Request().Context(), userID, notificationID); err != nil {
+		if errors.Is(err, utils.ErrorRecordNotFound) {
+			return ctx.JSON(http.StatusNotFound, &response.ErrorResponse{
+				Error:   "not_found",
+				Message: "通知が見つかりません",
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "通知の削除に失敗しました",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, &response.DeleteNotificationResponse{
+		Message: "通知を削除しました",
+	})
 }
 
 // MarkAsRead godoc
@@ -47,8 +222,7 @@ func (c *NotificationController) MarkAsRead(ctx echo.Context) error {
 		})
 	}
 
-	notificationService := service.NewNotificationService(c.container)
-	if err := notificationService.MarkAsRead(ctx.Request().Context(), userID, notificationID); err != nil {
+	if err := c.notificationService.MarkAsRead(ctx.Request().Context(), userID, notificationID); err != nil {
 		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
 			Error:   "internal_server_error",
 			Message: "通知の既読処理に失敗しました",
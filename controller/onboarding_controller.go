@@ -1,23 +1,26 @@
 package controller
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
+	"github.com/hackathon-20260110/api/adapter"
 	"github.com/hackathon-20260110/api/models"
 	"github.com/hackathon-20260110/api/requests"
 	"github.com/hackathon-20260110/api/response"
 	"github.com/hackathon-20260110/api/service"
 	"github.com/labstack/echo/v4"
-	"go.uber.org/dig"
 )
 
 type OnboardingController struct {
-	container *dig.Container
+	onboardingService *service.OnboardingService
 }
 
-func NewOnboardingController(container *dig.Container) *OnboardingController {
-	return &OnboardingController{container: container}
+func NewOnboardingController(onboardingService *service.OnboardingService) *OnboardingController {
+	return &OnboardingController{
+		onboardingService: onboardingService,
+	}
 }
 
 // @Summary オンボーディングチャット開始
@@ -38,8 +41,7 @@ func (c *OnboardingController) StartOnboardingChat(ctx echo.Context) error {
 		})
 	}
 
-	s := service.NewOnboardingService(c.container)
-	err := s.StartOnboardingChat(ctx.Request().Context(), userID)
+	err := c.onboardingService.StartOnboardingChat(ctx.Request().Context(), userID)
 	if err != nil {
 		fmt.Println(err)
 		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
@@ -79,8 +81,7 @@ func (c *OnboardingController) SendOnboardingMessage(ctx echo.Context) error {
 		})
 	}
 
-	s := service.NewOnboardingService(c.container)
-	err := s.SendOnboardingMessage(ctx.Request().Context(), userID, req.Content)
+	err := c.onboardingService.SendOnboardingMessage(ctx.Request().Context(), userID, req.Content)
 	if err != nil {
 		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
 			Error:   "internal_server_error",
@@ -93,6 +94,33 @@ func (c *OnboardingController) SendOnboardingMessage(ctx echo.Context) error {
 	})
 }
 
+// @Summary オンボーディング返信の生成状況取得
+// @Tags onboarding
+// @Description 直近に送信したメッセージに対するシステムの返信が、生成待ち（pending）・失敗（failed）・完了（done）のどれかを取得する
+// @Security Bearer
+// @Success 200 {object} response.OnboardingReplyStatusResponse "生成状況取得成功"
+// @Failure 401 {object} response.ErrorResponse "認証されていない、またはトークンが不正"
+// @Router /onboarding/chats/reply-status [get]
+func (c *OnboardingController) GetOnboardingReplyStatus(ctx echo.Context) error {
+	userID, ok := ctx.Get("userID").(string)
+	if !ok {
+		return ctx.JSON(http.StatusUnauthorized, &response.ErrorResponse{
+			Error:   "unauthorized",
+			Message: "認証されていない、またはトークンが不正",
+		})
+	}
+
+	result, err := c.onboardingService.GetOnboardingReplyStatus(userID)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "返信の生成状況の取得に失敗しました",
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, result)
+}
+
 // @Summary オンボーディングチャット完了
 // @Tags onboarding
 // @Description オンボーディングチャットを完了する
@@ -100,6 +128,7 @@ func (c *OnboardingController) SendOnboardingMessage(ctx echo.Context) error {
 // @Success 200 {object} response.OnboardingCompleteResponse "オンボーディングチャット完了成功"
 // @Failure 400 {object} response.ErrorResponse "リクエストが不正"
 // @Failure 401 {object} response.ErrorResponse "認証されていない、またはトークンが不正"
+// @Failure 502 {object} response.ErrorResponse "AIによるプロフィール抽出結果が不正"
 // @Router /onboarding/finish [post]
 func (c *OnboardingController) FinishOnboarding(ctx echo.Context) error {
 	userID, ok := ctx.Get("userID").(string)
@@ -110,9 +139,14 @@ func (c *OnboardingController) FinishOnboarding(ctx echo.Context) error {
 		})
 	}
 
-	s := service.NewOnboardingService(c.container)
-	userInfos, avatar, err := s.FinishOnboarding(ctx.Request().Context(), userID)
+	userInfos, avatar, err := c.onboardingService.FinishOnboarding(ctx.Request().Context(), userID)
 	if err != nil {
+		if errors.Is(err, adapter.ErrLLMInvalidStructuredOutput) {
+			return ctx.JSON(http.StatusBadGateway, &response.ErrorResponse{
+				Error:   "bad_gateway",
+				Message: "チャット内容からプロフィールを抽出できませんでした。時間をおいて再度お試しください",
+			})
+		}
 		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
 			Error:   "internal_server_error",
 			Message: "オンボーディングチャット完了に失敗しました",
@@ -8,15 +8,16 @@ import (
 	"github.com/hackathon-20260110/api/response"
 	"github.com/hackathon-20260110/api/service"
 	"github.com/labstack/echo/v4"
-	"go.uber.org/dig"
 )
 
 type ProfileController struct {
-	container *dig.Container
+	profileService *service.ProfileService
 }
 
-func NewProfileController(container *dig.Container) *ProfileController {
-	return &ProfileController{container: container}
+func NewProfileController(profileService *service.ProfileService) *ProfileController {
+	return &ProfileController{
+		profileService: profileService,
+	}
 }
 
 // @Summary 定義済みプロフィール項目一覧取得
@@ -27,8 +28,7 @@ func NewProfileController(container *dig.Container) *ProfileController {
 // @Failure 401 {object} response.ErrorResponse "認証エラー"
 // @Router /profile/predefined-keys [get]
 func (c *ProfileController) GetPredefinedKeys(ctx echo.Context) error {
-	service := service.NewProfileService(c.container)
-	keys := service.GetPredefinedInfoKeys()
+	keys := c.profileService.GetPredefinedInfoKeys()
 
 	return ctx.JSON(http.StatusOK, &response.PredefinedKeysResponse{
 		Keys: keys,
@@ -55,8 +55,7 @@ func (c *ProfileController) CreateUserInfo(ctx echo.Context) error {
 		})
 	}
 
-	service := service.NewProfileService(c.container)
-	result, err := service.CreateUserInfo(ctx.Request().Context(), userID, req)
+	result, err := c.profileService.CreateUserInfo(ctx.Request().Context(), userID, req)
 	if err != nil {
 		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
 			Error:   "internal_error",
@@ -90,8 +89,7 @@ func (c *ProfileController) UpdateUserInfo(ctx echo.Context) error {
 		})
 	}
 
-	service := service.NewProfileService(c.container)
-	result, err := service.UpdateUserInfo(ctx.Request().Context(), userID, infoID, req)
+	result, err := c.profileService.UpdateUserInfo(ctx.Request().Context(), userID, infoID, req)
 	if err != nil {
 		if err.Error() == "user info not found: record not found" {
 			return ctx.JSON(http.StatusNotFound, &response.ErrorResponse{
@@ -121,8 +119,7 @@ func (c *ProfileController) DeleteUserInfo(ctx echo.Context) error {
 	userID := middleware.GetFirebaseUID(ctx)
 	infoID := ctx.Param("infoId")
 
-	service := service.NewProfileService(c.container)
-	err := service.DeleteUserInfo(ctx.Request().Context(), userID, infoID)
+	err := c.profileService.DeleteUserInfo(ctx.Request().Context(), userID, infoID)
 	if err != nil {
 		if err.Error() == "user info not found: record not found" {
 			return ctx.JSON(http.StatusNotFound, &response.ErrorResponse{
@@ -151,8 +148,7 @@ func (c *ProfileController) DeleteUserInfo(ctx echo.Context) error {
 func (c *ProfileController) GetMyProfile(ctx echo.Context) error {
 	userID := middleware.GetFirebaseUID(ctx)
 
-	service := service.NewProfileService(c.container)
-	result, err := service.GetUserProfile(ctx.Request().Context(), userID, userID)
+	result, err := c.profileService.GetUserProfile(ctx.Request().Context(), userID, userID)
 	if err != nil {
 		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
 			Error:   "internal_error",
@@ -176,8 +172,7 @@ func (c *ProfileController) GetUserProfile(ctx echo.Context) error {
 	viewerUserID := middleware.GetFirebaseUID(ctx)
 	targetUserID := ctx.Param("userId")
 
-	service := service.NewProfileService(c.container)
-	result, err := service.GetUserProfile(ctx.Request().Context(), targetUserID, viewerUserID)
+	result, err := c.profileService.GetUserProfile(ctx.Request().Context(), targetUserID, viewerUserID)
 	if err != nil {
 		if err.Error() == "user not found: record not found" {
 			return ctx.JSON(http.StatusNotFound, &response.ErrorResponse{
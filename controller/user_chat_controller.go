@@ -7,15 +7,16 @@ import (
 	"github.com/hackathon-20260110/api/response"
 	"github.com/hackathon-20260110/api/service"
 	"github.com/labstack/echo/v4"
-	"go.uber.org/dig"
 )
 
 type UserChatController struct {
-	container *dig.Container
+	userChatService *service.UserChatService
 }
 
-func NewUserChatController(container *dig.Container) *UserChatController {
-	return &UserChatController{container: container}
+func NewUserChatController(userChatService *service.UserChatService) *UserChatController {
+	return &UserChatController{
+		userChatService: userChatService,
+	}
 }
 
 // GetMatchedUsers godoc
@@ -31,8 +32,7 @@ func NewUserChatController(container *dig.Container) *UserChatController {
 func (c *UserChatController) GetMatchedUsers(ctx echo.Context) error {
 	userID := ctx.Get("uid").(string)
 
-	userChatService := service.NewUserChatService(c.container)
-	matchedUsers, err := userChatService.GetMatchedUsers(ctx.Request().Context(), userID)
+	matchedUsers, err := c.userChatService.GetMatchedUsers(ctx.Request().Context(), userID)
 	if err != nil {
 		return ctx.JSON(http.StatusInternalServerError, response.ErrorResponse{
 			Error: "Failed to get matched users",
@@ -87,8 +87,7 @@ func (c *UserChatController) SendMessage(ctx echo.Context) error {
 		})
 	}
 
-	userChatService := service.NewUserChatService(c.container)
-	message, err := userChatService.SendMessage(ctx.Request().Context(), userID, partnerID, req.Content)
+	message, err := c.userChatService.SendMessage(ctx.Request().Context(), userID, partnerID, req.Content)
 	if err != nil {
 		return ctx.JSON(http.StatusForbidden, response.ErrorResponse{
 			Error: "Cannot send message to this user. Make sure you are matched.",
@@ -115,7 +114,11 @@ func (c *UserChatController) SendMessage(ctx echo.Context) error {
 // @Produce json
 // @Security Bearer
 // @Param partner_id path string true "相手ユーザーID"
+// @Param limit query int false "取得件数（最大100）" default(20)
+// @Param before query string false "このメッセージIDより古いものを取得する。未指定なら最新から"
+// @Param after query string false "このメッセージIDより新しいものを取得する。beforeとは同時に指定できない"
 // @Success 200 {object} response.GetUserChatMessagesResponse "メッセージ一覧取得成功"
+// @Failure 400 {object} response.ErrorResponse "リクエストが不正"
 // @Failure 401 {object} response.ErrorResponse "認証されていない、またはトークンが不正"
 // @Failure 403 {object} response.ErrorResponse "マッチしていないユーザーのメッセージ取得"
 // @Router /user-chats/{partner_id}/messages [get]
@@ -123,8 +126,15 @@ func (c *UserChatController) GetMessages(ctx echo.Context) error {
 	userID := ctx.Get("uid").(string)
 	partnerID := ctx.Param("partner_id")
 
-	userChatService := service.NewUserChatService(c.container)
-	messages, err := userChatService.GetMessages(ctx.Request().Context(), userID, partnerID)
+	page, err := bindChatPage(ctx)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, response.ErrorResponse{
+			Error:   "bad_request",
+			Message: err.Error(),
+		})
+	}
+
+	messages, nextCursor, partnerLastReadMessageID, err := c.userChatService.GetMessages(ctx.Request().Context(), userID, partnerID, page)
 	if err != nil {
 		return ctx.JSON(http.StatusForbidden, response.ErrorResponse{
 			Error: "Cannot get messages with this user. Make sure you are matched.",
@@ -143,6 +153,48 @@ func (c *UserChatController) GetMessages(ctx echo.Context) error {
 	}
 
 	return ctx.JSON(http.StatusOK, response.GetUserChatMessagesResponse{
-		Messages: responseMessages,
+		Messages:                 responseMessages,
+		NextCursor:               nextCursor,
+		PartnerLastReadMessageID: partnerLastReadMessageID,
 	})
 }
+
+// MarkAsRead godoc
+// @Summary ユーザーチャット既読
+// @Description マッチしているユーザーとのチャットを既読にする。自分の既読位置は相手のメッセージ履歴取得で「既読」として表示される
+// @Tags user-chat
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param partner_id path string true "相手ユーザーID"
+// @Param request body requests.MarkChatAsReadRequest false "既読にする位置。未指定なら最新のメッセージまで"
+// @Success 200 {object} response.MarkChatAsReadResponse "既読にした"
+// @Failure 400 {object} response.ErrorResponse "リクエストが不正"
+// @Failure 401 {object} response.ErrorResponse "認証されていない、またはトークンが不正"
+// @Failure 403 {object} response.ErrorResponse "マッチしていないユーザーとのチャット"
+// @Router /user-chats/{partner_id}/read [post]
+func (c *UserChatController) MarkAsRead(ctx echo.Context) error {
+	userID := ctx.Get("uid").(string)
+	partnerID := ctx.Param("partner_id")
+
+	var req requests.MarkChatAsReadRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+			Error:   "bad_request",
+			Message: "リクエストが不正です",
+		})
+	}
+	if err := req.Validate(); err != nil {
+		return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+			Error:   "bad_request",
+			Message: err.Error(),
+		})
+	}
+
+	result, err := c.userChatService.MarkAsRead(ctx.Request().Context(), userID, partnerID, req.LastReadMessageID)
+	if err != nil {
+		return matchMessageErrorResponse(ctx, err, "既読にできませんでした")
+	}
+
+	return ctx.JSON(http.StatusOK, result)
+}
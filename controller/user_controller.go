@@ -1,22 +1,34 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/hackathon-20260110/api/middleware"
+	"github.com/hackathon-20260110/api/models"
 	"github.com/hackathon-20260110/api/requests"
 	"github.com/hackathon-20260110/api/response"
 	"github.com/hackathon-20260110/api/service"
+	"github.com/hackathon-20260110/api/utils"
 	"github.com/labstack/echo/v4"
-	"go.uber.org/dig"
 )
 
 type UserController struct {
-	container *dig.Container
+	userService         *service.UserService
+	deviceService       *service.DeviceService
+	notificationService *service.NotificationService
 }
 
-func NewUserController(container *dig.Container) *UserController {
-	return &UserController{container: container}
+func NewUserController(
+	userService *service.UserService,
+	deviceService *service.DeviceService,
+	notificationService *service.NotificationService,
+) *UserController {
+	return &UserController{
+		userService:         userService,
+		deviceService:       deviceService,
+		notificationService: notificationService,
+	}
 }
 
 // @Summary 自分の情報取得（マイページ用）
@@ -36,8 +48,7 @@ func (c *UserController) GetMe(ctx echo.Context) error {
 		})
 	}
 
-	s := service.NewUserService(c.container)
-	u, err := s.GetUserByIDOrNil(userID)
+	u, err := c.userService.GetUserByIDOrNil(userID)
 	if err != nil {
 		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
 			Error:   "internal_server_error",
@@ -59,7 +70,7 @@ func (c *UserController) GetMe(ctx echo.Context) error {
 
 // @Summary ユーザー作成
 // @Tags users
-// @Description ユーザーを作成する
+// @Description ユーザーを作成する。恋愛対象の性別・希望年齢・距離の上限を合わせて登録でき、相手探しはお互いの希望を満たす相手に限られる
 // @Security Bearer
 // @Param request body requests.CreateUserRequest true "ユーザー作成リクエスト"
 // @Success 200 {object} response.User "ユーザー作成成功"
@@ -82,9 +93,14 @@ func (c *UserController) CreateUser(ctx echo.Context) error {
 			Message: "リクエストが不正です",
 		})
 	}
+	if err := args.Validate(); err != nil {
+		return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+			Error:   "bad_request",
+			Message: err.Error(),
+		})
+	}
 
-	s := service.NewUserService(c.container)
-	u, err := s.UpsertUser(userID, *args)
+	u, err := c.userService.UpsertUser(userID, *args)
 	if err != nil {
 		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
 			Error:   "internal_server_error",
@@ -240,3 +256,134 @@ func (c *UserController) GetMyAvatarAI(ctx echo.Context) error {
 		},
 	})
 }
+
+// @Summary プッシュ通知を受け取る端末の登録
+// @Tags users
+// @Description FCMの登録トークンを登録する。登録済みのトークンは自分の端末として上書きする
+// @Security Bearer
+// @Param request body requests.RegisterDeviceRequest true "端末登録リクエスト"
+// @Success 200 {object} response.Device "端末登録成功"
+// @Failure 400 {object} response.ErrorResponse "リクエストが不正"
+// @Failure 401 {object} response.ErrorResponse "認証されていない、またはトークンが不正"
+// @Router /users/me/devices [post]
+func (c *UserController) RegisterDevice(ctx echo.Context) error {
+	userID := middleware.GetFirebaseUID(ctx)
+
+	var req requests.RegisterDeviceRequest
+	if err := ctx.Bind(&req); err != nil || req.Token == "" {
+		return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+			Error:   "bad_request",
+			Message: "リクエストが不正です",
+		})
+	}
+	platform := models.DevicePlatform(req.Platform)
+	if platform != models.DevicePlatformIOS && platform != models.DevicePlatformAndroid && platform != models.DevicePlatformWeb {
+		return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+			Error:   "bad_request",
+			Message: "platformにはios, android, webのいずれかを指定してください",
+		})
+	}
+
+	device, err := c.deviceService.RegisterDevice(userID, req.Token, platform)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "端末の登録に失敗しました",
+		})
+	}
+	return ctx.JSON(http.StatusOK, device)
+}
+
+// @Summary プッシュ通知を受け取る端末の登録解除
+// @Tags users
+// @Description ログアウト時などに、FCMの登録トークンをプッシュ通知の送信先から外す
+// @Security Bearer
+// @Param request body requests.UnregisterDeviceRequest true "端末登録解除リクエスト"
+// @Success 200 {object} response.UnregisterDeviceResponse "登録解除成功"
+// @Failure 400 {object} response.ErrorResponse "リクエストが不正"
+// @Failure 401 {object} response.ErrorResponse "認証されていない、またはトークンが不正"
+// @Failure 404 {object} response.ErrorResponse "端末が登録されていない"
+// @Router /users/me/devices [delete]
+func (c *UserController) UnregisterDevice(ctx echo.Context) error {
+	userID := middleware.GetFirebaseUID(ctx)
+
+	var req requests.UnregisterDeviceRequest
+	if err := ctx.Bind(&req); err != nil || req.Token == "" {
+		return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+			Error:   "bad_request",
+			Message: "リクエストが不正です",
+		})
+	}
+
+	if err := c.deviceService.UnregisterDevice(userID, req.Token); err != nil {
+		if errors.Is(err, utils.ErrorRecordNotFound) {
+			return ctx.JSON(http.StatusNotFound, &response.ErrorResponse{
+				Error:   "not_found",
+				Message: "端末が登録されていません",
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "端末の登録解除に失敗しました",
+		})
+	}
+	return ctx.JSON(http.StatusOK, &response.UnregisterDeviceResponse{
+		Message: "端末の登録を解除しました",
+	})
+}
+
+// @Summary 通知設定の取得
+// @Tags users
+// @Description 通知の種類ごとの受け取り設定とおやすみ時間を取得する。未設定の場合は既定値（すべて受け取る・おやすみ時間なし）を返す
+// @Security Bearer
+// @Success 200 {object} response.NotificationSettings "通知設定取得成功"
+// @Failure 401 {object} response.ErrorResponse "認証されていない、またはトークンが不正"
+// @Router /users/me/notification-settings [get]
+func (c *UserController) GetNotificationSettings(ctx echo.Context) error {
+	userID := middleware.GetFirebaseUID(ctx)
+
+	settings, err := c.notificationService.GetSettings(userID)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "通知設定の取得に失敗しました",
+		})
+	}
+	return ctx.JSON(http.StatusOK, settings)
+}
+
+// @Summary 通知設定の更新
+// @Tags users
+// @Description 通知の種類ごとの受け取り設定とおやすみ時間を更新する。オフにした種類の通知は届かなくなる。おやすみ時間中の通知は一覧には入るがプッシュ通知は送らず、明けた後にまとめて1通送る
+// @Security Bearer
+// @Param request body requests.UpdateNotificationSettingsRequest true "通知設定更新リクエスト"
+// @Success 200 {object} response.NotificationSettings "通知設定更新成功"
+// @Failure 400 {object} response.ErrorResponse "リクエストが不正"
+// @Failure 401 {object} response.ErrorResponse "認証されていない、またはトークンが不正"
+// @Router /users/me/notification-settings [put]
+func (c *UserController) UpdateNotificationSettings(ctx echo.Context) error {
+	userID := middleware.GetFirebaseUID(ctx)
+
+	var req requests.UpdateNotificationSettingsRequest
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+			Error:   "bad_request",
+			Message: "リクエストが不正です",
+		})
+	}
+	if err := req.Validate(); err != nil {
+		return ctx.JSON(http.StatusBadRequest, &response.ErrorResponse{
+			Error:   "bad_request",
+			Message: err.Error(),
+		})
+	}
+
+	settings, err := c.notificationService.UpdateSettings(userID, req)
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, &response.ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "通知設定の更新に失敗しました",
+		})
+	}
+	return ctx.JSON(http.StatusOK, settings)
+}
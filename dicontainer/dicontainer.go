@@ -1,90 +1,245 @@
 package dicontainer
 
 import (
+	"fmt"
+
 	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/config"
 	"github.com/hackathon-20260110/api/controller"
 	"github.com/hackathon-20260110/api/driver"
 	"github.com/hackathon-20260110/api/service"
 	"go.uber.org/dig"
 )
 
-func GetContainer() *dig.Container {
+// GetContainer 検証済みの設定からドライバー・アダプター・サービス・コントローラーを登録する
+func GetContainer(cfg *config.Config) *dig.Container {
 	container := dig.New()
-	err := container.Provide(driver.NewPsql)
+	err := container.Provide(func() *config.Config { return cfg })
 	if err != nil {
 		panic(err)
 	}
-	err = container.Provide(driver.NewFirestore)
+	err = container.Provide(driver.NewPsql)
 	if err != nil {
 		panic(err)
 	}
-	err = container.Provide(driver.NewGenAIClient)
+	err = provideExternalStores(container, cfg)
 	if err != nil {
 		panic(err)
 	}
-	err = container.Provide(adapter.NewR2ClientFromEnv)
+	err = container.Provide(adapter.NewUserAdapter)
 	if err != nil {
 		panic(err)
 	}
-	err = container.Provide(adapter.NewR2Adapter)
+	err = provideLLMAdapter(container, cfg)
 	if err != nil {
 		panic(err)
 	}
-	err = container.Provide(adapter.NewUserAdapter)
+	err = container.Provide(adapter.NewUserInfoAdapter)
 	if err != nil {
 		panic(err)
 	}
-	err = container.Provide(adapter.NewLLMAdapter)
+	err = container.Provide(adapter.NewAvatarAdapter)
 	if err != nil {
 		panic(err)
 	}
-	err = container.Provide(adapter.NewOnboardingAdapter)
+	err = container.Provide(adapter.NewProfileAdapter)
 	if err != nil {
 		panic(err)
 	}
-	err = container.Provide(adapter.NewUserInfoAdapter)
+	err = container.Provide(adapter.NewDiagnosisAdapter)
 	if err != nil {
 		panic(err)
 	}
-	err = container.Provide(adapter.NewAvatarAdapter)
+	err = container.Provide(adapter.NewPointEventAdapter)
 	if err != nil {
 		panic(err)
 	}
-	err = container.Provide(adapter.NewProfileAdapter)
+	err = container.Provide(adapter.NewAvatarChatMemoryAdapter)
 	if err != nil {
 		panic(err)
 	}
-	err = container.Provide(adapter.NewDiagnosisAdapter)
+	err = container.Provide(adapter.NewMissionAdapter)
 	if err != nil {
 		panic(err)
 	}
-	err = container.Provide(service.NewDiagnosisService)
+	err = container.Provide(adapter.NewMatchingAdapter)
 	if err != nil {
 		panic(err)
 	}
-	err = container.Provide(controller.NewDiagnosisController)
+	err = container.Provide(adapter.NewChatReadCursorAdapter)
 	if err != nil {
 		panic(err)
 	}
-	err = container.Provide(adapter.NewAvatarChatAdapter)
+	err = container.Provide(adapter.NewDeviceTokenAdapter)
 	if err != nil {
 		panic(err)
 	}
-	err = container.Provide(adapter.NewMissionAdapter)
+	err = providePushSender(container, cfg)
 	if err != nil {
 		panic(err)
 	}
-	err = container.Provide(adapter.NewMatchingAdapter)
+	err = container.Provide(adapter.NewNotificationSettingAdapter)
 	if err != nil {
 		panic(err)
 	}
-	err = container.Provide(adapter.NewUserChatAdapter)
+	err = container.Provide(adapter.NewJobAdapter)
 	if err != nil {
 		panic(err)
 	}
-	err = container.Provide(adapter.NewNotificationAdapter)
+	err = container.Provide(adapter.NewCandidateAdapter)
+	if err != nil {
+		panic(err)
+	}
+	err = provideTokenAuth(container, cfg)
+	if err != nil {
+		panic(err)
+	}
+	err = provideServices(container)
+	if err != nil {
+		panic(err)
+	}
+	err = provideControllers(container)
 	if err != nil {
 		panic(err)
 	}
 	return container
 }
+
+// provideServices サービスを登録する。コンテナ内で1度だけ組み立て、全リクエストで使い回す
+func provideServices(container *dig.Container) error {
+	constructors := []interface{}{
+		service.NewUserService,
+		service.NewDeviceService,
+		service.NewNotificationService,
+		service.NewOnboardingService,
+		service.NewProfileService,
+		service.NewAvatarService,
+		service.NewAvatarChatService,
+		service.NewChatService,
+		service.NewCandidateService,
+		service.NewUserChatService,
+		service.NewAdminService,
+		service.NewDiagnosisService,
+	}
+	for _, constructor := range constructors {
+		if err := container.Provide(constructor); err != nil {
+			return err
+		}
+	}
+	// 通知は設定やおやすみ時間を見てから届けるため、Notifierを使うサービスにはNotificationServiceを渡す
+	return container.Provide(func(s *service.NotificationService) service.Notifier { return s })
+}
+
+// provideControllers コントローラーを登録する。ルーターはここから取り出して使う
+func provideControllers(container *dig.Container) error {
+	constructors := []interface{}{
+		controller.NewDebugController,
+		controller.NewDebugTokenController,
+		controller.NewAuthController,
+		controller.NewOnboardingController,
+		controller.NewUserController,
+		controller.NewChatController,
+		controller.NewMatchController,
+		controller.NewMatchPostController,
+		controller.NewAvatarController,
+		controller.NewProfileController,
+		controller.NewDiagnosisController,
+		controller.NewAvatarChatController,
+		controller.NewUserChatController,
+		controller.NewNotificationController,
+		controller.NewAdminController,
+	}
+	for _, constructor := range constructors {
+		if err := container.Provide(constructor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// provideExternalStores Firestoreに置くチャット・通知と、R2に置く画像のアダプターを登録する。
+// FLAVOR=local ではネットワークに出ないよう、メモリとローカルのディレクトリに置く実装に切り替える
+func provideExternalStores(container *dig.Container, cfg *config.Config) error {
+	constructors := []interface{}{
+		driver.NewFirestore,
+		adapter.NewR2Client,
+		adapter.NewR2Adapter,
+		adapter.NewOnboardingAdapter,
+		adapter.NewAvatarChatAdapter,
+		adapter.NewUserChatAdapter,
+		adapter.NewChatSummaryAdapter,
+		adapter.NewNotificationAdapter,
+	}
+	if cfg.IsLocal() {
+		constructors = []interface{}{
+			adapter.NewInMemoryStore,
+			adapter.NewLocalR2Adapter,
+			adapter.NewInMemoryOnboardingAdapter,
+			adapter.NewInMemoryAvatarChatAdapter,
+			adapter.NewInMemoryUserChatAdapter,
+			adapter.NewInMemoryChatSummaryAdapter,
+			adapter.NewInMemoryNotificationAdapter,
+		}
+	}
+	for _, constructor := range constructors {
+		if err := container.Provide(constructor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// provideLLMAdapter LLM_PROVIDERに応じてLLMAdapterの実装を切り替える
+func provideLLMAdapter(container *dig.Container, cfg *config.Config) error {
+	switch provider := adapter.LLMProvider(cfg.LLMProvider); provider {
+	case adapter.LLMProviderScripted:
+		return container.Provide(adapter.NewScriptedLLMAdapter)
+	case adapter.LLMProviderGemini:
+		if err := container.Provide(driver.NewGenAIClient); err != nil {
+			return err
+		}
+		return container.Provide(adapter.NewGeminiLLMAdapter)
+	default:
+		return fmt.Errorf("unknown LLM_PROVIDER: %q (expected 'gemini' or 'scripted')", provider)
+	}
+}
+
+// provideTokenAuth AUTH_PROVIDERに応じて、認証ミドルウェアのTokenVerifierの実装を切り替える。
+// /debug/id-token のTokenIssuerは local のときだけ登録する
+func provideTokenAuth(container *dig.Container, cfg *config.Config) error {
+	switch provider := adapter.AuthProvider(cfg.Auth.Provider); provider {
+	case adapter.AuthProviderLocal:
+		if err := container.Provide(adapter.NewLocalTokenAuth); err != nil {
+			return err
+		}
+		if err := container.Provide(func(a *adapter.LocalTokenAuth) adapter.TokenVerifier { return a }); err != nil {
+			return err
+		}
+		return container.Provide(func(a *adapter.LocalTokenAuth) adapter.TokenIssuer { return a })
+	case adapter.AuthProviderFirebase:
+		if err := container.Provide(driver.FirebaseAuthClient); err != nil {
+			return err
+		}
+		if err := container.Provide(adapter.NewFirebaseTokenAuth); err != nil {
+			return err
+		}
+		return container.Provide(func(a *adapter.FirebaseTokenAuth) adapter.TokenVerifier { return a })
+	default:
+		return fmt.Errorf("unknown AUTH_PROVIDER: %q (expected 'firebase' or 'local')", provider)
+	}
+}
+
+// providePushSender PUSH_PROVIDERに応じてPushSenderの実装を切り替える
+func providePushSender(container *dig.Container, cfg *config.Config) error {
+	switch provider := adapter.PushProvider(cfg.PushProvider); provider {
+	case adapter.PushProviderRecording:
+		return container.Provide(adapter.NewRecordingPushSenderAsPushSender)
+	case adapter.PushProviderFCM:
+		if err := container.Provide(driver.NewMessagingClient); err != nil {
+			return err
+		}
+		return container.Provide(adapter.NewFCMPushSender)
+	default:
+		return fmt.Errorf("unknown PUSH_PROVIDER: %q (expected 'fcm' or 'recording')", provider)
+	}
+}
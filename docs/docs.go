@@ -18,6 +18,77 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/users/{userId}/avatars/{avatarId}/matching-points": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "指定ユーザーと指定アバターのマッチングポイントを運営が増減させる。roleクレームがadminのトークンのみ実行でき、理由とともに監査ログに記録される",
+                "tags": [
+                    "admin"
+                ],
+                "summary": "マッチングポイント調整（運営用）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ポイントを調整するユーザーID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "アバターID（ULID）",
+                        "name": "avatarId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "増減させるポイントと理由",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requests.AdjustMatchingPointRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "マッチングポイント調整成功",
+                        "schema": {
+                            "$ref": "#/definitions/response.AdjustMatchingPointResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていない、またはトークンが不正",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "運営権限がない",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "ユーザーまたはアバターが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/auth/me": {
             "get": {
                 "security": [
@@ -53,7 +124,7 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "アバターとのチャット履歴を取得する",
+                "description": "アバターとのチャット履歴を古い順に取得する。before/afterにメッセージIDを渡して前後のページを取得できる",
                 "tags": [
                     "avatar-chat"
                 ],
@@ -65,6 +136,25 @@ const docTemplate = `{
                         "name": "avatar_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "取得件数（最大100）",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "このメッセージIDより古いものを取得する。未指定なら最新から",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "このメッセージIDより新しいものを取得する。beforeとは同時に指定できない",
+                        "name": "after",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -135,6 +225,127 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "AIの応答が不正",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/avatar-chats/{avatar_id}/messages/stream": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "アバターにメッセージを送信し、応答をServer-Sent Eventsで受け取る。\ntoken イベントで返信の断片（response.AvatarChatStreamChunk）を順に送り、保存完了後に done イベントで送信結果を送る。\n途中で失敗した場合は error イベント（response.ErrorResponse）を送って終了する。\ndone イベントの avatar_response.message が保存された正式な本文なので、表示はこれで置き換えること。\nEventSourceは自動で再接続してメッセージを送り直してしまうため、fetchでPOSTしてレスポンスのストリームを読むこと。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "avatar-chat"
+                ],
+                "summary": "アバターチャットメッセージ送信（ストリーミング）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "アバターID",
+                        "name": "avatar_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "メッセージ送信リクエスト",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requests.SendAvatarChatMessageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "done イベントのデータ",
+                        "schema": {
+                            "$ref": "#/definitions/response.SendAvatarChatMessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていない、またはトークンが不正",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/avatar-chats/{avatar_id}/read": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "アバターとのチャットを既読にし、チャット一覧の未読件数を減らす",
+                "tags": [
+                    "avatar-chat"
+                ],
+                "summary": "アバターチャット既読",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "アバターID",
+                        "name": "avatar_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "既読にする位置。未指定なら最新のメッセージまで",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/requests.MarkChatAsReadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "既読にした",
+                        "schema": {
+                            "$ref": "#/definitions/response.MarkChatAsReadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていない、またはトークンが不正",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "アバターが見つからない",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -210,18 +421,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/avatars/{avatarId}/matching-points": {
-            "post": {
+        "/avatars/{avatarId}/point-history": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "指定されたアバターとのマッチングポイントを更新する",
+                "description": "指定されたアバターとのマッチングポイントが、いつ・なぜ増減したかを新しい順に取得する",
                 "tags": [
                     "avatars"
                 ],
-                "summary": "マッチングポイント更新",
+                "summary": "マッチングポイント履歴取得",
                 "parameters": [
                     {
                         "type": "string",
@@ -231,20 +442,23 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "更新するポイント数",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/requests.UpdateMatchingPointRequest"
-                        }
+                        "type": "integer",
+                        "description": "取得件数（デフォルト20、最大100）",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "オフセット",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "マッチングポイント更新成功",
+                        "description": "ポイント履歴取得成功",
                         "schema": {
-                            "$ref": "#/definitions/response.UpdateMatchingPointResponse"
+                            "$ref": "#/definitions/response.PointHistoryResponse"
                         }
                     },
                     "400": {
@@ -674,18 +888,24 @@ const docTemplate = `{
         },
         "/debug/id-token": {
             "get": {
-                "description": "Firebase ID token（IDトークン）を直接生成します。このトークンはテスト・開発用途で使用できます。\nこのトークンはBearerトークンとしてAuthorizationヘッダーで直接使用できます。\n内部的には、カスタムトークンを生成してからFirebase REST APIで交換しています。",
+                "description": "指定したユーザーのID token（IDトークン）を直接生成します。このトークンはテスト・開発用途で使用できます。\nこのトークンはBearerトークンとしてAuthorizationヘッダーで直接使用できます。\nAUTH_PROVIDER=local（FLAVOR=local を含む）のときだけ登録され、APIが署名したJWTを返します。dev・prdのFirebase認証では使えません。",
                 "tags": [
                     "debug"
                 ],
-                "summary": "Firebase ID Tokenを生成",
+                "summary": "ID Tokenを生成",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Firebase User ID (UID)",
+                        "description": "User ID (UID)",
                         "name": "userId",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "トークンに付けるroleクレーム（運営用APIを試すなら admin）",
+                        "name": "role",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -708,7 +928,7 @@ const docTemplate = `{
                         }
                     },
                     "503": {
-                        "description": "Firebase Web API Key not configured",
+                        "description": "Token issuer not configured",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -723,7 +943,7 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "自分のAvatarと相手のAvatarの会話を分析して相性診断を実行し、マッチングポイントを加算する",
+                "description": "自分のAvatarと相手のAvatarの会話をサーバー側で生成・分析して相性診断を実行し、マッチングポイントを加算する。\n同じ相手との診断は24時間に1回まで",
                 "tags": [
                     "diagnosis"
                 ],
@@ -764,11 +984,23 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "429": {
+                        "description": "同じ相手と24時間以内に診断済み",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "サーバーエラー",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "AIの診断結果が不正",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -913,7 +1145,7 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "マッチング前の相手候補の一覧を取得する（基本情報のみ）",
+                "description": "マッチング前の相手候補の一覧を相性スコアの高い順に取得する（基本情報のみ）。自分・マッチング済み・ブロック関係にあるユーザーと、お互いの希望（性別・年齢・距離）を満たさないユーザーは含まない。\n並べ替えるのは条件に合う中からマッチングポイントの高い順に200人まで。超えた場合は truncated が true になり、total はその200人から数え、200件目より後ろのオフセットは空になる",
                 "tags": [
                     "matches"
                 ],
@@ -922,7 +1154,7 @@ const docTemplate = `{
                     {
                         "type": "integer",
                         "default": 20,
-                        "description": "取得件数",
+                        "description": "取得件数（最大100）",
                         "name": "limit",
                         "in": "query"
                     },
@@ -932,6 +1164,28 @@ const docTemplate = `{
                         "description": "オフセット",
                         "name": "offset",
                         "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "最小年齢。未指定なら自分の希望年齢",
+                        "name": "min_age",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "最大年齢。未指定なら自分の希望年齢",
+                        "name": "max_age",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "性別（male, female, other）。複数指定可、未指定なら自分の恋愛対象",
+                        "name": "gender",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -941,6 +1195,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.GetPartnersResponse"
                         }
                     },
+                    "400": {
+                        "description": "リクエストが不正",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "認証されていない、またはトークンが不正",
                         "schema": {
@@ -957,7 +1217,7 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "マッチした相手（本人）とのメッセージ履歴を取得する",
+                "description": "マッチした相手（本人）とのメッセージ履歴を古い順に取得する。before/afterにメッセージIDを渡して前後のページを取得できる",
                 "tags": [
                     "matches"
                 ],
@@ -973,15 +1233,20 @@ const docTemplate = `{
                     {
                         "type": "integer",
                         "default": 20,
-                        "description": "取得件数",
+                        "description": "取得件数（最大100）",
                         "name": "limit",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "オフセット",
-                        "name": "offset",
+                        "type": "string",
+                        "description": "このメッセージIDより古いものを取得する。未指定なら最新から",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "このメッセージIDより新しいものを取得する。beforeとは同時に指定できない",
+                        "name": "after",
                         "in": "query"
                     }
                 ],
@@ -992,6 +1257,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.GetMatchMessagesResponse"
                         }
                     },
+                    "400": {
+                        "description": "リクエストが不正",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "認証されていない、またはトークンが不正",
                         "schema": {
@@ -1018,7 +1289,7 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "マッチした相手（本人）にメッセージを送信する",
+                "description": "マッチした相手（本人）にメッセージを送信する。相手には新着メッセージとして通知する",
                 "tags": [
                     "matches"
                 ],
@@ -1179,31 +1450,126 @@ const docTemplate = `{
                 }
             }
         },
-        "/notification/read/{id}": {
-            "post": {
+        "/notification": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "指定された通知IDの通知を既読状態にします",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "自分宛ての通知を新しい順に取得します。続きはレスポンスのnext_cursorをcursorに指定して取得します",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "notification"
                 ],
-                "summary": "通知を既読にする",
+                "summary": "通知一覧を取得する",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "通知ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "前のページのnext_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "取得件数（最大100）",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.NotificationListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notification/read-all": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "自分宛ての未読通知をすべて既読状態にします",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "summary": "すべての通知を既読にする",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MarkAllAsReadResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notification/read/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "指定された通知IDの通知を既読状態にします",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "summary": "通知を既読にする",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "通知ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1234,6 +1600,101 @@ const docTemplate = `{
                 }
             }
         },
+        "/notification/unread-count": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "自分宛ての未読通知の件数を返します",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "summary": "未読の通知件数を取得する",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.UnreadCountResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notification/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "指定された通知IDの通知を削除します",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "summary": "通知を削除する",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "通知ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.DeleteNotificationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/onboarding/chats/messages": {
             "post": {
                 "security": [
@@ -1279,6 +1740,34 @@ const docTemplate = `{
                 }
             }
         },
+        "/onboarding/chats/reply-status": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "直近に送信したメッセージに対するシステムの返信が、生成待ち（pending）・失敗（failed）・完了（done）のどれかを取得する",
+                "tags": [
+                    "onboarding"
+                ],
+                "summary": "オンボーディング返信の生成状況取得",
+                "responses": {
+                    "200": {
+                        "description": "生成状況取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/response.OnboardingReplyStatusResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていない、またはトークンが不正",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/onboarding/finish": {
             "post": {
                 "security": [
@@ -1309,6 +1798,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "AIによるプロフィール抽出結果が不正",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -1403,12 +1898,327 @@ const docTemplate = `{
                 "tags": [
                     "user-chat"
                 ],
-                "summary": "マッチしているユーザー一覧取得",
+                "summary": "マッチしているユーザー一覧取得",
+                "responses": {
+                    "200": {
+                        "description": "マッチしているユーザー一覧取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetMatchedUsersResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていない、またはトークンが不正",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user-chats/{partner_id}/messages": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "マッチしているユーザーとのチャット履歴を取得する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-chat"
+                ],
+                "summary": "ユーザーチャットメッセージ一覧取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "相手ユーザーID",
+                        "name": "partner_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "取得件数（最大100）",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "このメッセージIDより古いものを取得する。未指定なら最新から",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "このメッセージIDより新しいものを取得する。beforeとは同時に指定できない",
+                        "name": "after",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "メッセージ一覧取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetUserChatMessagesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていない、またはトークンが不正",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "マッチしていないユーザーのメッセージ取得",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "マッチしているユーザーにメッセージを送信する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-chat"
+                ],
+                "summary": "ユーザーチャットメッセージ送信",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "相手ユーザーID",
+                        "name": "partner_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "メッセージ内容",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requests.SendUserChatMessageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "メッセージ送信成功",
+                        "schema": {
+                            "$ref": "#/definitions/response.SendUserChatMessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていない、またはトークンが不正",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "マッチしていないユーザーへのメッセージ送信",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user-chats/{partner_id}/read": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "マッチしているユーザーとのチャットを既読にする。自分の既読位置は相手のメッセージ履歴取得で「既読」として表示される",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-chat"
+                ],
+                "summary": "ユーザーチャット既読",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "相手ユーザーID",
+                        "name": "partner_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "既読にする位置。未指定なら最新のメッセージまで",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/requests.MarkChatAsReadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "既読にした",
+                        "schema": {
+                            "$ref": "#/definitions/response.MarkChatAsReadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていない、またはトークンが不正",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "マッチしていないユーザーとのチャット",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "ユーザーを作成する。恋愛対象の性別・希望年齢・距離の上限を合わせて登録でき、相手探しはお互いの希望を満たす相手に限られる",
+                "tags": [
+                    "users"
+                ],
+                "summary": "ユーザー作成",
+                "parameters": [
+                    {
+                        "description": "ユーザー作成リクエスト",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requests.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ユーザー作成成功",
+                        "schema": {
+                            "$ref": "#/definitions/response.User"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていない、またはトークンが不正",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "自分の全情報を取得する（マイページ用）。ユーザーが登録されていない場合はis_registered=falseを返す。",
+                "tags": [
+                    "users"
+                ],
+                "summary": "自分の情報取得（マイページ用）",
+                "responses": {
+                    "200": {
+                        "description": "自分の情報取得成功",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetMeResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていない、またはトークンが不正",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "自分のプロフィール情報を更新する",
+                "tags": [
+                    "users"
+                ],
+                "summary": "自分の情報更新",
+                "parameters": [
+                    {
+                        "description": "ユーザー更新リクエスト",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requests.UpdateUserRequest"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "マッチしているユーザー一覧取得成功",
+                        "description": "情報更新成功",
                         "schema": {
-                            "$ref": "#/definitions/response.GetMatchedUsersResponse"
+                            "$ref": "#/definitions/response.User"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストが不正",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "401": {
@@ -1420,38 +2230,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/user-chats/{partner_id}/messages": {
+        "/users/me/avatar-ai": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "マッチしているユーザーとのチャット履歴を取得する",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "自分の分身AI情報を取得する",
                 "tags": [
-                    "user-chat"
-                ],
-                "summary": "ユーザーチャットメッセージ一覧取得",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "相手ユーザーID",
-                        "name": "partner_id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "users"
                 ],
+                "summary": "自分の分身AI情報取得",
                 "responses": {
                     "200": {
-                        "description": "メッセージ一覧取得成功",
+                        "description": "分身AI情報取得成功",
                         "schema": {
-                            "$ref": "#/definitions/response.GetUserChatMessagesResponse"
+                            "$ref": "#/definitions/response.GetAvatarAIResponse"
                         }
                     },
                     "401": {
@@ -1460,54 +2255,43 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "マッチしていないユーザーのメッセージ取得",
+                    "404": {
+                        "description": "分身AIが存在しない（オンボーディング未完了）",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/users/me/devices": {
             "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "マッチしているユーザーにメッセージを送信する",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "FCMの登録トークンを登録する。登録済みのトークンは自分の端末として上書きする",
                 "tags": [
-                    "user-chat"
+                    "users"
                 ],
-                "summary": "ユーザーチャットメッセージ送信",
+                "summary": "プッシュ通知を受け取る端末の登録",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "相手ユーザーID",
-                        "name": "partner_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "メッセージ内容",
+                        "description": "端末登録リクエスト",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/requests.SendUserChatMessageRequest"
+                            "$ref": "#/definitions/requests.RegisterDeviceRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "メッセージ送信成功",
+                        "description": "端末登録成功",
                         "schema": {
-                            "$ref": "#/definitions/response.SendUserChatMessageResponse"
+                            "$ref": "#/definitions/response.Device"
                         }
                     },
                     "400": {
@@ -1521,44 +2305,36 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
-                    },
-                    "403": {
-                        "description": "マッチしていないユーザーへのメッセージ送信",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
                     }
                 }
-            }
-        },
-        "/users": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "ユーザーを作成する",
+                "description": "ログアウト時などに、FCMの登録トークンをプッシュ通知の送信先から外す",
                 "tags": [
                     "users"
                 ],
-                "summary": "ユーザー作成",
+                "summary": "プッシュ通知を受け取る端末の登録解除",
                 "parameters": [
                     {
-                        "description": "ユーザー作成リクエスト",
+                        "description": "端末登録解除リクエスト",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/requests.CreateUserRequest"
+                            "$ref": "#/definitions/requests.UnregisterDeviceRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "ユーザー作成成功",
+                        "description": "登録解除成功",
                         "schema": {
-                            "$ref": "#/definitions/response.User"
+                            "$ref": "#/definitions/response.UnregisterDeviceResponse"
                         }
                     },
                     "400": {
@@ -1572,27 +2348,33 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "端末が登録されていない",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/users/me": {
+        "/users/me/notification-settings": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "自分の全情報を取得する（マイページ用）。ユーザーが登録されていない場合はis_registered=falseを返す。",
+                "description": "通知の種類ごとの受け取り設定とおやすみ時間を取得する。未設定の場合は既定値（すべて受け取る・おやすみ時間なし）を返す",
                 "tags": [
                     "users"
                 ],
-                "summary": "自分の情報取得（マイページ用）",
+                "summary": "通知設定の取得",
                 "responses": {
                     "200": {
-                        "description": "自分の情報取得成功",
+                        "description": "通知設定取得成功",
                         "schema": {
-                            "$ref": "#/definitions/response.GetMeResponse"
+                            "$ref": "#/definitions/response.NotificationSettings"
                         }
                     },
                     "401": {
@@ -1609,27 +2391,27 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "自分のプロフィール情報を更新する",
+                "description": "通知の種類ごとの受け取り設定とおやすみ時間を更新する。オフにした種類の通知は届かなくなる。おやすみ時間中の通知は一覧には入るがプッシュ通知は送らず、明けた後にまとめて1通送る",
                 "tags": [
                     "users"
                 ],
-                "summary": "自分の情報更新",
+                "summary": "通知設定の更新",
                 "parameters": [
                     {
-                        "description": "ユーザー更新リクエスト",
+                        "description": "通知設定更新リクエスト",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/requests.UpdateUserRequest"
+                            "$ref": "#/definitions/requests.UpdateNotificationSettingsRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "情報更新成功",
+                        "description": "通知設定更新成功",
                         "schema": {
-                            "$ref": "#/definitions/response.User"
+                            "$ref": "#/definitions/response.NotificationSettings"
                         }
                     },
                     "400": {
@@ -1647,40 +2429,6 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/me/avatar-ai": {
-            "get": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "自分の分身AI情報を取得する",
-                "tags": [
-                    "users"
-                ],
-                "summary": "自分の分身AI情報取得",
-                "responses": {
-                    "200": {
-                        "description": "分身AI情報取得成功",
-                        "schema": {
-                            "$ref": "#/definitions/response.GetAvatarAIResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "認証されていない、またはトークンが不正",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "分身AIが存在しない（オンボーディング未完了）",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
         "/users/me/profile": {
             "get": {
                 "security": [
@@ -2061,6 +2809,20 @@ const docTemplate = `{
                 "UserInfoTypeImage"
             ]
         },
+        "requests.AdjustMatchingPointRequest": {
+            "type": "object",
+            "properties": {
+                "points": {
+                    "description": "Points 加算するポイント。減算する場合は負の値を指定する",
+                    "type": "integer",
+                    "example": -10
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "不正なポイント獲得が確認されたため"
+                }
+            }
+        },
         "requests.CreateChatRequest": {
             "type": "object",
             "required": [
@@ -2121,9 +2883,42 @@ const docTemplate = `{
                     "example": "山田太郎"
                 },
                 "gender": {
+                    "description": "male, female, other",
                     "type": "string",
                     "example": "male"
                 },
+                "interested_in_genders": {
+                    "description": "InterestedInGenders 恋愛対象の性別。省略すると異性（otherは全員）",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "female"
+                    ]
+                },
+                "latitude": {
+                    "type": "number",
+                    "example": 35.681236
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 139.767125
+                },
+                "max_distance_km": {
+                    "description": "MaxDistanceKm 相手との距離の上限。位置情報と合わせて指定する",
+                    "type": "integer",
+                    "example": 30
+                },
+                "preferred_max_age": {
+                    "type": "integer",
+                    "example": 35
+                },
+                "preferred_min_age": {
+                    "description": "PreferredMinAge / PreferredMaxAge 相手の年齢の希望。省略すると制限しない",
+                    "type": "integer",
+                    "example": 20
+                },
                 "profile_image_base64": {
                     "type": "string",
                     "example": "data:image/jpeg;base64,..."
@@ -2141,17 +2936,33 @@ const docTemplate = `{
         "requests.ExecuteDiagnosisRequest": {
             "type": "object",
             "required": [
-                "conversation_data",
                 "target_avatar_id"
             ],
             "properties": {
-                "conversation_data": {
-                    "type": "object",
-                    "additionalProperties": true
-                },
                 "target_avatar_id": {
                     "type": "string",
                     "example": "01ARZ3NDEKTSV4RRFFQ69G5FBV"
+                },
+                "topic": {
+                    "description": "会話のテーマ（任意、service.MaxDiagnosisTopicRunes 文字まで）",
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "休日の過ごし方"
+                },
+                "turn_count": {
+                    "description": "往復数（省略時はデフォルト値）",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "requests.MarkChatAsReadRequest": {
+            "type": "object",
+            "properties": {
+                "last_read_message_id": {
+                    "description": "LastReadMessageID このメッセージまでを既読にする。未指定なら最新のメッセージまで",
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
                 }
             }
         },
@@ -2170,6 +2981,24 @@ const docTemplate = `{
                 }
             }
         },
+        "requests.RegisterDeviceRequest": {
+            "type": "object",
+            "required": [
+                "platform",
+                "token"
+            ],
+            "properties": {
+                "platform": {
+                    "description": "ios, android, web",
+                    "type": "string",
+                    "example": "ios"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "fcm-registration-token"
+                }
+            }
+        },
         "requests.SendAvatarChatMessageRequest": {
             "type": "object",
             "required": [
@@ -2233,15 +3062,53 @@ const docTemplate = `{
         "requests.StartOnboardingRequest": {
             "type": "object"
         },
-        "requests.UpdateMatchingPointRequest": {
+        "requests.UnregisterDeviceRequest": {
             "type": "object",
             "required": [
-                "points"
+                "token"
             ],
             "properties": {
-                "points": {
-                    "type": "integer",
-                    "minimum": 1
+                "token": {
+                    "type": "string",
+                    "example": "fcm-registration-token"
+                }
+            }
+        },
+        "requests.UpdateNotificationSettingsRequest": {
+            "type": "object",
+            "properties": {
+                "avatar_activity_enabled": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "match_enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "message_enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "mission_unlock_enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "quiet_hours_enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "quiet_hours_end": {
+                    "type": "string",
+                    "example": "07:00"
+                },
+                "quiet_hours_start": {
+                    "description": "QuietHoursStart / QuietHoursEnd \"HH:MM\"。開始が終了より遅ければ日をまたぐ",
+                    "type": "string",
+                    "example": "22:00"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Tokyo"
                 }
             }
         },
@@ -2287,6 +3154,23 @@ const docTemplate = `{
                 }
             }
         },
+        "response.AdjustMatchingPointResponse": {
+            "type": "object",
+            "properties": {
+                "audit": {
+                    "$ref": "#/definitions/response.PointAdjustmentAudit"
+                },
+                "event": {
+                    "$ref": "#/definitions/response.PointEvent"
+                },
+                "is_matched": {
+                    "type": "boolean"
+                },
+                "relation": {
+                    "$ref": "#/definitions/response.UserAvatarRelation"
+                }
+            }
+        },
         "response.Avatar": {
             "type": "object",
             "properties": {
@@ -2465,6 +3349,7 @@ const docTemplate = `{
                     "example": "佐藤花子"
                 },
                 "unread_count": {
+                    "description": "相手（アバターの場合はアバター）からの未読メッセージ数",
                     "type": "integer",
                     "example": 0
                 },
@@ -2494,6 +3379,7 @@ const docTemplate = `{
                     "example": 100
                 },
                 "score_breakdown": {
+                    "description": "ポイント履歴の発生源（avatar_chat, diagnosis, manual, decay）ごとの合計",
                     "type": "object",
                     "additionalProperties": {
                         "type": "integer"
@@ -2502,7 +3388,7 @@ const docTemplate = `{
                 "unlock_threshold": {
                     "description": "マッチング成立に必要なスコア",
                     "type": "integer",
-                    "example": 80
+                    "example": 100
                 },
                 "updated_at": {
                     "type": "string",
@@ -2533,17 +3419,51 @@ const docTemplate = `{
                     "type": "string",
                     "example": "3600"
                 },
-                "id_token": {
+                "id_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJSUzI1NiIsImtpZCI6IjFlOWdkazcifQ..."
+                },
+                "token_type": {
+                    "type": "string",
+                    "example": "Bearer"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "user-12345"
+                }
+            }
+        },
+        "response.DeleteNotificationResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "通知を削除しました"
+                }
+            }
+        },
+        "response.Device": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "platform": {
                     "type": "string",
-                    "example": "eyJhbGciOiJSUzI1NiIsImtpZCI6IjFlOWdkazcifQ..."
+                    "example": "ios"
                 },
-                "token_type": {
+                "token": {
                     "type": "string",
-                    "example": "Bearer"
+                    "example": "fcm-registration-token"
                 },
-                "user_id": {
+                "updated_at": {
                     "type": "string",
-                    "example": "user-12345"
+                    "example": "2024-01-01T00:00:00Z"
                 }
             }
         },
@@ -2705,6 +3625,11 @@ const docTemplate = `{
                     "items": {
                         "$ref": "#/definitions/response.AvatarChatMessage"
                     }
+                },
+                "next_cursor": {
+                    "description": "NextCursor 続きがあれば次のbefore（afterで取得した場合は次のafter）に渡すメッセージID。無ければ空",
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
                 }
             }
         },
@@ -2771,13 +3696,15 @@ const docTemplate = `{
                         "$ref": "#/definitions/response.MatchMessage"
                     }
                 },
-                "offset": {
-                    "type": "integer",
-                    "example": 0
+                "next_cursor": {
+                    "description": "NextCursor 続きがあれば次のbefore（afterで取得した場合は次のafter）に渡すメッセージID。無ければ空",
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
                 },
-                "total": {
-                    "type": "integer",
-                    "example": 20
+                "partner_last_read_message_id": {
+                    "description": "PartnerLastReadMessageID 相手が既読にした最後のメッセージID。これ以下のIDの自分のメッセージに「既読」を表示する",
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
                 }
             }
         },
@@ -2837,8 +3764,14 @@ const docTemplate = `{
                     }
                 },
                 "total": {
+                    "description": "Total 並べ替えの対象になった人数。Truncatedなら条件に合う全員ではない",
                     "type": "integer",
                     "example": 50
+                },
+                "truncated": {
+                    "description": "Truncated 条件に合う候補が多く、マッチングポイントの高い順に上限の人数だけを並べ替えた。\nこの場合、上限より後ろのオフセットは空になり、ポイントの無い相手は相性が良くても含まれないことがある",
+                    "type": "boolean",
+                    "example": false
                 }
             }
         },
@@ -2877,6 +3810,16 @@ const docTemplate = `{
                     "items": {
                         "$ref": "#/definitions/response.UserChatMessageResponse"
                     }
+                },
+                "next_cursor": {
+                    "description": "NextCursor 続きがあれば次のbefore（afterで取得した場合は次のafter）に渡すメッセージID。無ければ空",
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "partner_last_read_message_id": {
+                    "description": "PartnerLastReadMessageID 相手が既読にした最後のメッセージID。これ以下のIDの自分のメッセージに「既読」を表示する",
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
                 }
             }
         },
@@ -2888,6 +3831,19 @@ const docTemplate = `{
                 }
             }
         },
+        "response.MarkAllAsReadResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "すべての通知を既読にしました"
+                },
+                "updated_count": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
         "response.MarkAsReadResponse": {
             "type": "object",
             "properties": {
@@ -2897,6 +3853,21 @@ const docTemplate = `{
                 }
             }
         },
+        "response.MarkChatAsReadResponse": {
+            "type": "object",
+            "properties": {
+                "last_read_message_id": {
+                    "description": "LastReadMessageID 既読にした最後のメッセージID。メッセージが無ければ空",
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "unread_count": {
+                    "description": "UnreadCount まだ読んでいない相手からのメッセージ数",
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
         "response.Match": {
             "type": "object",
             "properties": {
@@ -3053,6 +4024,110 @@ const docTemplate = `{
                 }
             }
         },
+        "response.Notification": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt 通知の作成日時",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "deep_link": {
+                    "type": "string",
+                    "example": "/matches/01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "has_read": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "佐藤花子さんとマッチングしました！"
+                },
+                "payload": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "example": "マッチング成立"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "match",
+                        "mission_unlock",
+                        "message",
+                        "avatar_activity",
+                        "system"
+                    ],
+                    "example": "match"
+                }
+            }
+        },
+        "response.NotificationListResponse": {
+            "type": "object",
+            "properties": {
+                "has_more": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "next_cursor": {
+                    "description": "NextCursor 次のページを取得するときにcursorに指定する。最後のページでは空",
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "notifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.Notification"
+                    }
+                }
+            }
+        },
+        "response.NotificationSettings": {
+            "type": "object",
+            "properties": {
+                "avatar_activity_enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "match_enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "message_enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "mission_unlock_enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "quiet_hours_enabled": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "quiet_hours_end": {
+                    "type": "string",
+                    "example": "07:00"
+                },
+                "quiet_hours_start": {
+                    "type": "string",
+                    "example": "22:00"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Tokyo"
+                }
+            }
+        },
         "response.OnboardingCompleteResponse": {
             "type": "object",
             "properties": {
@@ -3067,6 +4142,49 @@ const docTemplate = `{
                 }
             }
         },
+        "response.OnboardingReplyStatus": {
+            "type": "string",
+            "enum": [
+                "none",
+                "pending",
+                "failed",
+                "done"
+            ],
+            "x-enum-varnames": [
+                "OnboardingReplyStatusNone",
+                "OnboardingReplyStatusPending",
+                "OnboardingReplyStatusFailed",
+                "OnboardingReplyStatusDone"
+            ]
+        },
+        "response.OnboardingReplyStatusResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "job_id": {
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "next_retry_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "none, pending, failed, done",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.OnboardingReplyStatus"
+                        }
+                    ],
+                    "example": "pending"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
         "response.Partner": {
             "type": "object",
             "properties": {
@@ -3074,10 +4192,19 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 24
                 },
+                "avatar_id": {
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FCV"
+                },
                 "bio": {
                     "type": "string",
                     "example": "よろしくお願いします！"
                 },
+                "compatibility_score": {
+                    "description": "CompatibilityScore プロフィールの重なりとマッチングポイントから計算した相性（0〜100）。一覧はこの降順",
+                    "type": "integer",
+                    "example": 42
+                },
                 "display_name": {
                     "type": "string",
                     "example": "佐藤花子"
@@ -3090,12 +4217,100 @@ const docTemplate = `{
                     "type": "string",
                     "example": "01ARZ3NDEKTSV4RRFFQ69G5FBV"
                 },
+                "matching_point": {
+                    "description": "MatchingPoint 相手のアバターとの会話で貯めたポイント",
+                    "type": "integer",
+                    "example": 30
+                },
                 "profile_image_url": {
                     "type": "string",
                     "example": "https://example.com/images/profile2.jpg"
                 }
             }
         },
+        "response.PointAdjustmentAudit": {
+            "type": "object",
+            "properties": {
+                "admin_user_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "不正なポイント獲得が確認されたため"
+                },
+                "requested_delta": {
+                    "type": "integer",
+                    "example": -10
+                }
+            }
+        },
+        "response.PointEvent": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "delta": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "matching_point_after": {
+                    "type": "integer",
+                    "example": 45
+                },
+                "message_id": {
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FBV"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "共通の趣味の話で盛り上がった"
+                },
+                "source": {
+                    "description": "avatar_chat, diagnosis, manual, decay",
+                    "type": "string",
+                    "example": "avatar_chat"
+                }
+            }
+        },
+        "response.PointHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.PointEvent"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "matching_point": {
+                    "type": "integer",
+                    "example": 45
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
         "response.PredefinedKeyInfo": {
             "type": "object",
             "properties": {
@@ -3318,26 +4533,21 @@ const docTemplate = `{
                 }
             }
         },
-        "response.UpdateMatchingPointResponse": {
+        "response.UnreadCountResponse": {
             "type": "object",
             "properties": {
-                "avatar_id": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "matching_point": {
-                    "type": "integer"
-                },
-                "updated_at": {
-                    "type": "string"
-                },
-                "user_id": {
-                    "type": "string"
+                "unread_count": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "response.UnregisterDeviceResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "端末の登録を解除しました"
                 }
             }
         },
@@ -3368,10 +4578,31 @@ const docTemplate = `{
                     "type": "string",
                     "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
                 },
+                "interested_in_genders": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "female"
+                    ]
+                },
+                "max_distance_km": {
+                    "type": "integer",
+                    "example": 30
+                },
                 "onboarding_completed": {
                     "type": "boolean",
                     "example": false
                 },
+                "preferred_max_age": {
+                    "type": "integer",
+                    "example": 35
+                },
+                "preferred_min_age": {
+                    "type": "integer",
+                    "example": 20
+                },
                 "profile_image_url": {
                     "type": "string",
                     "example": "https://example.com/images/profile.jpg"
@@ -3546,7 +4777,7 @@ const docTemplate = `{
     },
     "securityDefinitions": {
         "Bearer": {
-            "description": "Firebase IDトークンをBearerトークンとして送信してください。例: \"Bearer eyJhbGciOiJSUzI1NiIs...\"",
+            "description": "IDトークン（AUTH_PROVIDER=firebase ならFirebase、local ならAPIが署名したJWT）をBearerトークンとして送信してください。例: \"Bearer eyJhbGciOiJSUzI1NiIs...\"",
             "type": "apiKey",
             "name": "Authorization",
             "in": "header"
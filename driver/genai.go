@@ -2,25 +2,29 @@ package driver
 
 import (
 	"context"
-	"log"
-	"os"
+	"errors"
+	"fmt"
 
+	"github.com/hackathon-20260110/api/config"
 	"google.golang.org/genai"
 )
 
-func NewGenAIClient() *genai.Client {
-	googleAPIKey := os.Getenv("GOOGLE_API_KEY")
-	geminiAPIKey := os.Getenv("GEMINI_API_KEY")
+var ErrGenAIAPIKeyNotSet = errors.New("genai: required environment variable (GOOGLE_API_KEY or GEMINI_API_KEY) is not set")
 
-	if googleAPIKey == "" && geminiAPIKey == "" {
-		log.Fatal("genai: required environment variable (GOOGLE_API_KEY or GEMINI_API_KEY) is not set")
+// NewGenAIClient APIキーが無い場合は起動を止めずにエラーを返す（LLM_PROVIDER=scriptedなら呼ばれない）
+func NewGenAIClient(cfg *config.Config) (*genai.Client, error) {
+	if cfg.GenAI.APIKey == "" {
+		return nil, ErrGenAIAPIKeyNotSet
 	}
 
 	ctx := context.Background()
-	client, err := genai.NewClient(ctx, nil)
+	client, err := genai.NewClient(ctx, &genai.ClientConfig{
+		APIKey:  cfg.GenAI.APIKey,
+		Backend: genai.BackendGeminiAPI,
+	})
 	if err != nil {
-		log.Fatalf("genai: failed to create client: %v", err)
+		return nil, fmt.Errorf("genai: failed to create client: %w", err)
 	}
 
-	return client
+	return client, nil
 }
@@ -2,60 +2,62 @@ package driver
 
 import (
 	"fmt"
-	"log"
 	"net/url"
-	"os"
 
+	"github.com/hackathon-20260110/api/config"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-// NewPsql は環境変数 flavor に応じて適切なPostgreSQLに接続し、*gorm.DB を返します。
-// - flavor=dev: ローカル環境（DB_HOST, DB_PORT, DB_USER, DB_PASSWORD, DB_NAME を使用）
+// NewPsql は flavor に応じて適切なPostgreSQLに接続し、*gorm.DB を返します。
+// - flavor=local, dev: ローカル環境（DB_HOST, DB_PORT, DB_USER, DB_PASSWORD, DB_NAME を使用）
 // - flavor=prd: Neon（DATABASE_URL を使用）
-func NewPsql() *gorm.DB {
-	dsn := buildDSN()
+// コネクションプールはプロセスで1つだけ作り、アダプター間で共有する。
+func NewPsql(cfg *config.Config) (*gorm.DB, error) {
+	dsn, err := buildDSN(cfg)
+	if err != nil {
+		return nil, err
+	}
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
 	if err != nil {
-		log.Fatalf("failed to connect to database: %v", err)
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
-	return db
-}
 
-func buildDSN() string {
-	flavor := os.Getenv("FLAVOR")
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database pool: %w", err)
+	}
+	sqlDB.SetMaxOpenConns(cfg.Database.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.Database.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.Database.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.Database.ConnMaxIdleTime)
+	return db, nil
+}
 
-	switch flavor {
-	case "dev":
-		return buildDevDSN()
-	case "prd":
-		return buildPrdDSN()
+func buildDSN(cfg *config.Config) (string, error) {
+	switch cfg.Flavor {
+	case config.FlavorLocal, config.FlavorDev:
+		return buildDevDSN(cfg.Database), nil
+	case config.FlavorPrd:
+		return buildPrdDSN(cfg.Database)
 	default:
-		log.Fatalf("unknown flavor: %q (expected 'dev' or 'prd')", flavor)
-		return ""
+		return "", fmt.Errorf("unknown flavor: %q (expected 'local', 'dev' or 'prd')", cfg.Flavor)
 	}
 }
 
-func buildDevDSN() string {
-	host := os.Getenv("DB_HOST")
-	port := os.Getenv("DB_PORT")
-	user := os.Getenv("DB_USER")
-	password := os.Getenv("DB_PASSWORD")
-	dbname := os.Getenv("DB_NAME")
-
+func buildDevDSN(db config.DatabaseConfig) string {
 	return fmt.Sprintf(
 		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		host, port, user, password, dbname,
+		db.Host, db.Port, db.User, db.Password, db.Name,
 	)
 }
 
-func buildPrdDSN() string {
-	databaseURL := os.Getenv("DATABASE_URL")
-	if databaseURL == "" {
-		log.Fatal("DATABASE_URL is required for prd flavor")
+func buildPrdDSN(db config.DatabaseConfig) (string, error) {
+	if db.URL == "" {
+		return "", fmt.Errorf("DATABASE_URL is required for prd flavor")
 	}
 
-	return ensureSSLMode(databaseURL, "require")
+	return ensureSSLMode(db.URL, "require"), nil
 }
 
 func ensureSSLMode(dsn, defaultMode string) string {
@@ -1,15 +1,21 @@
 package main
 
 import (
-	"os"
+	"context"
+	"log"
 
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/config"
 	"github.com/hackathon-20260110/api/dicontainer"
 	_ "github.com/hackathon-20260110/api/docs"
 	"github.com/hackathon-20260110/api/driver"
+	"github.com/hackathon-20260110/api/migrations"
 	"github.com/hackathon-20260110/api/router"
+	"github.com/hackathon-20260110/api/worker"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	echoSwagger "github.com/swaggo/echo-swagger"
+	"gorm.io/gorm"
 )
 
 // @title Hackathon API
@@ -19,14 +25,24 @@ import (
 // @securityDefinitions.apikey Bearer
 // @in header
 // @name Authorization
-// @description Firebase IDトークンをBearerトークンとして送信してください。例: "Bearer eyJhbGciOiJSUzI1NiIs..."
+// @description IDトークン（AUTH_PROVIDER=firebase ならFirebase、local ならAPIが署名したJWT）をBearerトークンとして送信してください。例: "Bearer eyJhbGciOiJSUzI1NiIs..."
 func main() {
 	// ================================
 	// 初期化処理
 	// ================================
 
-	// Firebase Admin SDK 初期化（失敗時は起動を止める）
-	driver.NewFirebaseAuth()
+	// 設定の読み込みと検証（足りない値をまとめて表示して起動を止める）
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if !cfg.IsLocal() {
+		// Firebase Admin SDK 初期化（失敗時は起動を止める）
+		if err := driver.NewFirebaseAuth(cfg); err != nil {
+			log.Fatal(err)
+		}
+	}
 	e := echo.New()
 
 	// ミドルウェア設定
@@ -34,7 +50,30 @@ func main() {
 	e.Use(middleware.Recover())
 	e.Use(middleware.BodyLimit("10M"))
 
-	container := dicontainer.GetContainer()
+	container := dicontainer.GetContainer(cfg)
+
+	if cfg.IsLocal() {
+		// docker compose up だけで動くよう、localではスキーマを起動時に最新にする
+		if err := container.Invoke(func(db *gorm.DB) error {
+			runner, err := migrations.NewRunner(db)
+			if err != nil {
+				return err
+			}
+			_, err = runner.Up()
+			return err
+		}); err != nil {
+			log.Fatal(err)
+		}
+		// R2の代わりに保存した画像を配信する
+		e.Static(adapter.LocalAssetsPath, cfg.Local.AssetsDir)
+	}
+
+	// ================================
+	// バックグラウンドジョブ
+	// ================================
+	if _, err := worker.Start(context.Background(), container); err != nil {
+		e.Logger.Fatal(err)
+	}
 
 	// ================================
 	// swaggerルート
@@ -70,10 +109,8 @@ func main() {
 	router.UserChatRouter(e, container)
 	// /notification/*
 	router.NotificationRouter(e, container)
+	// /admin/* (運営用、roleクレームがadminのみ)
+	router.AdminRouter(e, container)
 
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
-	}
-	e.Logger.Fatal(e.Start(":" + port))
+	e.Logger.Fatal(e.Start(":" + cfg.Port))
 }
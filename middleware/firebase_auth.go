@@ -4,7 +4,7 @@ import (
 	"net/http"
 	"strings"
 
-	"github.com/hackathon-20260110/api/driver"
+	"github.com/hackathon-20260110/api/adapter"
 	"github.com/hackathon-20260110/api/response"
 	"github.com/labstack/echo/v4"
 )
@@ -12,9 +12,15 @@ import (
 const (
 	contextKeyFirebaseUID = "firebase_uid"
 	contextKeyEmail       = "email"
+	contextKeyRole        = "role"
 )
 
-func FirebaseAuthMiddleware() echo.MiddlewareFunc {
+// RoleAdmin 運営用APIを呼び出せるユーザーのroleクレーム
+const RoleAdmin = "admin"
+
+// FirebaseAuthMiddleware BearerトークンをverifierでIDトークンとして検証し、ユーザーIDとクレームをコンテキストに入れる。
+// verifierはAUTH_PROVIDERに応じてFirebaseかローカルのJWTの実装になる
+func FirebaseAuthMiddleware(verifier adapter.TokenVerifier) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			// Get Authorization header
@@ -42,8 +48,8 @@ func FirebaseAuthMiddleware() echo.MiddlewareFunc {
 				})
 			}
 
-			// Verify the ID token using Firebase Admin SDK
-			uid, email, err := driver.VerifyIDToken(c.Request().Context(), idToken)
+			// Verify the ID token
+			token, err := verifier.VerifyIDToken(c.Request().Context(), idToken)
 			if err != nil {
 				return c.JSON(http.StatusUnauthorized, &response.ErrorResponse{
 					Error:   "invalid_token",
@@ -51,6 +57,7 @@ func FirebaseAuthMiddleware() echo.MiddlewareFunc {
 				})
 			}
 
+			uid, email, role := token.UserID, token.Email, token.Role
 			if uid == "" {
 				return c.JSON(http.StatusUnauthorized, &response.ErrorResponse{
 					Error:   "invalid_token",
@@ -64,6 +71,9 @@ func FirebaseAuthMiddleware() echo.MiddlewareFunc {
 			if email != "" {
 				c.Set(contextKeyEmail, email)
 			}
+			if role != "" {
+				c.Set(contextKeyRole, role)
+			}
 			return next(c)
 		}
 	}
@@ -90,3 +100,27 @@ func GetEmail(c echo.Context) string {
 	}
 	return ""
 }
+
+// GetRole retrieves the role custom claim from the Echo context.
+// Returns an empty string if the token has no role.
+func GetRole(c echo.Context) string {
+	if role, ok := c.Get(contextKeyRole).(string); ok {
+		return role
+	}
+	return ""
+}
+
+// RequireRole FirebaseAuthMiddlewareの後ろに置き、roleクレームが一致しないリクエストを403で弾く
+func RequireRole(role string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if GetRole(c) != role {
+				return c.JSON(http.StatusForbidden, &response.ErrorResponse{
+					Error:   "forbidden",
+					Message: "この操作を行う権限がありません",
+				})
+			}
+			return next(c)
+		}
+	}
+}
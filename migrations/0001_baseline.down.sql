@@ -0,0 +1,18 @@
+-- ベースラインを取り消すと全テーブルとデータが消える
+DROP TABLE IF EXISTS chat_read_cursors;
+DROP TABLE IF EXISTS avatar_chat_memories;
+DROP TABLE IF EXISTS suppressed_pushes;
+DROP TABLE IF EXISTS notification_settings;
+DROP TABLE IF EXISTS device_tokens;
+DROP TABLE IF EXISTS jobs;
+DROP TABLE IF EXISTS diagnosis_histories;
+DROP TABLE IF EXISTS user_blocks;
+DROP TABLE IF EXISTS matchings;
+DROP TABLE IF EXISTS point_adjustment_audits;
+DROP TABLE IF EXISTS point_events;
+DROP TABLE IF EXISTS user_avatar_relations;
+DROP TABLE IF EXISTS mission_unlocks;
+DROP TABLE IF EXISTS missions;
+DROP TABLE IF EXISTS user_infos;
+DROP TABLE IF EXISTS avatars;
+DROP TABLE IF EXISTS users;
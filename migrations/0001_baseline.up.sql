@@ -0,0 +1,253 @@
+-- AutoMigrate で作っていたテーブルに、外部キー・一意制約・CHECK制約を加えたもの。
+-- AutoMigrate で作成済みのデータベースにも適用できるよう、既にあるテーブル・インデックス・制約は作らない
+
+CREATE TABLE IF NOT EXISTS users (
+    id text PRIMARY KEY,
+    display_name text NOT NULL,
+    gender text NOT NULL,
+    birth_date timestamptz NOT NULL,
+    bio text NOT NULL,
+    profile_image_url text NOT NULL,
+    is_onboarding_completed boolean DEFAULT false,
+    interested_in_genders jsonb NOT NULL DEFAULT '[]',
+    preferred_min_age bigint NOT NULL DEFAULT 0,
+    preferred_max_age bigint NOT NULL DEFAULT 0,
+    max_distance_km bigint,
+    latitude decimal,
+    longitude decimal,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS avatars (
+    id text PRIMARY KEY,
+    user_id text NOT NULL,
+    avatar_icon_url text NOT NULL,
+    prompt text NOT NULL,
+    personality_traits jsonb,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_avatars_user_id ON avatars (user_id);
+
+CREATE TABLE IF NOT EXISTS user_infos (
+    id text PRIMARY KEY,
+    user_id text NOT NULL,
+    info_type text NOT NULL,
+    key text NOT NULL,
+    value text NOT NULL,
+    is_mission_reward boolean DEFAULT false,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_user_infos_user_id ON user_infos (user_id);
+
+CREATE TABLE IF NOT EXISTS missions (
+    id text PRIMARY KEY,
+    mission_owner_user_id text NOT NULL,
+    user_info_id text NOT NULL,
+    threshold_point_condition bigint,
+    unlock_condition text,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_missions_mission_owner_user_id ON missions (mission_owner_user_id);
+CREATE INDEX IF NOT EXISTS idx_missions_user_info_id ON missions (user_info_id);
+
+CREATE TABLE IF NOT EXISTS mission_unlocks (
+    id text PRIMARY KEY,
+    mission_id text NOT NULL,
+    unlocked_user_id text NOT NULL,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_mission_unlocks_mission_user ON mission_unlocks (mission_id, unlocked_user_id);
+CREATE INDEX IF NOT EXISTS idx_mission_unlocks_unlocked_user_id ON mission_unlocks (unlocked_user_id);
+
+CREATE TABLE IF NOT EXISTS user_avatar_relations (
+    id text PRIMARY KEY,
+    user_id text NOT NULL,
+    avatar_id text NOT NULL,
+    matching_point bigint NOT NULL,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_avatar_relations_user_avatar ON user_avatar_relations (user_id, avatar_id);
+CREATE INDEX IF NOT EXISTS idx_user_avatar_relations_avatar_id ON user_avatar_relations (avatar_id);
+
+CREATE TABLE IF NOT EXISTS point_events (
+    id text PRIMARY KEY,
+    relation_id text NOT NULL,
+    source text NOT NULL,
+    delta bigint NOT NULL,
+    matching_point_after bigint NOT NULL,
+    reason text NOT NULL DEFAULT '',
+    message_id text,
+    created_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_point_events_relation_created ON point_events (relation_id, created_at);
+
+-- 監査ログは調整の対象が削除されても残すため、外部キーは張らない
+CREATE TABLE IF NOT EXISTS point_adjustment_audits (
+    id text PRIMARY KEY,
+    admin_user_id text NOT NULL,
+    user_id text NOT NULL,
+    avatar_id text NOT NULL,
+    point_event_id text NOT NULL,
+    requested_delta bigint NOT NULL,
+    reason text NOT NULL,
+    created_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_point_adjustment_audits_admin_user_id ON point_adjustment_audits (admin_user_id);
+
+CREATE TABLE IF NOT EXISTS matchings (
+    id text PRIMARY KEY,
+    user1_id text NOT NULL,
+    user2_id text NOT NULL,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_matchings_users ON matchings (user1_id, user2_id);
+CREATE INDEX IF NOT EXISTS idx_matchings_user2_id ON matchings (user2_id);
+
+CREATE TABLE IF NOT EXISTS user_blocks (
+    id text PRIMARY KEY,
+    blocker_user_id text NOT NULL,
+    blocked_user_id text NOT NULL,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_blocks_pair ON user_blocks (blocker_user_id, blocked_user_id);
+CREATE INDEX IF NOT EXISTS idx_user_blocks_blocked_user_id ON user_blocks (blocked_user_id);
+
+CREATE TABLE IF NOT EXISTS diagnosis_histories (
+    id text PRIMARY KEY,
+    user_id text NOT NULL,
+    user_avatar_id text NOT NULL,
+    target_avatar_id text NOT NULL,
+    conversation_data jsonb,
+    diagnosis_score bigint NOT NULL,
+    ai_analysis_result jsonb,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_diagnosis_histories_user_id ON diagnosis_histories (user_id);
+
+CREATE TABLE IF NOT EXISTS jobs (
+    id text PRIMARY KEY,
+    type text NOT NULL,
+    subject_id text NOT NULL,
+    payload jsonb NOT NULL DEFAULT '{}',
+    status text NOT NULL,
+    attempts bigint NOT NULL DEFAULT 0,
+    max_attempts bigint NOT NULL,
+    run_at timestamptz NOT NULL,
+    leased_by text NOT NULL DEFAULT '',
+    leased_until timestamptz,
+    last_error text NOT NULL DEFAULT '',
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_jobs_type_subject_created ON jobs (type, subject_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_jobs_status_run_at ON jobs (status, run_at);
+
+CREATE TABLE IF NOT EXISTS device_tokens (
+    id text PRIMARY KEY,
+    user_id text NOT NULL,
+    token text NOT NULL,
+    platform text NOT NULL,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_device_tokens_user_id ON device_tokens (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_device_tokens_token ON device_tokens (token);
+
+CREATE TABLE IF NOT EXISTS notification_settings (
+    user_id text PRIMARY KEY,
+    match_enabled boolean NOT NULL,
+    mission_unlock_enabled boolean NOT NULL,
+    message_enabled boolean NOT NULL,
+    avatar_activity_enabled boolean NOT NULL,
+    quiet_hours_enabled boolean NOT NULL,
+    quiet_hours_start text NOT NULL,
+    quiet_hours_end text NOT NULL,
+    timezone text NOT NULL,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS suppressed_pushes (
+    id text PRIMARY KEY,
+    user_id text NOT NULL,
+    notification_id text NOT NULL,
+    type text NOT NULL,
+    title text NOT NULL,
+    created_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_suppressed_pushes_user_id ON suppressed_pushes (user_id);
+
+CREATE TABLE IF NOT EXISTS avatar_chat_memories (
+    user_id text NOT NULL,
+    avatar_id text NOT NULL,
+    summary text NOT NULL DEFAULT '',
+    facts jsonb,
+    summarized_until text NOT NULL DEFAULT '',
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (user_id, avatar_id)
+);
+CREATE INDEX IF NOT EXISTS idx_avatar_chat_memories_avatar_id ON avatar_chat_memories (avatar_id);
+
+-- partner_id はchat_kindによってユーザーかアバターを指すため、外部キーは張らない
+CREATE TABLE IF NOT EXISTS chat_read_cursors (
+    user_id text NOT NULL,
+    chat_kind text NOT NULL,
+    partner_id text NOT NULL,
+    last_read_message_id text NOT NULL DEFAULT '',
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (user_id, chat_kind, partner_id)
+);
+
+-- ALTER TABLE ... ADD CONSTRAINT には IF NOT EXISTS が無いため、名前で確認してから追加する。
+-- diagnosis_histories の外部キーはAutoMigrateが付けていた名前に合わせている
+DO $$
+DECLARE
+    c record;
+BEGIN
+    FOR c IN SELECT * FROM (VALUES
+        ('avatars', 'fk_avatars_user', 'FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE'),
+        ('user_infos', 'fk_user_infos_user', 'FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE'),
+        ('missions', 'fk_missions_owner', 'FOREIGN KEY (mission_owner_user_id) REFERENCES users (id) ON DELETE CASCADE'),
+        ('missions', 'fk_missions_user_info', 'FOREIGN KEY (user_info_id) REFERENCES user_infos (id) ON DELETE CASCADE'),
+        ('mission_unlocks', 'fk_mission_unlocks_mission', 'FOREIGN KEY (mission_id) REFERENCES missions (id) ON DELETE CASCADE'),
+        ('mission_unlocks', 'fk_mission_unlocks_user', 'FOREIGN KEY (unlocked_user_id) REFERENCES users (id) ON DELETE CASCADE'),
+        ('user_avatar_relations', 'fk_user_avatar_relations_user', 'FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE'),
+        ('user_avatar_relations', 'fk_user_avatar_relations_avatar', 'FOREIGN KEY (avatar_id) REFERENCES avatars (id) ON DELETE CASCADE'),
+        ('point_events', 'fk_point_events_relation', 'FOREIGN KEY (relation_id) REFERENCES user_avatar_relations (id) ON DELETE CASCADE'),
+        ('matchings', 'fk_matchings_user1', 'FOREIGN KEY (user1_id) REFERENCES users (id) ON DELETE CASCADE'),
+        ('matchings', 'fk_matchings_user2', 'FOREIGN KEY (user2_id) REFERENCES users (id) ON DELETE CASCADE'),
+        ('matchings', 'chk_matchings_user_order', 'CHECK (user1_id < user2_id)'),
+        ('user_blocks', 'fk_user_blocks_blocker', 'FOREIGN KEY (blocker_user_id) REFERENCES users (id) ON DELETE CASCADE'),
+        ('user_blocks', 'fk_user_blocks_blocked', 'FOREIGN KEY (blocked_user_id) REFERENCES users (id) ON DELETE CASCADE'),
+        ('user_blocks', 'chk_user_blocks_not_self', 'CHECK (blocker_user_id <> blocked_user_id)'),
+        ('diagnosis_histories', 'fk_diagnosis_histories_user', 'FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE'),
+        ('diagnosis_histories', 'fk_diagnosis_histories_user_avatar', 'FOREIGN KEY (user_avatar_id) REFERENCES avatars (id) ON DELETE CASCADE'),
+        ('diagnosis_histories', 'fk_diagnosis_histories_target_avatar', 'FOREIGN KEY (target_avatar_id) REFERENCES avatars (id) ON DELETE CASCADE'),
+        ('device_tokens', 'fk_device_tokens_user', 'FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE'),
+        ('notification_settings', 'fk_notification_settings_user', 'FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE'),
+        ('suppressed_pushes', 'fk_suppressed_pushes_user', 'FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE'),
+        ('avatar_chat_memories', 'fk_avatar_chat_memories_user', 'FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE'),
+        ('avatar_chat_memories', 'fk_avatar_chat_memories_avatar', 'FOREIGN KEY (avatar_id) REFERENCES avatars (id) ON DELETE CASCADE'),
+        ('chat_read_cursors', 'fk_chat_read_cursors_user', 'FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE'),
+        ('chat_read_cursors', 'chk_chat_read_cursors_chat_kind', 'CHECK (chat_kind IN (''user'', ''avatar''))')
+    ) AS t (table_name, constraint_name, definition)
+    LOOP
+        IF NOT EXISTS (
+            SELECT 1 FROM pg_constraint
+            WHERE conname = c.constraint_name AND conrelid = c.table_name::regclass
+        ) THEN
+            EXECUTE format('ALTER TABLE %I ADD CONSTRAINT %I %s', c.table_name, c.constraint_name, c.definition);
+        END IF;
+    END LOOP;
+END
+$$;
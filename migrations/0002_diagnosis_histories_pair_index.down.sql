@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_diagnosis_histories_user_target_created;
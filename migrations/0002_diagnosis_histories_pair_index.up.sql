@@ -0,0 +1,2 @@
+-- 同じ相手との診断の間隔を確かめるため、実行者と相手のAvatarごとに新しい順で引けるようにする
+CREATE INDEX IF NOT EXISTS idx_diagnosis_histories_user_target_created ON diagnosis_histories (user_id, target_avatar_id, created_at);
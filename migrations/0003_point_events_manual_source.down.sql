@@ -0,0 +1 @@
+UPDATE point_events SET source = 'admin' WHERE source = 'manual';
@@ -0,0 +1,2 @@
+-- 運営による手動調整の発生源を admin から manual に揃える
+UPDATE point_events SET source = 'manual' WHERE source = 'admin';
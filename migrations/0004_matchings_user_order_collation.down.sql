@@ -0,0 +1,2 @@
+ALTER TABLE matchings DROP CONSTRAINT IF EXISTS chk_matchings_user_order;
+ALTER TABLE matchings ADD CONSTRAINT chk_matchings_user_order CHECK (user1_id < user2_id);
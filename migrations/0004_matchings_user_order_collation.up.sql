@@ -0,0 +1,4 @@
+-- 並び順をアプリ（Goのバイト順）と揃えるため、DBの照合順序ではなく "C" で比較する。
+-- en_US.utf8 などでは大文字小文字の混ざったFirebase UIDの大小がGoと食い違い、マッチングを作れなくなる
+ALTER TABLE matchings DROP CONSTRAINT IF EXISTS chk_matchings_user_order;
+ALTER TABLE matchings ADD CONSTRAINT chk_matchings_user_order CHECK (user1_id COLLATE "C" < user2_id COLLATE "C");
@@ -0,0 +1,12 @@
+-- 同じ会話の要約ジョブが同時に登録されると同じメッセージを二重に要約してしまうため、
+-- 実行待ち・実行中のものは (type, subject_id) ごとに1件に限る。JobAdapter.EnqueueIfNotActive はこの制約で重複を判定する
+-- 既に重複しているものは、最も古いものだけを残してデッドレターにする
+UPDATE jobs SET status = 'dead', leased_until = NULL, last_error = 'duplicate active job'
+WHERE type IN ('avatar_chat_summary') AND status IN ('queued', 'running')
+    AND id NOT IN (
+        SELECT DISTINCT ON (type, subject_id) id FROM jobs
+        WHERE type IN ('avatar_chat_summary') AND status IN ('queued', 'running')
+        ORDER BY type, subject_id, created_at, id
+    );
+CREATE UNIQUE INDEX IF NOT EXISTS idx_jobs_active_type_subject ON jobs (type, subject_id)
+    WHERE type IN ('avatar_chat_summary') AND status IN ('queued', 'running');
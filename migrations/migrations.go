@@ -0,0 +1,247 @@
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hackathon-20260110/api/utils"
+	"gorm.io/gorm"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// migrationFilePattern "0001_baseline.up.sql" のような {バージョン}_{名前}.{up|down}.sql
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// advisoryLockKey 複数のプロセスが同時にマイグレーションしないよう取るロックのキー
+const advisoryLockKey = 20260110
+
+// Migration 1つのバージョンで適用・取り消すSQL
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus マイグレーションと、適用済みならその日時
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// schemaMigration 適用済みのバージョンを記録する schema_migrations の1行
+type schemaMigration struct {
+	Version   int64 `gorm:"primaryKey"`
+	Name      string
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Load 埋め込んだSQLファイルを読み、バージョン順に並べて返す
+func Load() ([]Migration, error) {
+	return load(files)
+}
+
+func load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, utils.WrapError(err)
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", entry.Name())
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, utils.WrapError(err)
+		}
+		body, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, utils.WrapError(err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has different names: %s and %s", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(body)
+		} else {
+			migration.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both up and down files", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Runner マイグレーションを適用・取り消す。1つのマイグレーションとその記録は同じトランザクションで行う
+type Runner struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+func NewRunner(db *gorm.DB) (*Runner, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, utils.WrapError(err)
+	}
+	return &Runner{db: db, migrations: migrations}, nil
+}
+
+// Up 未適用のマイグレーションを古い順にすべて適用し、適用したものを返す
+func (r *Runner) Up() ([]Migration, error) {
+	if err := r.ensureTable(); err != nil {
+		return nil, utils.WrapError(err)
+	}
+
+	var applied []Migration
+	for _, migration := range r.migrations {
+		done, err := r.apply(migration)
+		if err != nil {
+			return applied, fmt.Errorf("migration %d_%s up: %w", migration.Version, migration.Name, err)
+		}
+		if done {
+			applied = append(applied, migration)
+		}
+	}
+	return applied, nil
+}
+
+// Down 適用済みのマイグレーションを新しい順にsteps個取り消し、取り消したものを返す
+func (r *Runner) Down(steps int) ([]Migration, error) {
+	if err := r.ensureTable(); err != nil {
+		return nil, utils.WrapError(err)
+	}
+
+	var reverted []Migration
+	for i := len(r.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+		migration := r.migrations[i]
+		done, err := r.revert(migration)
+		if err != nil {
+			return reverted, fmt.Errorf("migration %d_%s down: %w", migration.Version, migration.Name, err)
+		}
+		if done {
+			reverted = append(reverted, migration)
+		}
+	}
+	return reverted, nil
+}
+
+// Status すべてのマイグレーションと適用状況をバージョン順に返す
+func (r *Runner) Status() ([]MigrationStatus, error) {
+	if err := r.ensureTable(); err != nil {
+		return nil, utils.WrapError(err)
+	}
+
+	var rows []schemaMigration
+	if err := r.db.Find(&rows).Error; err != nil {
+		return nil, utils.WrapError(err)
+	}
+	appliedAt := make(map[int64]time.Time, len(rows))
+	for _, row := range rows {
+		appliedAt[row.Version] = row.AppliedAt
+	}
+
+	statuses := make([]MigrationStatus, 0, len(r.migrations))
+	for _, migration := range r.migrations {
+		status := MigrationStatus{Migration: migration}
+		if at, ok := appliedAt[migration.Version]; ok {
+			status.AppliedAt = &at
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func (r *Runner) ensureTable() error {
+	return r.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version bigint PRIMARY KEY,
+		name text NOT NULL,
+		applied_at timestamptz NOT NULL
+	)`).Error
+}
+
+// apply 未適用ならマイグレーションを適用して記録する。既に適用済みならfalseを返す
+func (r *Runner) apply(migration Migration) (bool, error) {
+	done := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		applied, err := lockAndCheckApplied(tx, migration.Version)
+		if err != nil || applied {
+			return err
+		}
+		if err := execSQL(tx, migration.Up); err != nil {
+			return err
+		}
+		if err := tx.Create(&schemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}).Error; err != nil {
+			return err
+		}
+		done = true
+		return nil
+	})
+	return done, err
+}
+
+// revert 適用済みならマイグレーションを取り消して記録を消す。未適用ならfalseを返す
+func (r *Runner) revert(migration Migration) (bool, error) {
+	done := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		applied, err := lockAndCheckApplied(tx, migration.Version)
+		if err != nil || !applied {
+			return err
+		}
+		if err := execSQL(tx, migration.Down); err != nil {
+			return err
+		}
+		if err := tx.Delete(&schemaMigration{Version: migration.Version}).Error; err != nil {
+			return err
+		}
+		done = true
+		return nil
+	})
+	return done, err
+}
+
+// execSQL SQLファイルの中身を、GORMのプレースホルダの解釈を通さずにそのまま実行する
+func execSQL(tx *gorm.DB, sql string) error {
+	_, err := tx.Statement.ConnPool.ExecContext(tx.Statement.Context, sql)
+	return err
+}
+
+// lockAndCheckApplied トランザクションの終わりまでマイグレーション用のロックを取り、versionが適用済みか返す
+func lockAndCheckApplied(tx *gorm.DB, version int64) (bool, error) {
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", advisoryLockKey).Error; err != nil {
+		return false, err
+	}
+	var count int64
+	if err := tx.Model(&schemaMigration{}).Where("version = ?", version).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
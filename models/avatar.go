@@ -11,3 +11,22 @@ type Avatar struct {
 	CreatedAt         time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt         time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 }
+
+// PersonalityTraits Avatar.PersonalityTraits に保存する性格特性。
+// オンボーディング完了時にチャット履歴からLLMで生成する
+type PersonalityTraits struct {
+	BigFive       BigFiveScores `json:"big_five"`
+	Interests     []string      `json:"interests"`
+	DatingValues  []string      `json:"dating_values"`
+	SpeakingStyle string        `json:"speaking_style"`
+	TopicsToAvoid []string      `json:"topics_to_avoid"`
+}
+
+// BigFiveScores ビッグファイブの各因子を1〜5で表す
+type BigFiveScores struct {
+	Openness          int `json:"openness"`
+	Conscientiousness int `json:"conscientiousness"`
+	Extraversion      int `json:"extraversion"`
+	Agreeableness     int `json:"agreeableness"`
+	Neuroticism       int `json:"neuroticism"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// AvatarChatMemory ユーザーとアバターの長い会話を覚えておくための記憶。
+// 直近の履歴はそのままプロンプトに入れ、それより前の内容は要約と事実のリストで渡す
+type AvatarChatMemory struct {
+	UserID   string `gorm:"primaryKey" json:"user_id"`
+	AvatarID string `gorm:"primaryKey" json:"avatar_id"`
+	// Summary これまでの会話の要約。新しいメッセージが溜まるたびに書き直す
+	Summary string `json:"summary" gorm:"type:text;not null;default:''"`
+	// Facts 会話から分かったチャット相手（UserID）についての事実（仕事、趣味、予定など）
+	Facts []string `json:"facts" gorm:"type:jsonb;serializer:json"`
+	// SummarizedUntil 要約に含めた最後のメッセージID（ULID）。空なら未要約
+	SummarizedUntil string    `json:"summarized_until" gorm:"not null;default:''"`
+	CreatedAt       time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// IsSummarized messageIDのメッセージが要約に含まれているか。メッセージIDはULIDなので文字列の順が送信順になる
+func (m *AvatarChatMemory) IsSummarized(messageID string) bool {
+	return m.SummarizedUntil != "" && messageID <= m.SummarizedUntil
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+type ChatKind string
+
+const (
+	// ChatKindUser マッチしたユーザー同士のチャット。PartnerIDは相手のユーザーID
+	ChatKindUser ChatKind = "user"
+	// ChatKindAvatar アバターとのチャット。PartnerIDはアバターID
+	ChatKindAvatar ChatKind = "avatar"
+)
+
+// ChatReadCursor チャットの参加者ごとの既読位置。
+// メッセージIDはULIDなので、LastReadMessageID以下のIDのメッセージを既読とみなす
+type ChatReadCursor struct {
+	UserID    string   `gorm:"primaryKey" json:"user_id"`
+	ChatKind  ChatKind `gorm:"primaryKey" json:"chat_kind"`
+	PartnerID string   `gorm:"primaryKey" json:"partner_id"`
+	// LastReadMessageID 最後に読んだメッセージID。空なら1件も読んでいない
+	LastReadMessageID string    `json:"last_read_message_id" gorm:"not null;default:''"`
+	CreatedAt         time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// IsRead messageIDのメッセージを既読か
+func (c *ChatReadCursor) IsRead(messageID string) bool {
+	return c.LastReadMessageID != "" && messageID <= c.LastReadMessageID
+}
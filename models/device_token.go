@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+type DevicePlatform string
+
+const (
+	DevicePlatformIOS     DevicePlatform = "ios"
+	DevicePlatformAndroid DevicePlatform = "android"
+	DevicePlatformWeb     DevicePlatform = "web"
+)
+
+// DeviceToken プッシュ通知の送信先となるFCMの登録トークン。
+// 同じ端末で別のユーザーがログインし直した場合は、そのユーザーに付け替える
+type DeviceToken struct {
+	ID        string         `gorm:"primaryKey" json:"id"`
+	UserID    string         `json:"user_id" gorm:"not null;index"`
+	Token     string         `json:"token" gorm:"not null;uniqueIndex"`
+	Platform  DevicePlatform `json:"platform" gorm:"not null"`
+	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+}
@@ -18,3 +18,15 @@ type DiagnosisHistory struct {
 	UserAvatar   Avatar `gorm:"foreignKey:UserAvatarID" json:"user_avatar,omitempty"`
 	TargetAvatar Avatar `gorm:"foreignKey:TargetAvatarID" json:"target_avatar,omitempty"`
 }
+
+// DiagnosisConversation DiagnosisHistory.ConversationDataに保存するAvatar同士の会話ログ
+type DiagnosisConversation struct {
+	Topic    string                         `json:"topic"`
+	Messages []DiagnosisConversationMessage `json:"messages"`
+}
+
+type DiagnosisConversationMessage struct {
+	SpeakerAvatarID string `json:"speaker_avatar_id"`
+	SpeakerName     string `json:"speaker_name"`
+	Message         string `json:"message"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+type JobType string
+
+const (
+	JobTypeOnboardingReply JobType = "onboarding_reply"
+	// JobTypeNotificationDigest おやすみ時間明けに、止めていたプッシュ通知をまとめて送る
+	JobTypeNotificationDigest JobType = "notification_digest"
+	// JobTypeAvatarChatSummary アバターとの会話が溜まったら要約と相手についての事実を更新する
+	JobTypeAvatarChatSummary JobType = "avatar_chat_summary"
+)
+
+type JobStatus string
+
+const (
+	// JobStatusQueued 実行待ち。リトライ待ちの場合はRunAtが未来になっている
+	JobStatusQueued JobStatus = "queued"
+	// JobStatusRunning ワーカーがリース中。LeasedUntilを過ぎたものは再度取得される
+	JobStatusRunning   JobStatus = "running"
+	JobStatusSucceeded JobStatus = "succeeded"
+	// JobStatusDead 最大試行回数に達して諦めた（デッドレター）
+	JobStatusDead JobStatus = "dead"
+)
+
+// Job Postgresに永続化するバックグラウンドジョブ。プロセスが再起動しても失われない
+type Job struct {
+	ID   string  `gorm:"primaryKey" json:"id"`
+	Type JobType `json:"type" gorm:"not null;index:idx_jobs_type_subject_created,priority:1"`
+	// SubjectID ジョブの対象（オンボーディング返信ならユーザーID）。状態の問い合わせに使う
+	SubjectID   string     `json:"subject_id" gorm:"not null;index:idx_jobs_type_subject_created,priority:2"`
+	Payload     string     `json:"payload" gorm:"type:jsonb;not null;default:'{}'"`
+	Status      JobStatus  `json:"status" gorm:"not null;index:idx_jobs_status_run_at,priority:1"`
+	Attempts    int        `json:"attempts" gorm:"not null;default:0"`
+	MaxAttempts int        `json:"max_attempts" gorm:"not null"`
+	RunAt       time.Time  `json:"run_at" gorm:"not null;index:idx_jobs_status_run_at,priority:2"`
+	LeasedBy    string     `json:"leased_by" gorm:"not null;default:''"`
+	LeasedUntil *time.Time `json:"leased_until"`
+	LastError   string     `json:"last_error" gorm:"not null;default:''"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime;index:idx_jobs_type_subject_created,priority:3" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
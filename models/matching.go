@@ -5,8 +5,16 @@ import "time"
 type Matching struct {
 	ID string `gorm:"primaryKey" json:"id"`
 	// User1ID < User2ID
-	User1ID   string    `json:"user1_id" gorm:"not null"`
-	User2ID   string    `json:"user2_id" gorm:"not null"`
+	User1ID   string    `json:"user1_id" gorm:"not null;uniqueIndex:idx_matchings_users,priority:1"`
+	User2ID   string    `json:"user2_id" gorm:"not null;uniqueIndex:idx_matchings_users,priority:2"`
 	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 }
+
+// PartnerID userIDから見たマッチング相手のユーザーID
+func (m *Matching) PartnerID(userID string) string {
+	if m.User1ID == userID {
+		return m.User2ID
+	}
+	return m.User1ID
+}
@@ -4,8 +4,8 @@ import "time"
 
 type MissionUnlock struct {
 	ID             string    `gorm:"primaryKey" json:"id"`
-	MissionID      string    `json:"mission_id" gorm:"not null"`
-	UnlockedUserID string    `json:"unlocked_user_id" gorm:"not null"`
+	MissionID      string    `json:"mission_id" gorm:"not null;uniqueIndex:idx_mission_unlocks_mission_user,priority:1"`
+	UnlockedUserID string    `json:"unlocked_user_id" gorm:"not null;uniqueIndex:idx_mission_unlocks_mission_user,priority:2"`
 	CreatedAt      time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt      time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 }
@@ -2,11 +2,26 @@ package models
 
 import "time"
 
+type NotificationType string
+
+const (
+	NotificationTypeMatch          NotificationType = "match"
+	NotificationTypeMissionUnlock  NotificationType = "mission_unlock"
+	NotificationTypeMessage        NotificationType = "message"
+	NotificationTypeAvatarActivity NotificationType = "avatar_activity"
+	NotificationTypeSystem         NotificationType = "system"
+)
+
 type Notification struct {
-	ID        string    `json:"id"`
-	UserID    string    `json:"user_id"`
-	Title     string    `json:"title"`
-	Message   string    `json:"message"`
-	HasRead   bool      `json:"has_read"`
-	CreatedAt time.Time `json:"created_at"`
+	ID      string           `json:"id"`
+	UserID  string           `json:"user_id"`
+	Type    NotificationType `json:"type"`
+	Title   string           `json:"title"`
+	Message string           `json:"message"`
+	// DeepLink 通知をタップしたときの遷移先（例: /matches/{matchingId}）
+	DeepLink string `json:"deep_link"`
+	// Payload 遷移先の画面で使うID等
+	Payload   map[string]string `json:"payload"`
+	HasRead   bool              `json:"has_read"`
+	CreatedAt time.Time         `json:"created_at"`
 }
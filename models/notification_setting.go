@@ -0,0 +1,114 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultNotificationTimezone 設定が無いユーザーのおやすみ時間を解釈するタイムゾーン
+const DefaultNotificationTimezone = "Asia/Tokyo"
+
+// NotificationSetting 通知の種類ごとの受け取り設定と、プッシュ通知を止めるおやすみ時間。
+// 行が無いユーザーは DefaultNotificationSetting として扱う
+type NotificationSetting struct {
+	UserID                string `gorm:"primaryKey" json:"user_id"`
+	MatchEnabled          bool   `json:"match_enabled" gorm:"not null"`
+	MissionUnlockEnabled  bool   `json:"mission_unlock_enabled" gorm:"not null"`
+	MessageEnabled        bool   `json:"message_enabled" gorm:"not null"`
+	AvatarActivityEnabled bool   `json:"avatar_activity_enabled" gorm:"not null"`
+	QuietHoursEnabled     bool   `json:"quiet_hours_enabled" gorm:"not null"`
+	// QuietHoursStart / QuietHoursEnd "HH:MM"。Start > End なら日をまたぐ（22:00〜07:00など）
+	QuietHoursStart string    `json:"quiet_hours_start" gorm:"not null"`
+	QuietHoursEnd   string    `json:"quiet_hours_end" gorm:"not null"`
+	Timezone        string    `json:"timezone" gorm:"not null"` // IANAのタイムゾーン名
+	CreatedAt       time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// DefaultNotificationSetting すべての通知を受け取り、おやすみ時間は無効
+func DefaultNotificationSetting(userID string) NotificationSetting {
+	return NotificationSetting{
+		UserID:                userID,
+		MatchEnabled:          true,
+		MissionUnlockEnabled:  true,
+		MessageEnabled:        true,
+		AvatarActivityEnabled: true,
+		QuietHoursEnabled:     false,
+		QuietHoursStart:       "22:00",
+		QuietHoursEnd:         "07:00",
+		Timezone:              DefaultNotificationTimezone,
+	}
+}
+
+// IsTypeEnabled その種類の通知を受け取るか。運営からのお知らせは止められない
+func (s *NotificationSetting) IsTypeEnabled(notificationType NotificationType) bool {
+	switch notificationType {
+	case NotificationTypeMatch:
+		return s.MatchEnabled
+	case NotificationTypeMissionUnlock:
+		return s.MissionUnlockEnabled
+	case NotificationTypeMessage:
+		return s.MessageEnabled
+	case NotificationTypeAvatarActivity:
+		return s.AvatarActivityEnabled
+	default:
+		return true
+	}
+}
+
+// QuietUntil nowがおやすみ時間中なら、その終了時刻とtrueを返す
+func (s *NotificationSetting) QuietUntil(now time.Time) (time.Time, bool) {
+	if !s.QuietHoursEnabled {
+		return time.Time{}, false
+	}
+	start, err := ParseClock(s.QuietHoursStart)
+	if err != nil {
+		return time.Time{}, false
+	}
+	end, err := ParseClock(s.QuietHoursEnd)
+	if err != nil || start == end {
+		return time.Time{}, false
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		loc, _ = time.LoadLocation(DefaultNotificationTimezone)
+	}
+
+	local := now.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	endToday := time.Date(local.Year(), local.Month(), local.Day(), end/60, end%60, 0, 0, loc)
+
+	if start < end {
+		if minute >= start && minute < end {
+			return endToday, true
+		}
+		return time.Time{}, false
+	}
+	// 日をまたぐ場合
+	if minute >= start {
+		return endToday.AddDate(0, 0, 1), true
+	}
+	if minute < end {
+		return endToday, true
+	}
+	return time.Time{}, false
+}
+
+// ParseClock "HH:MM" を0時からの分に変換する
+func ParseClock(clock string) (int, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("invalid clock %q: %w", clock, err)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// SuppressedPush おやすみ時間中に送らなかったプッシュ通知。明けた後にダイジェストとしてまとめて送る
+type SuppressedPush struct {
+	ID             string           `gorm:"primaryKey" json:"id"`
+	UserID         string           `json:"user_id" gorm:"not null;index"`
+	NotificationID string           `json:"notification_id" gorm:"not null"`
+	Type           NotificationType `json:"type" gorm:"not null"`
+	Title          string           `json:"title" gorm:"not null"`
+	CreatedAt      time.Time        `gorm:"autoCreateTime" json:"created_at"`
+}
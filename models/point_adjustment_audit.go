@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// PointAdjustmentAudit 運営がマッチングポイントを手動で調整した記録。
+// 誰が・誰のどのアバターとの関係を・なぜ動かしたかを残す
+type PointAdjustmentAudit struct {
+	ID           string `gorm:"primaryKey" json:"id"`
+	AdminUserID  string `json:"admin_user_id" gorm:"not null;index"`
+	UserID       string `json:"user_id" gorm:"not null"`
+	AvatarID     string `json:"avatar_id" gorm:"not null"`
+	PointEventID string `json:"point_event_id" gorm:"not null"`
+	// RequestedDelta 調整として指定された値。上下限で切り詰められた結果はPointEvent.Deltaに残る
+	RequestedDelta int       `json:"requested_delta" gorm:"not null"`
+	Reason         string    `json:"reason" gorm:"not null"`
+	CreatedAt      time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
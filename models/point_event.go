@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// MaxMatchingPoint マッチングポイントの上限。到達するとマッチングが成立する
+const MaxMatchingPoint = 100
+
+type PointEventSource string
+
+const (
+	PointEventSourceAvatarChat PointEventSource = "avatar_chat"
+	PointEventSourceDiagnosis  PointEventSource = "diagnosis"
+	PointEventSourceManual     PointEventSource = "manual" // 運営による手動調整
+	PointEventSourceDecay      PointEventSource = "decay"
+)
+
+// PointEvent UserAvatarRelationのマッチングポイントが動いた理由の履歴
+type PointEvent struct {
+	ID         string           `gorm:"primaryKey" json:"id"`
+	RelationID string           `json:"relation_id" gorm:"not null;index:idx_point_events_relation_created,priority:1"`
+	Source     PointEventSource `json:"source" gorm:"not null"`
+	// Delta 実際に反映された増減。上下限で切り詰められた場合は要求値と異なる
+	Delta              int       `json:"delta" gorm:"not null"`
+	MatchingPointAfter int       `json:"matching_point_after" gorm:"not null"`
+	Reason             string    `json:"reason" gorm:"not null;default:''"`
+	MessageID          *string   `json:"message_id"` // アバターチャット由来の場合、ポイントの根拠になった返信のID
+	CreatedAt          time.Time `gorm:"autoCreateTime;index:idx_point_events_relation_created,priority:2" json:"created_at"`
+}
@@ -10,6 +10,15 @@ type User struct {
 	Bio                   string    `json:"bio" gorm:"not null"`
 	ProfileImageURL       string    `json:"profile_image_url" gorm:"not null"`
 	IsOnboardingCompleted bool      `json:"is_onboarding_completed" gorm:"default:false"`
-	CreatedAt             time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt             time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// 相手に求める条件。詳細は user_preference.go
+	InterestedInGenders []string `json:"interested_in_genders" gorm:"serializer:json;type:jsonb;not null;default:'[]'"` // 空なら DefaultInterestedInGenders
+	PreferredMinAge     int      `json:"preferred_min_age" gorm:"not null;default:0"`                                   // 0なら制限しない
+	PreferredMaxAge     int      `json:"preferred_max_age" gorm:"not null;default:0"`                                   // 0なら制限しない
+	MaxDistanceKm       *int     `json:"max_distance_km"`                                                               // nilなら制限しない
+	Latitude            *float64 `json:"latitude"`
+	Longitude           *float64 `json:"longitude"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 }
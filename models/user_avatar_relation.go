@@ -4,8 +4,8 @@ import "time"
 
 type UserAvatarRelation struct {
 	ID            string    `gorm:"primaryKey" json:"id"`
-	UserID        string    `json:"user_id" gorm:"not null"`
-	AvatarID      string    `json:"avatar_id" gorm:"not null"`
+	UserID        string    `json:"user_id" gorm:"not null;uniqueIndex:idx_user_avatar_relations_user_avatar,priority:1"`
+	AvatarID      string    `json:"avatar_id" gorm:"not null;uniqueIndex:idx_user_avatar_relations_user_avatar,priority:2"`
 	MatchingPoint int       `json:"matching_point" gorm:"not null"`
 	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt     time.Time `gorm:"autoUpdateTime" json:"updated_at"`
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// UserBlock BlockerUserIDがBlockedUserIDをブロックしている。どちら側からも相手は候補に出ない
+type UserBlock struct {
+	ID            string    `gorm:"primaryKey" json:"id"`
+	BlockerUserID string    `json:"blocker_user_id" gorm:"not null;uniqueIndex:idx_user_blocks_pair,priority:1"`
+	BlockedUserID string    `json:"blocked_user_id" gorm:"not null;uniqueIndex:idx_user_blocks_pair,priority:2;index"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/hackathon-20260110/api/utils"
+)
+
+const (
+	GenderMale   = "male"
+	GenderFemale = "female"
+	GenderOther  = "other"
+)
+
+// Genders User.Gender に入れてよい値
+var Genders = []string{GenderMale, GenderFemale, GenderOther}
+
+func IsValidGender(gender string) bool {
+	return slices.Contains(Genders, gender)
+}
+
+// DefaultInterestedInGenders 恋愛対象を未設定のユーザーは、従来どおり異性（otherは全員）を対象にする
+func DefaultInterestedInGenders(gender string) []string {
+	switch gender {
+	case GenderMale:
+		return []string{GenderFemale}
+	case GenderFemale:
+		return []string{GenderMale}
+	default:
+		return slices.Clone(Genders)
+	}
+}
+
+// InterestedGenders 恋愛対象の性別。未設定なら性別から決める
+func (u *User) InterestedGenders() []string {
+	if len(u.InterestedInGenders) > 0 {
+		return u.InterestedInGenders
+	}
+	return DefaultInterestedInGenders(u.Gender)
+}
+
+// Admits uがotherを相手候補として受け入れるか（性別・年齢・距離の希望を満たすか）。
+// 距離はどちらかが位置情報を登録していなければ判定しない
+func (u *User) Admits(other *User, now time.Time) bool {
+	if !slices.Contains(u.InterestedGenders(), other.Gender) {
+		return false
+	}
+
+	age := utils.CalculateAge(other.BirthDate, now)
+	if u.PreferredMinAge > 0 && age < u.PreferredMinAge {
+		return false
+	}
+	if u.PreferredMaxAge > 0 && age > u.PreferredMaxAge {
+		return false
+	}
+
+	if u.MaxDistanceKm != nil {
+		if distance, ok := distanceKm(u, other); ok && distance > float64(*u.MaxDistanceKm) {
+			return false
+		}
+	}
+	return true
+}
+
+// MutuallyAdmits aとbがお互いの希望を満たすときだけtrue。
+// 相手探しではaがbを見られるのはbの希望もaを受け入れる場合に限る
+func MutuallyAdmits(a, b *User, now time.Time) bool {
+	return a.Admits(b, now) && b.Admits(a, now)
+}
+
+const earthRadiusKm = 6371.0
+
+// distanceKm 二人の位置の大円距離
+func distanceKm(a, b *User) (float64, bool) {
+	if a.Latitude == nil || a.Longitude == nil || b.Latitude == nil || b.Longitude == nil {
+		return 0, false
+	}
+
+	lat1 := *a.Latitude * math.Pi / 180
+	lat2 := *b.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (*b.Longitude - *a.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h)), true
+}
@@ -0,0 +1,7 @@
+package requests
+
+type AdjustMatchingPointRequest struct {
+	// Points 加算するポイント。減算する場合は負の値を指定する
+	Points int    `json:"points" example:"-10"`
+	Reason string `json:"reason" example:"不正なポイント獲得が確認されたため"`
+}
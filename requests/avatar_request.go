@@ -1,5 +1,6 @@
 package requests
 
-type UpdateMatchingPointRequest struct {
-	Points int `json:"points" validate:"required,min=1"`
+type GetPointHistoryRequest struct {
+	Limit  int `query:"limit"`
+	Offset int `query:"offset"`
 }
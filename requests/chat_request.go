@@ -1,5 +1,11 @@
 package requests
 
+import (
+	"errors"
+
+	"github.com/oklog/ulid/v2"
+)
+
 // CreateChatRequest 新しいチャット開始リクエスト
 type CreateChatRequest struct {
 	PartnerID string `json:"partner_id" example:"01ARZ3NDEKTSV4RRFFQ69G5FBV" binding:"required"`
@@ -12,3 +18,40 @@ type SendMessageRequest struct {
 	Content string `json:"content" example:"こんにちは！今日はいい天気ですね。" binding:"required"`
 	// 将来的に画像やファイル添付などに対応する場合に拡張可能
 }
+
+// ChatHistoryRequest チャット履歴の取得条件。before/afterはメッセージID（ULID）で、同時には指定できない
+type ChatHistoryRequest struct {
+	Limit int `query:"limit"`
+	// Before このメッセージより古いものを取得する（過去に遡る）
+	Before string `query:"before"`
+	// After このメッセージより新しいものを取得する（新着の差分を取る）
+	After string `query:"after"`
+}
+
+// Validate エラーメッセージはそのままクライアントに返す
+func (r *ChatHistoryRequest) Validate() error {
+	if r.Limit < 0 {
+		return errors.New("limitは0以上で指定してください")
+	}
+	if r.Before != "" && r.After != "" {
+		return errors.New("beforeとafterは同時に指定できません")
+	}
+	return nil
+}
+
+// MarkChatAsReadRequest 既読にするリクエスト
+type MarkChatAsReadRequest struct {
+	// LastReadMessageID このメッセージまでを既読にする。未指定なら最新のメッセージまで
+	LastReadMessageID string `json:"last_read_message_id,omitempty" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+}
+
+// Validate エラーメッセージはそのままクライアントに返す
+func (r *MarkChatAsReadRequest) Validate() error {
+	if r.LastReadMessageID == "" {
+		return nil
+	}
+	if _, err := ulid.ParseStrict(r.LastReadMessageID); err != nil {
+		return errors.New("last_read_message_idはメッセージIDで指定してください")
+	}
+	return nil
+}
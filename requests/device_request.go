@@ -0,0 +1,12 @@
+package requests
+
+// RegisterDeviceRequest プッシュ通知を受け取る端末の登録リクエスト
+type RegisterDeviceRequest struct {
+	Token    string `json:"token" example:"fcm-registration-token" binding:"required"`
+	Platform string `json:"platform" example:"ios" binding:"required"` // ios, android, web
+}
+
+// UnregisterDeviceRequest 端末の登録解除リクエスト
+type UnregisterDeviceRequest struct {
+	Token string `json:"token" example:"fcm-registration-token" binding:"required"`
+}
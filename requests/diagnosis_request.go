@@ -1,13 +1,15 @@
 package requests
 
 // 診断実行リクエスト
+// 会話はサーバー側でAvatar同士に生成させるため、クライアントからは受け取らない
 type ExecuteDiagnosisRequest struct {
-	TargetAvatarID   string                 `json:"target_avatar_id" validate:"required" example:"01ARZ3NDEKTSV4RRFFQ69G5FBV"`
-	ConversationData map[string]interface{} `json:"conversation_data" validate:"required"`
+	TargetAvatarID string `json:"target_avatar_id" validate:"required" example:"01ARZ3NDEKTSV4RRFFQ69G5FBV"`
+	TurnCount      int    `json:"turn_count,omitempty" example:"3"`                 // 往復数（省略時はデフォルト値）
+	Topic          string `json:"topic,omitempty" maxLength:"50" example:"休日の過ごし方"` // 会話のテーマ（任意、service.MaxDiagnosisTopicRunes 文字まで）
 }
 
 // 診断履歴取得のクエリパラメータ
 type GetDiagnosisHistoryQuery struct {
 	Limit  int `query:"limit" example:"20"`
 	Offset int `query:"offset" example:"0"`
-}
\ No newline at end of file
+}
@@ -1,5 +1,15 @@
 package requests
 
+// GetCandidatesRequest 相手候補一覧の取得条件
+type GetCandidatesRequest struct {
+	Limit  int `query:"limit"`
+	Offset int `query:"offset"`
+	MinAge int `query:"min_age"`
+	MaxAge int `query:"max_age"`
+	// Gender 複数指定可（?gender=female&gender=other）。未指定なら自分の恋愛対象
+	Gender []string `query:"gender"`
+}
+
 // SendMatchMessageRequest マッチ後の本人とのメッセージ送信リクエスト
 type SendMatchMessageRequest struct {
 	Content string `json:"content" example:"こんにちは！マッチできて嬉しいです。" binding:"required"`
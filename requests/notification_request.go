@@ -0,0 +1,44 @@
+package requests
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hackathon-20260110/api/models"
+)
+
+type ListNotificationsRequest struct {
+	// Cursor 前のページのnext_cursor。省略すると最新から
+	Cursor string `query:"cursor"`
+	Limit  int    `query:"limit"`
+}
+
+// UpdateNotificationSettingsRequest 通知設定の更新リクエスト。すべての項目を指定する
+type UpdateNotificationSettingsRequest struct {
+	MatchEnabled          bool `json:"match_enabled" example:"true"`
+	MissionUnlockEnabled  bool `json:"mission_unlock_enabled" example:"true"`
+	MessageEnabled        bool `json:"message_enabled" example:"true"`
+	AvatarActivityEnabled bool `json:"avatar_activity_enabled" example:"false"`
+	QuietHoursEnabled     bool `json:"quiet_hours_enabled" example:"true"`
+	// QuietHoursStart / QuietHoursEnd "HH:MM"。開始が終了より遅ければ日をまたぐ
+	QuietHoursStart string `json:"quiet_hours_start" example:"22:00"`
+	QuietHoursEnd   string `json:"quiet_hours_end" example:"07:00"`
+	Timezone        string `json:"timezone" example:"Asia/Tokyo"`
+}
+
+// Validate おやすみ時間とタイムゾーンを検証する。エラーメッセージはそのままクライアントに返す
+func (r *UpdateNotificationSettingsRequest) Validate() error {
+	if _, err := models.ParseClock(r.QuietHoursStart); err != nil {
+		return errors.New("quiet_hours_startはHH:MM形式で指定してください")
+	}
+	if _, err := models.ParseClock(r.QuietHoursEnd); err != nil {
+		return errors.New("quiet_hours_endはHH:MM形式で指定してください")
+	}
+	if r.Timezone == "" {
+		return errors.New("timezoneを指定してください")
+	}
+	if _, err := time.LoadLocation(r.Timezone); err != nil {
+		return errors.New("timezoneが不正です")
+	}
+	return nil
+}
@@ -1,11 +1,69 @@
 package requests
 
-import "time"
+import (
+	"errors"
+	"time"
+
+	"github.com/hackathon-20260110/api/models"
+)
+
+// 相手の年齢として指定できる範囲
+const (
+	MinPreferredAge = 18
+	MaxPreferredAge = 120
+)
 
 type CreateUserRequest struct {
 	DisplayName        string    `json:"display_name" example:"山田太郎"`
-	Gender             string    `json:"gender" example:"male"`
+	Gender             string    `json:"gender" example:"male"` // male, female, other
 	BirthDate          time.Time `json:"birth_date" example:"2000-01-01"`
 	Bio                string    `json:"bio" example:"よろしくお願いします！"`
 	ProfileImageBase64 string    `json:"profile_image_base64" example:"data:image/jpeg;base64,..."`
+
+	// InterestedInGenders 恋愛対象の性別。省略すると異性（otherは全員）
+	InterestedInGenders []string `json:"interested_in_genders,omitempty" example:"female"`
+	// PreferredMinAge / PreferredMaxAge 相手の年齢の希望。省略すると制限しない
+	PreferredMinAge int `json:"preferred_min_age,omitempty" example:"20"`
+	PreferredMaxAge int `json:"preferred_max_age,omitempty" example:"35"`
+	// MaxDistanceKm 相手との距離の上限。位置情報と合わせて指定する
+	MaxDistanceKm *int     `json:"max_distance_km,omitempty" example:"30"`
+	Latitude      *float64 `json:"latitude,omitempty" example:"35.681236"`
+	Longitude     *float64 `json:"longitude,omitempty" example:"139.767125"`
+}
+
+// Validate 性別と相手に求める条件を検証する。エラーメッセージはそのままクライアントに返す
+func (r *CreateUserRequest) Validate() error {
+	if !models.IsValidGender(r.Gender) {
+		return errors.New("genderにはmale, female, otherのいずれかを指定してください")
+	}
+	for _, gender := range r.InterestedInGenders {
+		if !models.IsValidGender(gender) {
+			return errors.New("interested_in_gendersにはmale, female, otherのみ指定できます")
+		}
+	}
+
+	for _, age := range []int{r.PreferredMinAge, r.PreferredMaxAge} {
+		if age != 0 && (age < MinPreferredAge || age > MaxPreferredAge) {
+			return errors.New("希望年齢は18〜120の範囲で指定してください")
+		}
+	}
+	if r.PreferredMinAge > 0 && r.PreferredMaxAge > 0 && r.PreferredMinAge > r.PreferredMaxAge {
+		return errors.New("preferred_min_ageはpreferred_max_age以下にしてください")
+	}
+
+	if (r.Latitude == nil) != (r.Longitude == nil) {
+		return errors.New("latitudeとlongitudeは両方指定してください")
+	}
+	if r.Latitude != nil && (*r.Latitude < -90 || *r.Latitude > 90 || *r.Longitude < -180 || *r.Longitude > 180) {
+		return errors.New("位置情報が不正です")
+	}
+	if r.MaxDistanceKm != nil {
+		if *r.MaxDistanceKm <= 0 {
+			return errors.New("max_distance_kmは1以上で指定してください")
+		}
+		if r.Latitude == nil {
+			return errors.New("max_distance_kmを指定する場合は位置情報も指定してください")
+		}
+	}
+	return nil
 }
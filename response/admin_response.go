@@ -0,0 +1,18 @@
+package response
+
+import "time"
+
+type PointAdjustmentAudit struct {
+	ID             string    `json:"id" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	AdminUserID    string    `json:"admin_user_id"`
+	RequestedDelta int       `json:"requested_delta" example:"-10"`
+	Reason         string    `json:"reason" example:"不正なポイント獲得が確認されたため"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type AdjustMatchingPointResponse struct {
+	Relation  UserAvatarRelation   `json:"relation"`
+	Event     PointEvent           `json:"event"`
+	Audit     PointAdjustmentAudit `json:"audit"`
+	IsMatched bool                 `json:"is_matched"`
+}
@@ -14,25 +14,34 @@ type LoginResponse struct {
 
 // User ユーザー情報
 type User struct {
-	ID                  string `json:"id" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
-	DisplayName         string `json:"display_name" example:"山田太郎"`
-	Age                 int    `json:"age" example:"25"`
-	Gender              string `json:"gender" example:"male"`
-	ProfileImageURL     string `json:"profile_image_url" example:"https://example.com/images/profile.jpg"`
-	Bio                 string `json:"bio" example:"よろしくお願いします！"`
-	OnboardingCompleted bool   `json:"onboarding_completed" example:"false"`
-	CreatedAt           string `json:"created_at" example:"2024-01-01T00:00:00Z"`
-	UpdatedAt           string `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+	ID                  string   `json:"id" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	DisplayName         string   `json:"display_name" example:"山田太郎"`
+	Age                 int      `json:"age" example:"25"`
+	Gender              string   `json:"gender" example:"male"`
+	ProfileImageURL     string   `json:"profile_image_url" example:"https://example.com/images/profile.jpg"`
+	Bio                 string   `json:"bio" example:"よろしくお願いします！"`
+	OnboardingCompleted bool     `json:"onboarding_completed" example:"false"`
+	InterestedInGenders []string `json:"interested_in_genders" example:"female"`
+	PreferredMinAge     int      `json:"preferred_min_age" example:"20"`
+	PreferredMaxAge     int      `json:"preferred_max_age" example:"35"`
+	MaxDistanceKm       *int     `json:"max_distance_km,omitempty" example:"30"`
+	CreatedAt           string   `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt           string   `json:"updated_at" example:"2024-01-01T00:00:00Z"`
 }
 
 func NewUserResponse(user models.User) User {
 	return User{
 		ID:                  user.ID,
 		DisplayName:         user.DisplayName,
+		Gender:              user.Gender,
 		Age:                 utils.CalculateAge(user.BirthDate, time.Now()),
 		ProfileImageURL:     user.ProfileImageURL,
 		Bio:                 user.Bio,
 		OnboardingCompleted: user.IsOnboardingCompleted,
+		InterestedInGenders: user.InterestedGenders(),
+		PreferredMinAge:     user.PreferredMinAge,
+		PreferredMaxAge:     user.PreferredMaxAge,
+		MaxDistanceKm:       user.MaxDistanceKm,
 		CreatedAt:           user.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:           user.UpdatedAt.Format(time.RFC3339),
 	}
@@ -24,10 +24,17 @@ type SendAvatarChatMessageResponse struct {
 	UnlockedMissions []UnlockedUserInfo `json:"unlocked_missions"`
 }
 
+// AvatarChatStreamChunk SSEの token イベントで送る返信の断片
+type AvatarChatStreamChunk struct {
+	Text string `json:"text" example:"そうなんですね"`
+}
+
 type GetAvatarChatMessagesResponse struct {
-	Messages      []AvatarChatMessage `json:"messages"`
-	MatchingPoint int                 `json:"matching_point" example:"50"`
-	IsMatched     bool                `json:"is_matched" example:"false"`
+	Messages []AvatarChatMessage `json:"messages"`
+	// NextCursor 続きがあれば次のbefore（afterで取得した場合は次のafter）に渡すメッセージID。無ければ空
+	NextCursor    string `json:"next_cursor" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	MatchingPoint int    `json:"matching_point" example:"50"`
+	IsMatched     bool   `json:"is_matched" example:"false"`
 }
 
 type GetAvatarChatStatusResponse struct {
@@ -33,15 +33,24 @@ type AvatarListResponse struct {
 	Avatars []AvatarWithRelation `json:"avatars"`
 }
 
-type UpdateMatchingPointResponse struct {
-	ID            string    `json:"id"`
-	UserID        string    `json:"user_id"`
-	AvatarID      string    `json:"avatar_id"`
-	MatchingPoint int       `json:"matching_point"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
-}
-
 type AvatarDetailResponse struct {
 	Avatar Avatar `json:"avatar"`
 }
+
+type PointEvent struct {
+	ID                 string    `json:"id" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	Source             string    `json:"source" example:"avatar_chat"` // avatar_chat, diagnosis, manual, decay
+	Delta              int       `json:"delta" example:"5"`
+	MatchingPointAfter int       `json:"matching_point_after" example:"45"`
+	Reason             string    `json:"reason" example:"共通の趣味の話で盛り上がった"`
+	MessageID          *string   `json:"message_id,omitempty" example:"01ARZ3NDEKTSV4RRFFQ69G5FBV"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+type PointHistoryResponse struct {
+	MatchingPoint int          `json:"matching_point" example:"45"`
+	Events        []PointEvent `json:"events"`
+	Total         int          `json:"total" example:"12"`
+	Limit         int          `json:"limit" example:"20"`
+	Offset        int          `json:"offset" example:"0"`
+}
@@ -9,7 +9,7 @@ type Chat struct {
 	PartnerImageURL string `json:"partner_image_url" example:"https://example.com/images/profile2.jpg"`
 	LastMessage     string `json:"last_message,omitempty" example:"こんにちは！"`
 	LastMessageAt   string `json:"last_message_at,omitempty" example:"2024-01-01T12:00:00Z"`
-	UnreadCount     int    `json:"unread_count" example:"0"`
+	UnreadCount     int    `json:"unread_count" example:"0"` // 相手（アバターの場合はアバター）からの未読メッセージ数
 	MatchingScore   int    `json:"matching_score" example:"75"`
 	IsMatched       bool   `json:"is_matched" example:"false"`
 	CreatedAt       string `json:"created_at" example:"2024-01-01T00:00:00Z"`
@@ -62,8 +62,8 @@ type ChatScore struct {
 	ChatID          string         `json:"chat_id" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
 	CurrentScore    int            `json:"current_score" example:"75"`
 	MaxScore        int            `json:"max_score" example:"100"`
-	ScoreBreakdown  map[string]int `json:"score_breakdown,omitempty"`
-	UnlockThreshold int            `json:"unlock_threshold" example:"80"` // マッチング成立に必要なスコア
+	ScoreBreakdown  map[string]int `json:"score_breakdown,omitempty"`      // ポイント履歴の発生源（avatar_chat, diagnosis, manual, decay）ごとの合計
+	UnlockThreshold int            `json:"unlock_threshold" example:"100"` // マッチング成立に必要なスコア
 	UpdatedAt       string         `json:"updated_at" example:"2024-01-01T12:00:00Z"`
 }
 
@@ -71,3 +71,11 @@ type ChatScore struct {
 type GetChatScoreResponse struct {
 	Score ChatScore `json:"score"`
 }
+
+// MarkChatAsReadResponse 既読にした結果
+type MarkChatAsReadResponse struct {
+	// LastReadMessageID 既読にした最後のメッセージID。メッセージが無ければ空
+	LastReadMessageID string `json:"last_read_message_id" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	// UnreadCount まだ読んでいない相手からのメッセージ数
+	UnreadCount int `json:"unread_count" example:"0"`
+}
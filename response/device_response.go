@@ -0,0 +1,17 @@
+package response
+
+import "time"
+
+// Device プッシュ通知の送信先として登録された端末
+type Device struct {
+	ID        string    `json:"id" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	Token     string    `json:"token" example:"fcm-registration-token"`
+	Platform  string    `json:"platform" example:"ios"`
+	CreatedAt time.Time `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+}
+
+// UnregisterDeviceResponse 端末の登録解除レスポンス
+type UnregisterDeviceResponse struct {
+	Message string `json:"message" example:"端末の登録を解除しました"`
+}
@@ -65,9 +65,11 @@ type MatchMessage struct {
 // GetMatchMessagesResponse マッチ後のメッセージ履歴取得レスポンス
 type GetMatchMessagesResponse struct {
 	Messages []MatchMessage `json:"messages"`
-	Total    int            `json:"total" example:"20"`
-	Limit    int            `json:"limit" example:"20"`
-	Offset   int            `json:"offset" example:"0"`
+	// NextCursor 続きがあれば次のbefore（afterで取得した場合は次のafter）に渡すメッセージID。無ければ空
+	NextCursor string `json:"next_cursor" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	Limit      int    `json:"limit" example:"20"`
+	// PartnerLastReadMessageID 相手が既読にした最後のメッセージID。これ以下のIDの自分のメッセージに「既読」を表示する
+	PartnerLastReadMessageID string `json:"partner_last_read_message_id" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
 }
 
 // SendMatchMessageResponse マッチ後のメッセージ送信レスポンス
@@ -1,5 +1,56 @@
 package response
 
+import "time"
+
 type MarkAsReadResponse struct {
 	Message string `json:"message" example:"通知を既読にしました"`
 }
+
+// Notification 通知
+type Notification struct {
+	ID       string            `json:"id" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	Type     string            `json:"type" example:"match" enums:"match,mission_unlock,message,avatar_activity,system"`
+	Title    string            `json:"title" example:"マッチング成立"`
+	Message  string            `json:"message" example:"佐藤花子さんとマッチングしました！"`
+	DeepLink string            `json:"deep_link,omitempty" example:"/matches/01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	Payload  map[string]string `json:"payload,omitempty"`
+	HasRead  bool              `json:"has_read" example:"false"`
+	// CreatedAt 通知の作成日時
+	CreatedAt time.Time `json:"created_at" example:"2024-01-01T00:00:00Z"`
+}
+
+// NotificationListResponse 通知一覧レスポンス
+type NotificationListResponse struct {
+	Notifications []Notification `json:"notifications"`
+	// NextCursor 次のページを取得するときにcursorに指定する。最後のページでは空
+	NextCursor string `json:"next_cursor,omitempty" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	HasMore    bool   `json:"has_more" example:"true"`
+}
+
+// UnreadCountResponse 未読件数レスポンス
+type UnreadCountResponse struct {
+	UnreadCount int64 `json:"unread_count" example:"3"`
+}
+
+// MarkAllAsReadResponse 一括既読レスポンス
+type MarkAllAsReadResponse struct {
+	Message      string `json:"message" example:"すべての通知を既読にしました"`
+	UpdatedCount int    `json:"updated_count" example:"3"`
+}
+
+// DeleteNotificationResponse 通知削除レスポンス
+type DeleteNotificationResponse struct {
+	Message string `json:"message" example:"通知を削除しました"`
+}
+
+// NotificationSettings 通知設定
+type NotificationSettings struct {
+	MatchEnabled          bool   `json:"match_enabled" example:"true"`
+	MissionUnlockEnabled  bool   `json:"mission_unlock_enabled" example:"true"`
+	MessageEnabled        bool   `json:"message_enabled" example:"true"`
+	AvatarActivityEnabled bool   `json:"avatar_activity_enabled" example:"true"`
+	QuietHoursEnabled     bool   `json:"quiet_hours_enabled" example:"false"`
+	QuietHoursStart       string `json:"quiet_hours_start" example:"22:00"`
+	QuietHoursEnd         string `json:"quiet_hours_end" example:"07:00"`
+	Timezone              string `json:"timezone" example:"Asia/Tokyo"`
+}
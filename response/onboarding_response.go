@@ -1,6 +1,10 @@
 package response
 
-import "github.com/hackathon-20260110/api/models"
+import (
+	"time"
+
+	"github.com/hackathon-20260110/api/models"
+)
 
 // StartOnboardingResponse オンボーディング開始レスポンス（チャット形式）
 type StartOnboardingResponse struct {
@@ -48,3 +52,23 @@ type OnboardingCompleteResponse struct {
 	UserInfos []models.UserInfo `json:"user_infos"`
 	Avatar    models.Avatar     `json:"avatar"`
 }
+
+type OnboardingReplyStatus string
+
+const (
+	// OnboardingReplyStatusNone まだメッセージを送っていない
+	OnboardingReplyStatusNone    OnboardingReplyStatus = "none"
+	OnboardingReplyStatusPending OnboardingReplyStatus = "pending"
+	// OnboardingReplyStatusFailed リトライしても返信を生成できなかった。メッセージを送り直してもらう
+	OnboardingReplyStatusFailed OnboardingReplyStatus = "failed"
+	OnboardingReplyStatusDone   OnboardingReplyStatus = "done"
+)
+
+// OnboardingReplyStatusResponse 直近のメッセージに対するシステム返信の生成状況
+type OnboardingReplyStatusResponse struct {
+	Status      OnboardingReplyStatus `json:"status" example:"pending"` // none, pending, failed, done
+	JobID       string                `json:"job_id,omitempty" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	Attempts    int                   `json:"attempts" example:"1"`
+	NextRetryAt *time.Time            `json:"next_retry_at,omitempty"`
+	UpdatedAt   *time.Time            `json:"updated_at,omitempty"`
+}
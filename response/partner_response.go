@@ -3,18 +3,27 @@ package response
 // Partner 相手候補の基本情報
 type Partner struct {
 	ID              string `json:"id" example:"01ARZ3NDEKTSV4RRFFQ69G5FBV"`
+	AvatarID        string `json:"avatar_id" example:"01ARZ3NDEKTSV4RRFFQ69G5FCV"`
 	DisplayName     string `json:"display_name" example:"佐藤花子"`
 	Age             int    `json:"age" example:"24"`
 	Gender          string `json:"gender" example:"female"`
 	ProfileImageURL string `json:"profile_image_url" example:"https://example.com/images/profile2.jpg"`
 	Bio             string `json:"bio" example:"よろしくお願いします！"`
+	// MatchingPoint 相手のアバターとの会話で貯めたポイント
+	MatchingPoint int `json:"matching_point" example:"30"`
+	// CompatibilityScore プロフィールの重なりとマッチングポイントから計算した相性（0〜100）。一覧はこの降順
+	CompatibilityScore int `json:"compatibility_score" example:"42"`
 	// 基本情報のみを返す（詳細情報は段階的に公開）
 }
 
 // GetPartnersResponse 相手候補一覧取得レスポンス
 type GetPartnersResponse struct {
 	Partners []Partner `json:"partners"`
-	Total    int       `json:"total" example:"50"`
-	Limit    int       `json:"limit" example:"20"`
-	Offset   int       `json:"offset" example:"0"`
+	// Total 並べ替えの対象になった人数。Truncatedなら条件に合う全員ではない
+	Total  int `json:"total" example:"50"`
+	Limit  int `json:"limit" example:"20"`
+	Offset int `json:"offset" example:"0"`
+	// Truncated 条件に合う候補が多く、マッチングポイントの高い順に上限の人数だけを並べ替えた。
+	// この場合、上限より後ろのオフセットは空になり、ポイントの無い相手は相性が良くても含まれないことがある
+	Truncated bool `json:"truncated" example:"false"`
 }
@@ -30,4 +30,8 @@ type SendUserChatMessageResponse struct {
 
 type GetUserChatMessagesResponse struct {
 	Messages []UserChatMessageResponse `json:"messages"`
+	// NextCursor 続きがあれば次のbefore（afterで取得した場合は次のafter）に渡すメッセージID。無ければ空
+	NextCursor string `json:"next_cursor" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	// PartnerLastReadMessageID 相手が既読にした最後のメッセージID。これ以下のIDの自分のメッセージに「既読」を表示する
+	PartnerLastReadMessageID string `json:"partner_last_read_message_id" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
 }
@@ -0,0 +1,18 @@
+package router
+
+import (
+	"github.com/hackathon-20260110/api/controller"
+	"github.com/hackathon-20260110/api/middleware"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/dig"
+)
+
+func AdminRouter(e *echo.Echo, container *dig.Container) {
+	controller := resolveController[*controller.AdminController](container)
+
+	firebaseAuth := firebaseAuthMiddleware(container)
+	adminOnly := middleware.RequireRole(middleware.RoleAdmin)
+
+	// ポイントは通常アバターチャットと相性診断でのみ増減する。ここは運営による補正用
+	e.POST("/admin/users/:userId/avatars/:avatarId/matching-points", controller.AdjustMatchingPoint, firebaseAuth, adminOnly)
+}
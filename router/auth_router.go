@@ -2,16 +2,15 @@ package router
 
 import (
 	"github.com/hackathon-20260110/api/controller"
-	"github.com/hackathon-20260110/api/middleware"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/dig"
 )
 
 func AuthRouter(e *echo.Echo, container *dig.Container) {
-	controller := controller.NewAuthController(container)
+	controller := resolveController[*controller.AuthController](container)
 
 	// Firebase認証ミドルウェアを適用
-	firebaseAuth := middleware.FirebaseAuthMiddleware()
+	firebaseAuth := firebaseAuthMiddleware(container)
 
 	// すべてのauthエンドポイントでFirebase認証を必須にする
 	e.GET("/auth/me", controller.Me, firebaseAuth)
@@ -2,17 +2,18 @@ package router
 
 import (
 	"github.com/hackathon-20260110/api/controller"
-	"github.com/hackathon-20260110/api/middleware"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/dig"
 )
 
 func AvatarChatRouter(e *echo.Echo, container *dig.Container) {
-	c := controller.NewAvatarChatController(container)
+	c := resolveController[*controller.AvatarChatController](container)
 
-	firebaseAuth := middleware.FirebaseAuthMiddleware()
+	firebaseAuth := firebaseAuthMiddleware(container)
 
 	e.POST("/avatar-chats/:avatar_id/messages", c.SendMessage, firebaseAuth)
 	e.GET("/avatar-chats/:avatar_id/messages", c.GetMessages, firebaseAuth)
+	e.POST("/avatar-chats/:avatar_id/messages/stream", c.StreamMessage, firebaseAuth)
 	e.GET("/avatar-chats/:avatar_id/status", c.GetStatus, firebaseAuth)
+	e.POST("/avatar-chats/:avatar_id/read", c.MarkAsRead, firebaseAuth)
 }
@@ -2,16 +2,15 @@ package router
 
 import (
 	"github.com/hackathon-20260110/api/controller"
-	"github.com/hackathon-20260110/api/middleware"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/dig"
 )
 
 func AvatarRouter(e *echo.Echo, container *dig.Container) {
-	controller := controller.NewAvatarController(container)
+	controller := resolveController[*controller.AvatarController](container)
 
-	firebaseAuth := middleware.FirebaseAuthMiddleware()
+	firebaseAuth := firebaseAuthMiddleware(container)
 
 	e.GET("/avatars", controller.GetAvatarList, firebaseAuth)
-	e.POST("/avatars/:avatarId/matching-points", controller.UpdateMatchingPoint, firebaseAuth)
+	e.GET("/avatars/:avatarId/point-history", controller.GetPointHistory, firebaseAuth)
 }
@@ -2,16 +2,15 @@ package router
 
 import (
 	"github.com/hackathon-20260110/api/controller"
-	"github.com/hackathon-20260110/api/middleware"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/dig"
 )
 
 func ChatRouter(e *echo.Echo, container *dig.Container) {
-	controller := controller.NewChatController(container)
+	controller := resolveController[*controller.ChatController](container)
 
 	// Firebase認証ミドルウェアを適用
-	firebaseAuth := middleware.FirebaseAuthMiddleware()
+	firebaseAuth := firebaseAuthMiddleware(container)
 
 	// すべてのchatsエンドポイントでFirebase認証を必須にする
 	e.POST("/chats", controller.CreateChat, firebaseAuth)
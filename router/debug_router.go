@@ -1,19 +1,29 @@
 package router
 
 import (
+	"github.com/hackathon-20260110/api/config"
 	"github.com/hackathon-20260110/api/controller"
-	"github.com/hackathon-20260110/api/middleware"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/dig"
 )
 
 func DebugRouter(e *echo.Echo, container *dig.Container) {
-	controller := controller.NewDebugController(container)
-	firebaseAuth := middleware.FirebaseAuthMiddleware()
+	controller := resolveController[*controller.DebugController](container)
+	firebaseAuth := firebaseAuthMiddleware(container)
 
 	e.GET("/debug/health", controller.Health)
 	e.GET("/debug/endpoints", controller.Endpoints)
 	e.POST("/debug/echo", controller.Echo)
 	e.GET("/debug/auth-check", controller.AtuchCheck, firebaseAuth)
+
+	// 任意のユーザーのIDトークンを発行できるので、APIが署名したトークンで認証するときだけ公開する
+	if resolveController[*config.Config](container).Auth.Provider == config.AuthProviderLocal {
+		debugTokenRouter(e, container)
+	}
+}
+
+func debugTokenRouter(e *echo.Echo, container *dig.Container) {
+	controller := resolveController[*controller.DebugTokenController](container)
+
 	e.GET("/debug/id-token", controller.IDToken)
 }
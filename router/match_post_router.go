@@ -2,16 +2,15 @@ package router
 
 import (
 	"github.com/hackathon-20260110/api/controller"
-	"github.com/hackathon-20260110/api/middleware"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/dig"
 )
 
 func MatchPostRouter(e *echo.Echo, container *dig.Container) {
-	controller := controller.NewMatchPostController(container)
+	controller := resolveController[*controller.MatchPostController](container)
 
 	// Firebase認証ミドルウェアを適用
-	firebaseAuth := middleware.FirebaseAuthMiddleware()
+	firebaseAuth := firebaseAuthMiddleware(container)
 
 	// マッチング関連のエンドポイント
 	e.GET("/matches/candidates", controller.GetCandidates, firebaseAuth) // 相手候補一覧（/partnersから移動）
@@ -2,14 +2,17 @@ package router
 
 import (
 	"github.com/hackathon-20260110/api/controller"
-	"github.com/hackathon-20260110/api/middleware"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/dig"
 )
 
 func NotificationRouter(e *echo.Echo, container *dig.Container) {
-	notificationController := controller.NewNotificationController(container)
-	firebaseAuth := middleware.FirebaseAuthMiddleware()
+	notificationController := resolveController[*controller.NotificationController](container)
+	firebaseAuth := firebaseAuthMiddleware(container)
 
+	e.GET("/notification", notificationController.ListNotifications, firebaseAuth)
+	e.GET("/notification/unread-count", notificationController.GetUnreadCount, firebaseAuth)
+	e.POST("/notification/read-all", notificationController.MarkAllAsRead, firebaseAuth)
 	e.POST("/notification/read/:id", notificationController.MarkAsRead, firebaseAuth)
+	e.DELETE("/notification/:id", notificationController.DeleteNotification, firebaseAuth)
 }
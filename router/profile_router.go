@@ -2,14 +2,13 @@ package router
 
 import (
 	"github.com/hackathon-20260110/api/controller"
-	"github.com/hackathon-20260110/api/middleware"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/dig"
 )
 
 func ProfileRouter(e *echo.Echo, container *dig.Container) {
-	controller := controller.NewProfileController(container)
-	firebaseAuth := middleware.FirebaseAuthMiddleware()
+	controller := resolveController[*controller.ProfileController](container)
+	firebaseAuth := firebaseAuthMiddleware(container)
 
 	// プロフィール関連エンドポイント
 	e.GET("/profile/predefined-keys", controller.GetPredefinedKeys, firebaseAuth)
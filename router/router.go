@@ -0,0 +1,31 @@
+package router
+
+import (
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/middleware"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/dig"
+)
+
+// resolveController コンテナで組み立て済みのコントローラー（や設定）を取り出す。
+// 依存が足りない場合は起動時に気付けるよう panic する
+func resolveController[T any](container *dig.Container) T {
+	var resolved T
+	if err := container.Invoke(func(c T) {
+		resolved = c
+	}); err != nil {
+		panic(err)
+	}
+	return resolved
+}
+
+// firebaseAuthMiddleware コンテナのTokenVerifierでIDトークンを検証する認証ミドルウェアを作る
+func firebaseAuthMiddleware(container *dig.Container) echo.MiddlewareFunc {
+	var verifier adapter.TokenVerifier
+	if err := container.Invoke(func(v adapter.TokenVerifier) {
+		verifier = v
+	}); err != nil {
+		panic(err)
+	}
+	return middleware.FirebaseAuthMiddleware(verifier)
+}
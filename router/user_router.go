@@ -2,16 +2,15 @@ package router
 
 import (
 	"github.com/hackathon-20260110/api/controller"
-	"github.com/hackathon-20260110/api/middleware"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/dig"
 )
 
 func UserRouter(e *echo.Echo, container *dig.Container) {
-	controller := controller.NewUserController(container)
+	controller := resolveController[*controller.UserController](container)
 
 	// Firebase認証ミドルウェアを適用
-	firebaseAuth := middleware.FirebaseAuthMiddleware()
+	firebaseAuth := firebaseAuthMiddleware(container)
 
 	// すべてのusersエンドポイントでFirebase認証を必須にする
 	e.GET("/users/me", controller.GetMe, firebaseAuth)
@@ -20,4 +19,8 @@ func UserRouter(e *echo.Echo, container *dig.Container) {
 	e.GET("/users/:userId", controller.GetUser, firebaseAuth)
 	e.GET("/users/:userId/avatar-ai", controller.GetUserAvatarAI, firebaseAuth)
 	e.GET("/users/me/avatar-ai", controller.GetMyAvatarAI, firebaseAuth)
+	e.POST("/users/me/devices", controller.RegisterDevice, firebaseAuth)
+	e.DELETE("/users/me/devices", controller.UnregisterDevice, firebaseAuth)
+	e.GET("/users/me/notification-settings", controller.GetNotificationSettings, firebaseAuth)
+	e.PUT("/users/me/notification-settings", controller.UpdateNotificationSettings, firebaseAuth)
 }
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/response"
+)
+
+// AdminService 運営用の操作。ロールの確認はルーターのミドルウェアで行う
+type AdminService struct {
+	avatarAdapter     adapter.AvatarAdapter
+	userAdapter       adapter.UserAdapter
+	pointEventAdapter adapter.PointEventAdapter
+	notifier          Notifier
+}
+
+func NewAdminService(
+	avatarAdapter adapter.AvatarAdapter,
+	userAdapter adapter.UserAdapter,
+	pointEventAdapter adapter.PointEventAdapter,
+	notifier Notifier,
+) *AdminService {
+	return &AdminService{
+		avatarAdapter:     avatarAdapter,
+		userAdapter:       userAdapter,
+		pointEventAdapter: pointEventAdapter,
+		notifier:          notifier,
+	}
+}
+
+// AdjustMatchingPoint ユーザーとアバターのマッチングポイントを運営が調整する。
+// 通常の獲得経路と同じく上下限やマッチング成立の判定を通し、監査ログを残す
+func (s *AdminService) AdjustMatchingPoint(ctx context.Context, adminUserID, userID, avatarID string, points int, reason string) (*response.AdjustMatchingPointResponse, error) {
+	avatar, err := s.avatarAdapter.GetByID(avatarID)
+	if err != nil {
+		return nil, err
+	}
+	// 存在しないユーザーへのRelationを作らない
+	if _, err := s.userAdapter.GetByID(userID); err != nil {
+		return nil, err
+	}
+
+	result, audit, err := s.pointEventAdapter.ApplyAdminAdjustment(adminUserID, userID, avatarID, models.PointEvent{
+		Source: models.PointEventSourceManual,
+		Delta:  points,
+		Reason: reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.NewMatching != nil {
+		notifyNewMatching(ctx, s.notifier, s.userAdapter, result.NewMatching, userID, avatar.UserID)
+	}
+	notifyUnlockedMissions(ctx, s.notifier, s.userAdapter, result.UnlockedMissions, userID, avatar)
+
+	relation := result.Relation
+	event := result.Event
+	return &response.AdjustMatchingPointResponse{
+		Relation: response.UserAvatarRelation{
+			ID:            relation.ID,
+			UserID:        relation.UserID,
+			AvatarID:      relation.AvatarID,
+			MatchingPoint: relation.MatchingPoint,
+			CreatedAt:     relation.CreatedAt,
+			UpdatedAt:     relation.UpdatedAt,
+		},
+		Event: response.PointEvent{
+			ID:                 event.ID,
+			Source:             string(event.Source),
+			Delta:              event.Delta,
+			MatchingPointAfter: event.MatchingPointAfter,
+			Reason:             event.Reason,
+			CreatedAt:          event.CreatedAt,
+		},
+		Audit: response.PointAdjustmentAudit{
+			ID:             audit.ID,
+			AdminUserID:    audit.AdminUserID,
+			RequestedDelta: audit.RequestedDelta,
+			Reason:         audit.Reason,
+			CreatedAt:      audit.CreatedAt,
+		},
+		IsMatched: result.IsMatched,
+	}, nil
+}
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/utils"
+)
+
+const (
+	// avatarChatSummaryInterval 要約に含まれていないメッセージがこの往復数だけ溜まったら要約を更新する。
+	// avatarChatPromptWindow より小さくし、直近の履歴から外れる前に要約へ取り込む
+	avatarChatSummaryInterval = 10
+	// avatarChatSummaryBatchSize 要約の更新で1回のLLM呼び出しに渡すメッセージ数
+	avatarChatSummaryBatchSize = 100
+	// maxAvatarChatMemoryFacts 覚えておく相手についての事実の上限
+	maxAvatarChatMemoryFacts = 30
+)
+
+// avatarChatSummaryPayload JobTypeAvatarChatSummary のペイロード
+type avatarChatSummaryPayload struct {
+	UserID   string `json:"user_id"`
+	AvatarID string `json:"avatar_id"`
+}
+
+// avatarChatSummary 要約更新の構造化出力
+type avatarChatSummary struct {
+	Summary string   `json:"summary"`
+	Facts   []string `json:"facts"`
+}
+
+func (s *avatarChatSummary) LLMSchema() *adapter.LLMSchema {
+	return &adapter.LLMSchema{
+		Type: adapter.LLMSchemaTypeObject,
+		Properties: map[string]*adapter.LLMSchema{
+			"summary": {
+				Type:        adapter.LLMSchemaTypeString,
+				Description: "これまでの会話全体の要約",
+				MinLength:   adapter.LLMSchemaCount(1),
+			},
+			"facts": {
+				Type:        adapter.LLMSchemaTypeArray,
+				Description: "会話から分かった相手についての事実",
+				Items:       &adapter.LLMSchema{Type: adapter.LLMSchemaTypeString},
+			},
+		},
+		Required: []string{"summary", "facts"},
+	}
+}
+
+// buildAvatarChatMemorySection 直近の履歴より前の会話を、要約と相手についての事実としてプロンプトに渡す
+func buildAvatarChatMemorySection(memory *models.AvatarChatMemory) string {
+	if memory == nil || (memory.Summary == "" && len(memory.Facts) == 0) {
+		return ""
+	}
+
+	section := ""
+	if memory.Summary != "" {
+		section += fmt.Sprintf("# これまでの会話の要約\n%s\n\n", memory.Summary)
+	}
+	if len(memory.Facts) > 0 {
+		section += "# 相手について覚えていること\n"
+		section += "会話の中で自然に触れてかまいません。相手に同じことを何度も聞かないでください。\n"
+		for _, fact := range memory.Facts {
+			section += fmt.Sprintf("- %s\n", fact)
+		}
+		section += "\n"
+	}
+	return section
+}
+
+// scheduleAvatarChatSummary 要約に含まれていないメッセージが溜まっていたら、要約の更新をジョブに登録する。
+// 返信は保存済みなので、失敗してもログだけ残す
+func scheduleAvatarChatSummary(jobAdapter adapter.JobAdapter, turn *avatarChatTurn, replyID string) {
+	unsummarized := 0
+	for _, msg := range turn.chatHistory {
+		if !turn.memory.IsSummarized(msg.ID) {
+			unsummarized++
+		}
+	}
+	if !turn.memory.IsSummarized(replyID) {
+		unsummarized++
+	}
+	if unsummarized < avatarChatSummaryInterval*2 {
+		return
+	}
+
+	subjectID := avatarChatSummarySubject(turn.userID, turn.avatarID)
+	payload, err := json.Marshal(avatarChatSummaryPayload{UserID: turn.userID, AvatarID: turn.avatarID})
+	if err != nil {
+		log.Printf("Error marshaling avatar chat summary payload for %s: %v", subjectID, err)
+		return
+	}
+	// 同時に返信した別のターンが登録済みなら何もしない。同じ会話を並行して要約しないよう、重複はキューで防ぐ
+	if _, err := jobAdapter.EnqueueIfNotActive(&models.Job{
+		Type:      models.JobTypeAvatarChatSummary,
+		SubjectID: subjectID,
+		Payload:   string(payload),
+	}); err != nil {
+		log.Printf("Error enqueueing avatar chat summary for %s: %v", subjectID, err)
+	}
+}
+
+func avatarChatSummarySubject(userID string, avatarID string) string {
+	return userID + ":" + avatarID
+}
+
+// SummarizeAvatarChat 要約に含まれていないメッセージを古い順に読み、要約と相手についての事実を更新するジョブのハンドラー。
+// バッチごとに保存するので、途中で失敗しても再実行時は続きから処理する
+func (s *AvatarChatService) SummarizeAvatarChat(ctx context.Context, job models.Job) error {
+	var payload avatarChatSummaryPayload
+	if err := json.Unmarshal([]byte(job.Payload), &payload); err != nil {
+		return utils.WrapError(err)
+	}
+
+	avatar, err := s.avatarAdapter.GetByID(payload.AvatarID)
+	if err != nil {
+		return utils.WrapError(err)
+	}
+	avatarOwnerUser, err := s.userAdapter.GetByID(avatar.UserID)
+	if err != nil {
+		return utils.WrapError(err)
+	}
+
+	memory, err := s.avatarChatMemoryAdapter.Get(payload.UserID, payload.AvatarID)
+	if err != nil {
+		return utils.WrapError(err)
+	}
+
+	for {
+		messages, nextCursor, err := s.avatarChatAdapter.GetAvatarChatMessages(ctx, payload.UserID, payload.AvatarID, adapter.ChatPage{
+			Limit:      avatarChatSummaryBatchSize,
+			After:      memory.SummarizedUntil,
+			FromOldest: true,
+		})
+		if err != nil {
+			return utils.WrapError(err)
+		}
+		if len(messages) == 0 {
+			return nil
+		}
+
+		summary, err := s.summarizeAvatarChat(ctx, s.llmAdapter, avatarOwnerUser, memory, messages)
+		if err != nil {
+			return utils.WrapError(err)
+		}
+
+		memory.Summary = strings.TrimSpace(summary.Summary)
+		memory.Facts = normalizeAvatarChatFacts(summary.Facts)
+		memory.SummarizedUntil = messages[len(messages)-1].ID
+		if err := s.avatarChatMemoryAdapter.Save(memory); err != nil {
+			return utils.WrapError(err)
+		}
+
+		if nextCursor == "" {
+			return nil
+		}
+	}
+}
+
+func (s *AvatarChatService) summarizeAvatarChat(
+	ctx context.Context,
+	llmAdapter adapter.LLMAdapter,
+	avatarOwnerUser models.User,
+	memory *models.AvatarChatMemory,
+	messages []adapter.AvatarChatMessage,
+) (*avatarChatSummary, error) {
+	previousSummary := memory.Summary
+	if previousSummary == "" {
+		previousSummary = "（なし）"
+	}
+	previousFacts := ""
+	for _, fact := range memory.Facts {
+		previousFacts += fmt.Sprintf("- %s\n", fact)
+	}
+	if previousFacts == "" {
+		previousFacts = "（なし）\n"
+	}
+
+	chatHistoryStr := ""
+	for _, msg := range messages {
+		sender := "相手"
+		if msg.SenderType == models.SenderTypeAvatarAI {
+			sender = avatarOwnerUser.DisplayName
+		}
+		chatHistoryStr += fmt.Sprintf("%s: 「%s」\n", sender, msg.Message)
+	}
+
+	prompt := fmt.Sprintf(`
+# 命令
+マッチングアプリで、相手が「%s」の分身AIと会話しています。
+これまでの要約と覚えている事実に、新しい会話の内容を反映して書き直してください。
+
+# これまでの要約
+%s
+
+# 相手について覚えていること
+%s
+# 新しい会話
+%s
+# 出力形式
+以下のJSON形式で出力してください。他の文字は一切出力しないでください。
+- summary: 会話全体の要約。話題の流れと、二人の関係の進み具合が分かるように300文字程度で
+- facts: 会話から分かった相手（「相手」と表記した側）についての事実の配列。仕事・住んでいる場所・趣味・予定・好き嫌いなど、後の会話で覚えていると嬉しいことを短い文で
+  - これまでの事実は、新しい会話で変わったものだけ更新し、それ以外は残してください
+  - %s自身についての情報や、推測は含めないでください
+  - 最大%d件
+`, avatarOwnerUser.DisplayName, previousSummary, previousFacts, chatHistoryStr, avatarOwnerUser.DisplayName, maxAvatarChatMemoryFacts)
+
+	req := adapter.NewLLMPromptRequest(adapter.LLM_MODEL_TYPE_GEMINI2_5_FLASH, adapter.LLMTaskAvatarChatSummary, prompt)
+
+	var summary avatarChatSummary
+	if err := llmAdapter.CreateStructuredCompletion(ctx, req, &summary); err != nil {
+		return nil, utils.WrapError(err)
+	}
+	return &summary, nil
+}
+
+// normalizeAvatarChatFacts 空の項目と重複を除き、上限を超えた分は古い方から捨てる
+func normalizeAvatarChatFacts(facts []string) []string {
+	seen := make(map[string]bool, len(facts))
+	normalized := make([]string, 0, len(facts))
+	for _, fact := range facts {
+		fact = strings.TrimSpace(fact)
+		if fact == "" || seen[fact] {
+			continue
+		}
+		seen[fact] = true
+		normalized = append(normalized, fact)
+	}
+	if len(normalized) > maxAvatarChatMemoryFacts {
+		normalized = normalized[len(normalized)-maxAvatarChatMemoryFacts:]
+	}
+	return normalized
+}
@@ -3,33 +3,104 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/hackathon-20260110/api/adapter"
 	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/response"
 	"github.com/hackathon-20260110/api/utils"
-	"go.uber.org/dig"
-	"google.golang.org/genai"
 	"gorm.io/gorm"
 )
 
 type AvatarChatService struct {
-	container *dig.Container
+	avatarChatAdapter       adapter.AvatarChatAdapter
+	avatarChatMemoryAdapter adapter.AvatarChatMemoryAdapter
+	avatarAdapter           adapter.AvatarAdapter
+	userAdapter             adapter.UserAdapter
+	userInfoAdapter         adapter.UserInfoAdapter
+	missionAdapter          adapter.MissionAdapter
+	matchingAdapter         adapter.MatchingAdapter
+	llmAdapter              adapter.LLMAdapter
+	pointEventAdapter       adapter.PointEventAdapter
+	jobAdapter              adapter.JobAdapter
+	chatReadCursorAdapter   adapter.ChatReadCursorAdapter
+	chatSummaryAdapter      adapter.ChatSummaryAdapter
+	notifier                Notifier
 }
 
-func NewAvatarChatService(container *dig.Container) *AvatarChatService {
-	return &AvatarChatService{container: container}
+func NewAvatarChatService(
+	avatarChatAdapter adapter.AvatarChatAdapter,
+	avatarChatMemoryAdapter adapter.AvatarChatMemoryAdapter,
+	avatarAdapter adapter.AvatarAdapter,
+	userAdapter adapter.UserAdapter,
+	userInfoAdapter adapter.UserInfoAdapter,
+	missionAdapter adapter.MissionAdapter,
+	matchingAdapter adapter.MatchingAdapter,
+	llmAdapter adapter.LLMAdapter,
+	pointEventAdapter adapter.PointEventAdapter,
+	jobAdapter adapter.JobAdapter,
+	chatReadCursorAdapter adapter.ChatReadCursorAdapter,
+	chatSummaryAdapter adapter.ChatSummaryAdapter,
+	notifier Notifier,
+) *AvatarChatService {
+	return &AvatarChatService{
+		avatarChatAdapter:       avatarChatAdapter,
+		avatarChatMemoryAdapter: avatarChatMemoryAdapter,
+		avatarAdapter:           avatarAdapter,
+		userAdapter:             userAdapter,
+		userInfoAdapter:         userInfoAdapter,
+		missionAdapter:          missionAdapter,
+		matchingAdapter:         matchingAdapter,
+		llmAdapter:              llmAdapter,
+		pointEventAdapter:       pointEventAdapter,
+		jobAdapter:              jobAdapter,
+		chatReadCursorAdapter:   chatReadCursorAdapter,
+		chatSummaryAdapter:      chatSummaryAdapter,
+		notifier:                notifier,
+	}
 }
 
+// 1回のアバターチャットで増減するマッチングポイントの範囲
+const (
+	MinAvatarChatPointChange = -10
+	MaxAvatarChatPointChange = 10
+)
+
 type LLMChatResponse struct {
 	Message     string `json:"message"`
 	PointChange int    `json:"point_change"`
 	Reason      string `json:"reason"`
 }
 
+func (r *LLMChatResponse) LLMSchema() *adapter.LLMSchema {
+	return &adapter.LLMSchema{
+		Type: adapter.LLMSchemaTypeObject,
+		Properties: map[string]*adapter.LLMSchema{
+			"message": {
+				Type:        adapter.LLMSchemaTypeString,
+				Description: "相手へのメッセージ（2〜3文程度、日本語）",
+				MinLength:   adapter.LLMSchemaCount(1),
+			},
+			"point_change": {
+				Type:        adapter.LLMSchemaTypeInteger,
+				Description: "会話の質に基づくポイント変化",
+				Minimum:     adapter.LLMSchemaBound(MinAvatarChatPointChange),
+				Maximum:     adapter.LLMSchemaBound(MaxAvatarChatPointChange),
+			},
+			"reason": {
+				Type:        adapter.LLMSchemaTypeString,
+				Description: "ポイント変化の理由（簡潔に）",
+			},
+		},
+		Required: []string{"message", "point_change", "reason"},
+	}
+}
+
 type SendMessageResult struct {
 	AvatarResponse   adapter.AvatarChatMessage
 	MatchingPoint    int
@@ -45,73 +116,75 @@ type UnlockedMissionInfo struct {
 	Value      string
 }
 
+// avatarChatPromptWindow 返信の生成とポイント評価でプロンプトに含める直近のメッセージ数
+const avatarChatPromptWindow = 30
+
+// avatarChatTurn 1往復分のチャット処理で、応答生成前に確定する状態
+type avatarChatTurn struct {
+	userID           string
+	avatarID         string
+	avatar           *models.Avatar
+	avatarOwnerUser  models.User
+	chatHistory      []adapter.AvatarChatMessage
+	memory           *models.AvatarChatMemory
+	visibleUserInfos []*models.UserInfo
+	lockedUserInfos  []*models.UserInfo
+}
+
 func (s *AvatarChatService) SendMessage(ctx context.Context, userID string, avatarID string, content string) (*SendMessageResult, error) {
-	var avatarChatAdapter adapter.AvatarChatAdapter
-	var avatarAdapter adapter.AvatarAdapter
-	var userAdapter adapter.UserAdapter
-	var userInfoAdapter adapter.UserInfoAdapter
-	var missionAdapter adapter.MissionAdapter
-	var matchingAdapter adapter.MatchingAdapter
-	var llmAdapter adapter.LLMAdapter
-	var notificationAdapter adapter.NotificationAdapter
-
-	if err := s.container.Invoke(func(
-		aca adapter.AvatarChatAdapter,
-		aa adapter.AvatarAdapter,
-		ua adapter.UserAdapter,
-		uia adapter.UserInfoAdapter,
-		ma adapter.MissionAdapter,
-		mta adapter.MatchingAdapter,
-		la adapter.LLMAdapter,
-		na adapter.NotificationAdapter,
-	) error {
-		avatarChatAdapter = aca
-		avatarAdapter = aa
-		userAdapter = ua
-		userInfoAdapter = uia
-		missionAdapter = ma
-		matchingAdapter = mta
-		llmAdapter = la
-		notificationAdapter = na
-		return nil
-	}); err != nil {
+	turn, err := s.beginTurn(ctx, userID, avatarID, content)
+	if err != nil {
 		return nil, utils.WrapError(err)
 	}
 
-	avatar, err := avatarAdapter.GetByID(avatarID)
+	llmResponse, err := s.generateAvatarResponse(ctx, turn.avatar, turn.avatarOwnerUser, turn.visibleUserInfos, turn.lockedUserInfos, turn.memory, turn.chatHistory, s.llmAdapter)
 	if err != nil {
 		return nil, utils.WrapError(err)
 	}
 
-	avatarOwnerUser, err := userAdapter.GetByID(avatar.UserID)
+	return s.completeTurn(ctx, turn, llmResponse.Message, llmResponse.PointChange, llmResponse.Reason)
+}
+
+// SendMessageStream アバターの返信を生成しながら onChunk に渡す。
+// ポイントは返信の全文が揃ってから別途評価し、保存まで終えた結果を返す
+func (s *AvatarChatService) SendMessageStream(ctx context.Context, userID string, avatarID string, content string, onChunk func(chunk string) error) (*SendMessageResult, error) {
+	turn, err := s.beginTurn(ctx, userID, avatarID, content)
 	if err != nil {
 		return nil, utils.WrapError(err)
 	}
 
-	avatarOwnerUserInfos, err := userInfoAdapter.GetByUserID(avatar.UserID)
+	message, err := s.streamAvatarMessage(ctx, turn, onChunk)
 	if err != nil {
 		return nil, utils.WrapError(err)
 	}
 
-	relation, err := avatarAdapter.GetUserAvatarRelation(userID, avatarID)
+	evaluation, err := s.evaluateAvatarReply(ctx, turn, message)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			relation = models.UserAvatarRelation{
-				ID:            utils.GenerateULID(),
-				UserID:        userID,
-				AvatarID:      avatarID,
-				MatchingPoint: 0,
-			}
-			if err := avatarAdapter.CreateUserAvatarRelation(relation); err != nil {
-				return nil, utils.WrapError(err)
-			}
-		} else {
-			return nil, utils.WrapError(err)
-		}
+		return nil, utils.WrapError(err)
 	}
 
-	existingMatching, _ := matchingAdapter.GetMatchingByUsers(userID, avatar.UserID)
-	isAlreadyMatched := existingMatching != nil
+	return s.completeTurn(ctx, turn, message, evaluation.PointChange, evaluation.Reason)
+}
+
+// beginTurn 必要な情報を集め、ユーザーのメッセージを保存する
+func (s *AvatarChatService) beginTurn(ctx context.Context, userID string, avatarID string, content string) (*avatarChatTurn, error) {
+	turn := &avatarChatTurn{userID: userID, avatarID: avatarID}
+
+	avatar, err := s.avatarAdapter.GetByID(avatarID)
+	if err != nil {
+		return nil, utils.WrapError(err)
+	}
+	turn.avatar = avatar
+
+	turn.avatarOwnerUser, err = s.userAdapter.GetByID(avatar.UserID)
+	if err != nil {
+		return nil, utils.WrapError(err)
+	}
+
+	avatarOwnerUserInfos, err := s.userInfoAdapter.GetByUserID(avatar.UserID)
+	if err != nil {
+		return nil, utils.WrapError(err)
+	}
 
 	userMessage := adapter.AvatarChatMessage{
 		ID:         utils.GenerateULID(),
@@ -120,117 +193,94 @@ func (s *AvatarChatService) SendMessage(ctx context.Context, userID string, avat
 		CreatedAt:  time.Now(),
 	}
 
-	if err := avatarChatAdapter.CreateAvatarChatMessage(ctx, userID, avatarID, userMessage); err != nil {
+	if err := s.avatarChatAdapter.CreateAvatarChatMessage(ctx, userID, avatarID, userMessage); err != nil {
+		return nil, utils.WrapError(err)
+	}
+
+	turn.chatHistory, _, err = s.avatarChatAdapter.GetAvatarChatMessages(ctx, userID, avatarID, adapter.ChatPage{Limit: avatarChatPromptWindow})
+	if err != nil {
 		return nil, utils.WrapError(err)
 	}
 
-	chatHistory, err := avatarChatAdapter.GetAvatarChatMessages(ctx, userID, avatarID)
+	// 直近の履歴より前の内容は要約と事実のリストで補う
+	turn.memory, err = s.avatarChatMemoryAdapter.Get(userID, avatarID)
 	if err != nil {
 		return nil, utils.WrapError(err)
 	}
 
-	llmResponse, err := s.generateAvatarResponse(avatarOwnerUser, avatarOwnerUserInfos, chatHistory, llmAdapter)
+	turn.visibleUserInfos, turn.lockedUserInfos, err = s.partitionUserInfosByUnlock(userID, avatar.UserID, avatarOwnerUserInfos, s.missionAdapter)
 	if err != nil {
 		return nil, utils.WrapError(err)
 	}
 
+	return turn, nil
+}
+
+// completeTurn アバターの返信を保存し、ポイント・マッチング・ミッションを更新する
+func (s *AvatarChatService) completeTurn(ctx context.Context, turn *avatarChatTurn, message string, pointChange int, reason string) (*SendMessageResult, error) {
+	userID := turn.userID
+	avatar := turn.avatar
+
 	avatarResponse := adapter.AvatarChatMessage{
 		ID:         utils.GenerateULID(),
 		SenderType: models.SenderTypeAvatarAI,
-		Message:    llmResponse.Message,
+		Message:    message,
 		CreatedAt:  time.Now(),
 	}
 
-	if err := avatarChatAdapter.CreateAvatarChatMessage(ctx, userID, avatarID, avatarResponse); err != nil {
+	if err := s.avatarChatAdapter.CreateAvatarChatMessage(ctx, userID, turn.avatarID, avatarResponse); err != nil {
 		return nil, utils.WrapError(err)
 	}
 
-	newMatchingPoint := relation.MatchingPoint + llmResponse.PointChange
-	if newMatchingPoint < 0 {
-		newMatchingPoint = 0
-	}
-	if newMatchingPoint > 100 {
-		newMatchingPoint = 100
-	}
-
-	relation.MatchingPoint = newMatchingPoint
-	if err := avatarAdapter.UpdateUserAvatarRelation(relation); err != nil {
+	// ポイント更新・マッチング成立・ミッション解禁は同時送信でも競合しないよう1トランザクションで行う
+	pointResult, err := s.pointEventAdapter.ApplyPointChange(userID, turn.avatarID, models.PointEvent{
+		Source:    models.PointEventSourceAvatarChat,
+		Delta:     pointChange,
+		Reason:    reason,
+		MessageID: &avatarResponse.ID,
+	})
+	if err != nil {
 		return nil, utils.WrapError(err)
 	}
 
-	isMatched := isAlreadyMatched
-	if !isAlreadyMatched && newMatchingPoint >= 100 {
-		user1ID := userID
-		user2ID := avatar.UserID
-		if user1ID > user2ID {
-			user1ID, user2ID = user2ID, user1ID
-		}
-
-		matching := models.Matching{
-			ID:      utils.GenerateULID(),
-			User1ID: user1ID,
-			User2ID: user2ID,
-		}
-
-		if err := matchingAdapter.CreateMatching(matching); err != nil {
-			log.Printf("Error creating matching: %v", err)
-		} else {
-			isMatched = true
-
-			senderUser, err := userAdapter.GetByID(userID)
-			if err != nil {
-				log.Printf("Error getting sender user for notification: %v", err)
-			} else {
-				now := time.Now()
-
-				notificationForSender := models.Notification{
-					ID:        utils.GenerateULID(),
-					UserID:    userID,
-					Title:     "マッチング成立",
-					Message:   fmt.Sprintf("%sさんとマッチングしました！", avatarOwnerUser.DisplayName),
-					CreatedAt: now,
-				}
-				if err := notificationAdapter.CreateNotification(ctx, userID, notificationForSender); err != nil {
-					log.Printf("Error creating notification for sender: %v", err)
-				}
-
-				notificationForOwner := models.Notification{
-					ID:        utils.GenerateULID(),
-					UserID:    avatar.UserID,
-					Title:     "マッチング成立",
-					Message:   fmt.Sprintf("%sさんとマッチングしました！", senderUser.DisplayName),
-					CreatedAt: now,
-				}
-				if err := notificationAdapter.CreateNotification(ctx, avatar.UserID, notificationForOwner); err != nil {
-					log.Printf("Error creating notification for avatar owner: %v", err)
-				}
-			}
-		}
+	if pointResult.NewMatching != nil {
+		notifyNewMatching(ctx, s.notifier, s.userAdapter, pointResult.NewMatching, userID, avatar.UserID)
 	}
-
-	unlockedMissions, err := s.checkAndUnlockMissions(userID, avatar.UserID, newMatchingPoint, missionAdapter, userInfoAdapter)
-	if err != nil {
-		log.Printf("Error checking missions: %v", err)
+	notifyUnlockedMissions(ctx, s.notifier, s.userAdapter, pointResult.UnlockedMissions, userID, avatar)
+	// 返信のたびに送ると多すぎるので、持ち主には会話の最初の返信だけ知らせる
+	if len(turn.chatHistory) == 1 && userID != avatar.UserID {
+		notifyAvatarActivity(ctx, s.notifier, s.userAdapter, avatar, userID)
 	}
 
+	unlockedMissions := s.describeUnlockedMissions(pointResult.UnlockedMissions, s.userInfoAdapter)
+
+	scheduleAvatarChatSummary(s.jobAdapter, turn, avatarResponse.ID)
+
 	return &SendMessageResult{
 		AvatarResponse:   avatarResponse,
-		MatchingPoint:    newMatchingPoint,
-		PointChange:      llmResponse.PointChange,
-		IsMatched:        isMatched,
+		MatchingPoint:    pointResult.Relation.MatchingPoint,
+		PointChange:      pointResult.Event.Delta,
+		IsMatched:        pointResult.IsMatched,
 		UnlockedMissions: unlockedMissions,
 	}, nil
 }
 
-func (s *AvatarChatService) generateAvatarResponse(
+// buildAvatarPersonaPrompt 出力形式を除いた、アバターとして返信するための共通プロンプト
+func buildAvatarPersonaPrompt(
+	avatar *models.Avatar,
 	avatarOwnerUser models.User,
-	avatarOwnerUserInfos []*models.UserInfo,
+	visibleUserInfos []*models.UserInfo,
+	lockedUserInfos []*models.UserInfo,
+	memory *models.AvatarChatMemory,
 	chatHistory []adapter.AvatarChatMessage,
-	llmAdapter adapter.LLMAdapter,
-) (*LLMChatResponse, error) {
-	userInfoStr := ""
-	for _, info := range avatarOwnerUserInfos {
-		userInfoStr += fmt.Sprintf("- %s: %s\n", info.Key, info.Value)
+) string {
+	// 未解禁項目は値を渡さず、項目名だけを伝えてはぐらかさせる
+	lockedInfoStr := ""
+	for _, info := range lockedUserInfos {
+		lockedInfoStr += fmt.Sprintf("- %s\n", info.Key)
+	}
+	if lockedInfoStr == "" {
+		lockedInfoStr = "（なし）\n"
 	}
 
 	chatHistoryStr := ""
@@ -247,7 +297,7 @@ func (s *AvatarChatService) generateAvatarResponse(
 		chatHistoryStr += fmt.Sprintf("%s: 「%s」\n", sender, msg.Message)
 	}
 
-	prompt := fmt.Sprintf(`
+	return fmt.Sprintf(`
 # 命令
 あなたは「%s」という名前のユーザーの分身AIです。
 以下のユーザー情報を参考にして、相手からのメッセージに対して自然な会話を行ってください。
@@ -257,102 +307,320 @@ func (s *AvatarChatService) generateAvatarResponse(
 性別: %s
 自己紹介: %s
 
-# 詳細情報
+%s
+# まだ相手に公開していない項目
+以下の項目は、相手がミッションを達成するまで秘密です。
+あなたも内容を知らないものとして扱い、推測や作り話もしないでください。
+相手から質問された場合は「もう少し仲良くなったら教えるね」のように、やんわりとはぐらかしてください。
 %s
 
-# これまでの会話履歴
+%s# 直近の会話履歴
 %s
+`, avatarOwnerUser.DisplayName, avatarOwnerUser.DisplayName, avatarOwnerUser.Gender, avatarOwnerUser.Bio,
+		buildAvatarPersonaSection(avatar, visibleUserInfos, lockedUserInfos), lockedInfoStr,
+		buildAvatarChatMemorySection(memory), chatHistoryStr)
+}
+
+// buildAvatarPersonaSection オンボーディングで生成したペルソナがあればそれを、
+// 無い（ペルソナ生成前に作られた）アバターはUserInfoの一覧を人格の材料にする
+func buildAvatarPersonaSection(avatar *models.Avatar, visibleUserInfos []*models.UserInfo, lockedUserInfos []*models.UserInfo) string {
+	if avatar == nil || strings.TrimSpace(avatar.Prompt) == "" {
+		userInfoStr := ""
+		for _, info := range visibleUserInfos {
+			userInfoStr += fmt.Sprintf("- %s: %s\n", info.Key, info.Value)
+		}
+		return fmt.Sprintf("# 詳細情報\n%s\n", userInfoStr)
+	}
+
+	// ペルソナはミッション設定前に作られるため、後から非公開になった値が含まれていれば伏せる
+	section := fmt.Sprintf("# あなたの人格\n%s\n\n", redactLockedUserInfo(avatar.Prompt, lockedUserInfos))
+
+	var traits models.PersonalityTraits
+	if err := json.Unmarshal([]byte(avatar.PersonalityTraits), &traits); err == nil {
+		traitsStr := ""
+		if traits.SpeakingStyle != "" {
+			traitsStr += fmt.Sprintf("- 話し方: %s\n", traits.SpeakingStyle)
+		}
+		if len(traits.Interests) > 0 {
+			traitsStr += fmt.Sprintf("- 興味関心: %s\n", strings.Join(traits.Interests, "、"))
+		}
+		if len(traits.DatingValues) > 0 {
+			traitsStr += fmt.Sprintf("- 恋愛で大切にしていること: %s\n", strings.Join(traits.DatingValues, "、"))
+		}
+		if len(traits.TopicsToAvoid) > 0 {
+			traitsStr += fmt.Sprintf("- 避けたい話題: %s\n", strings.Join(traits.TopicsToAvoid, "、"))
+		}
+		if traitsStr != "" {
+			section += fmt.Sprintf("# 性格特性\n%s\n", redactLockedUserInfo(traitsStr, lockedUserInfos))
+		}
+	}
+
+	// 個別の事実はペルソナに含めていないため、ミッションで解禁された項目だけは具体的に伝える
+	unlockedInfoStr := ""
+	for _, info := range visibleUserInfos {
+		if info.IsMissionReward {
+			unlockedInfoStr += fmt.Sprintf("- %s: %s\n", info.Key, info.Value)
+		}
+	}
+	if unlockedInfoStr != "" {
+		section += fmt.Sprintf("# 相手に公開済みの情報\n%s\n", unlockedInfoStr)
+	}
+	return section
+}
 
+// streamAvatarMessage JSONではなく返信本文だけを生成させ、届いた順に onChunk へ渡す
+func (s *AvatarChatService) streamAvatarMessage(ctx context.Context, turn *avatarChatTurn, onChunk func(chunk string) error) (string, error) {
+	prompt := buildAvatarPersonaPrompt(turn.avatar, turn.avatarOwnerUser, turn.visibleUserInfos, turn.lockedUserInfos, turn.memory, turn.chatHistory) + `
 # 出力形式
-以下のJSON形式で出力してください。他の文字は一切出力しないでください。
-- message: 相手へのメッセージ（2〜3文程度、日本語）
-- point_change: 会話の質に基づくポイント変化（-10〜+10の整数）
-  - 良い会話（共通点発見、質問への丁寧な回答、興味を示す）: +5〜+10
-  - 普通の会話: +1〜+4
-  - 微妙な会話（無関心、失礼な発言）: -5〜-10
-- reason: ポイント変化の理由（簡潔に）
+相手へのメッセージ本文だけを2〜3文程度の日本語で出力してください。
+名前の接頭辞、かぎ括弧、JSON、説明文は出力しないでください。
+`
+
+	req := adapter.NewLLMPromptRequest(adapter.LLM_MODEL_TYPE_GEMINI2_5_FLASH, adapter.LLMTaskAvatarChatStream, prompt)
+
+	// 未公開項目の値を含む断片を送りそうになったら、以降はクライアントへ流さない。
+	// 値が断片の境目で分かれても前半を送らないよう、末尾の「最長の値の文字数-1」文字は次の断片が来るまで手元に残す。
+	// 保存する本文は最後にはぐらかしの定型文へ差し替える
+	holdBackRunes := max(maxLockedUserInfoRunes(turn.lockedUserInfos)-1, 0)
+	streamed := ""
+	pending := []rune{}
+	leaked := false
+	message, err := s.llmAdapter.CreateChatCompletionStream(ctx, req, func(chunk string) error {
+		if leaked {
+			return nil
+		}
+		streamed += chunk
+		if containsLockedUserInfo(streamed, turn.lockedUserInfos) {
+			leaked = true
+			return nil
+		}
+		pending = append(pending, []rune(chunk)...)
+		if len(pending) <= holdBackRunes {
+			return nil
+		}
+		ready := string(pending[:len(pending)-holdBackRunes])
+		pending = append([]rune{}, pending[len(pending)-holdBackRunes:]...)
+		return onChunk(ready)
+	})
+	if err != nil {
+		return "", utils.WrapError(err)
+	}
 
-{
-  "message": "メッセージ内容",
-  "point_change": 5,
-  "reason": "理由"
+	message = strings.TrimSpace(message)
+	if leaked || containsLockedUserInfo(message, turn.lockedUserInfos) {
+		log.Printf("Streamed avatar response for user %s leaked a locked user info; replacing message", turn.avatarOwnerUser.ID)
+		message = lockedInfoDeflectionMessage
+	} else if len(pending) > 0 {
+		// 返信が確定し、残りが値の一部でないことが分かったので送る
+		if err := onChunk(string(pending)); err != nil {
+			return "", utils.WrapError(err)
+		}
+	}
+	if message == "" {
+		return "", utils.WrapError(fmt.Errorf("avatar stream returned an empty message"))
+	}
+
+	return message, nil
+}
+
+// avatarReplyEvaluation ストリーミングした返信に対するポイント評価の構造化出力
+type avatarReplyEvaluation struct {
+	PointChange int    `json:"point_change"`
+	Reason      string `json:"reason"`
 }
-`, avatarOwnerUser.DisplayName, avatarOwnerUser.DisplayName, avatarOwnerUser.Gender, avatarOwnerUser.Bio, userInfoStr, chatHistoryStr)
-
-	contents := []*genai.Content{
-		{
-			Role: "user",
-			Parts: []*genai.Part{
-				{
-					Text: prompt,
-				},
+
+func (e *avatarReplyEvaluation) LLMSchema() *adapter.LLMSchema {
+	return &adapter.LLMSchema{
+		Type: adapter.LLMSchemaTypeObject,
+		Properties: map[string]*adapter.LLMSchema{
+			"point_change": {
+				Type:        adapter.LLMSchemaTypeInteger,
+				Description: "会話の質に基づくポイント変化",
+				Minimum:     adapter.LLMSchemaBound(MinAvatarChatPointChange),
+				Maximum:     adapter.LLMSchemaBound(MaxAvatarChatPointChange),
+			},
+			"reason": {
+				Type:        adapter.LLMSchemaTypeString,
+				Description: "ポイント変化の理由（簡潔に）",
 			},
 		},
+		Required: []string{"point_change", "reason"},
 	}
+}
 
-	resp, err := llmAdapter.CreateChatCompletion(contents, adapter.LLM_MODEL_TYPE_GEMINI2_5_FLASH)
-	if err != nil {
-		return nil, utils.WrapError(err)
+// evaluateAvatarReply 返信が確定した後に、この往復の会話の質を採点する
+func (s *AvatarChatService) evaluateAvatarReply(ctx context.Context, turn *avatarChatTurn, reply string) (*avatarReplyEvaluation, error) {
+	chatHistoryStr := ""
+	for _, msg := range turn.chatHistory {
+		sender := "相手"
+		if msg.SenderType == models.SenderTypeAvatarAI {
+			sender = turn.avatarOwnerUser.DisplayName
+		}
+		chatHistoryStr += fmt.Sprintf("%s: 「%s」\n", sender, msg.Message)
 	}
+	chatHistoryStr += fmt.Sprintf("%s: 「%s」\n", turn.avatarOwnerUser.DisplayName, reply)
 
-	resp = strings.TrimSpace(resp)
-	resp = strings.TrimPrefix(resp, "```json")
-	resp = strings.TrimPrefix(resp, "```")
-	resp = strings.TrimSuffix(resp, "```")
-	resp = strings.TrimSpace(resp)
+	prompt := fmt.Sprintf(`
+# 命令
+マッチングアプリで、相手が「%s」の分身AIと会話しています。
+最後の相手のメッセージと、それに対する返信のやり取りについて、会話の質を評価してください。
 
-	var llmResponse LLMChatResponse
-	if err := json.Unmarshal([]byte(resp), &llmResponse); err != nil {
-		log.Printf("Failed to parse LLM response as JSON: %v, response: %s", err, resp)
-		return &LLMChatResponse{
-			Message:     resp,
-			PointChange: 5,
-			Reason:      "デフォルトポイント",
-		}, nil
+# 会話履歴
+%s
+
+# 出力形式
+以下のJSON形式で出力してください。他の文字は一切出力しないでください。
+- point_change: 会話の質に基づくポイント変化（-10〜+10の整数）
+  - 良い会話（共通点発見、質問への丁寧な回答、興味を示す）: +5〜+10
+  - 普通の会話: +1〜+4
+  - 微妙な会話（無関心、失礼な発言）: -5〜-10
+- reason: ポイント変化の理由（簡潔に）
+`, turn.avatarOwnerUser.DisplayName, chatHistoryStr)
+
+	req := adapter.NewLLMPromptRequest(adapter.LLM_MODEL_TYPE_GEMINI2_5_FLASH, adapter.LLMTaskAvatarChatEvaluation, prompt)
+
+	var evaluation avatarReplyEvaluation
+	if err := s.llmAdapter.CreateStructuredCompletion(ctx, req, &evaluation); err != nil {
+		return nil, utils.WrapError(err)
 	}
 
-	return &llmResponse, nil
+	return &evaluation, nil
 }
 
-func (s *AvatarChatService) checkAndUnlockMissions(
+// partitionUserInfosByUnlock アバター所有者のUserInfoを、チャット相手に公開済みのものと
+// ミッション未解禁のものに分ける。ProfileServiceで「???」表示になる項目をアバターに喋らせないため。
+func (s *AvatarChatService) partitionUserInfosByUnlock(
 	userID string,
 	avatarOwnerUserID string,
-	currentMatchingPoint int,
+	userInfos []*models.UserInfo,
 	missionAdapter adapter.MissionAdapter,
-	userInfoAdapter adapter.UserInfoAdapter,
-) ([]UnlockedMissionInfo, error) {
+) ([]*models.UserInfo, []*models.UserInfo, error) {
 	missions, err := missionAdapter.GetMissionsByOwnerUserID(avatarOwnerUserID)
 	if err != nil {
-		return nil, utils.WrapError(err)
+		return nil, nil, utils.WrapError(err)
 	}
 
-	var unlockedMissions []UnlockedMissionInfo
+	unlocks, err := missionAdapter.GetMissionUnlocksByUserID(userID)
+	if err != nil {
+		return nil, nil, utils.WrapError(err)
+	}
+
+	unlockedMissionIDs := make(map[string]bool, len(unlocks))
+	for _, unlock := range unlocks {
+		unlockedMissionIDs[unlock.MissionID] = true
+	}
 
+	unlockedUserInfoIDs := make(map[string]bool)
 	for _, mission := range missions {
-		if mission.ThresholdPointCondition == nil {
-			continue
+		if unlockedMissionIDs[mission.ID] {
+			unlockedUserInfoIDs[mission.UserInfoID] = true
 		}
+	}
 
-		if currentMatchingPoint < *mission.ThresholdPointCondition {
+	visible := make([]*models.UserInfo, 0, len(userInfos))
+	var locked []*models.UserInfo
+	for _, info := range userInfos {
+		if info.IsMissionReward && !unlockedUserInfoIDs[info.ID] {
+			locked = append(locked, info)
 			continue
 		}
+		visible = append(visible, info)
+	}
 
-		existingUnlock, _ := missionAdapter.GetMissionUnlock(mission.ID, userID)
-		if existingUnlock != nil {
+	return visible, locked, nil
+}
+
+// lockedInfoDeflectionMessage 未解禁の情報が応答に含まれてしまった場合に差し替えるメッセージ
+const lockedInfoDeflectionMessage = "それはもう少し仲良くなってから教えるね！よかったら、あなたのことももっと聞かせて？"
+
+// redactLockedUserInfo 未解禁項目の値を伏せ字にする。対象はcontainsLockedUserInfoと同じ
+func redactLockedUserInfo(text string, lockedUserInfos []*models.UserInfo) string {
+	for _, info := range lockedUserInfos {
+		value := strings.TrimSpace(info.Value)
+		if utf8.RuneCountInString(value) < 2 {
 			continue
 		}
+		text = strings.ReplaceAll(text, value, "（秘密）")
+	}
+	return text
+}
 
-		missionUnlock := models.MissionUnlock{
-			ID:             utils.GenerateULID(),
-			MissionID:      mission.ID,
-			UnlockedUserID: userID,
+// maxLockedUserInfoRunes containsLockedUserInfo が検出する値のうち、最も長いものの文字数を返す
+func maxLockedUserInfoRunes(lockedUserInfos []*models.UserInfo) int {
+	maxRunes := 0
+	for _, info := range lockedUserInfos {
+		runes := utf8.RuneCountInString(strings.TrimSpace(info.Value))
+		if runes >= 2 && runes > maxRunes {
+			maxRunes = runes
 		}
+	}
+	return maxRunes
+}
 
-		if err := missionAdapter.CreateMissionUnlock(missionUnlock); err != nil {
-			log.Printf("Error creating mission unlock: %v", err)
+// containsLockedUserInfo 応答に未解禁項目の値がそのまま含まれていないか確認する
+// 1文字の値は通常の会話と偶然一致しやすいため対象外にする
+func containsLockedUserInfo(message string, lockedUserInfos []*models.UserInfo) bool {
+	for _, info := range lockedUserInfos {
+		value := strings.TrimSpace(info.Value)
+		if utf8.RuneCountInString(value) < 2 {
 			continue
 		}
+		if strings.Contains(message, value) {
+			return true
+		}
+	}
+	return false
+}
 
+func (s *AvatarChatService) generateAvatarResponse(
+	ctx context.Context,
+	avatar *models.Avatar,
+	avatarOwnerUser models.User,
+	visibleUserInfos []*models.UserInfo,
+	lockedUserInfos []*models.UserInfo,
+	memory *models.AvatarChatMemory,
+	chatHistory []adapter.AvatarChatMessage,
+	llmAdapter adapter.LLMAdapter,
+) (*LLMChatResponse, error) {
+	prompt := buildAvatarPersonaPrompt(avatar, avatarOwnerUser, visibleUserInfos, lockedUserInfos, memory, chatHistory) + `
+# 出力形式
+以下のJSON形式で出力してください。他の文字は一切出力しないでください。
+- message: 相手へのメッセージ（2〜3文程度、日本語）
+- point_change: 会話の質に基づくポイント変化（-10〜+10の整数）
+  - 良い会話（共通点発見、質問への丁寧な回答、興味を示す）: +5〜+10
+  - 普通の会話: +1〜+4
+  - 微妙な会話（無関心、失礼な発言）: -5〜-10
+- reason: ポイント変化の理由（簡潔に）
+
+{
+  "message": "メッセージ内容",
+  "point_change": 5,
+  "reason": "理由"
+}
+`
+
+	req := adapter.NewLLMPromptRequest(adapter.LLM_MODEL_TYPE_GEMINI2_5_FLASH, adapter.LLMTaskAvatarChat, prompt)
+
+	var llmResponse LLMChatResponse
+	if err := llmAdapter.CreateStructuredCompletion(ctx, req, &llmResponse); err != nil {
+		return nil, utils.WrapError(err)
+	}
+
+	if containsLockedUserInfo(llmResponse.Message, lockedUserInfos) {
+		log.Printf("Avatar response for user %s leaked a locked user info; replacing message", avatarOwnerUser.ID)
+		llmResponse.Message = lockedInfoDeflectionMessage
+	}
+
+	return &llmResponse, nil
+}
+
+// describeUnlockedMissions 解禁されたミッションの報酬になっている項目の中身を取得する
+func (s *AvatarChatService) describeUnlockedMissions(
+	missions []models.Mission,
+	userInfoAdapter adapter.UserInfoAdapter,
+) []UnlockedMissionInfo {
+	var unlockedMissions []UnlockedMissionInfo
+	for _, mission := range missions {
 		userInfo, err := userInfoAdapter.GetByID(mission.UserInfoID)
 		if err != nil {
 			log.Printf("Error getting user info for mission: %v", err)
@@ -366,98 +634,99 @@ func (s *AvatarChatService) checkAndUnlockMissions(
 			Value:      userInfo.Value,
 		})
 	}
-
-	return unlockedMissions, nil
+	return unlockedMissions
 }
 
-func (s *AvatarChatService) GetMessages(ctx context.Context, userID string, avatarID string) ([]adapter.AvatarChatMessage, int, bool, error) {
-	var avatarChatAdapter adapter.AvatarChatAdapter
-	var avatarAdapter adapter.AvatarAdapter
-	var matchingAdapter adapter.MatchingAdapter
-
-	if err := s.container.Invoke(func(
-		aca adapter.AvatarChatAdapter,
-		aa adapter.AvatarAdapter,
-		mta adapter.MatchingAdapter,
-	) error {
-		avatarChatAdapter = aca
-		avatarAdapter = aa
-		matchingAdapter = mta
-		return nil
-	}); err != nil {
-		return nil, 0, false, utils.WrapError(err)
-	}
-
-	messages, err := avatarChatAdapter.GetAvatarChatMessages(ctx, userID, avatarID)
+func (s *AvatarChatService) GetMessages(ctx context.Context, userID string, avatarID string, page adapter.ChatPage) ([]adapter.AvatarChatMessage, string, int, bool, error) {
+	messages, nextCursor, err := s.avatarChatAdapter.GetAvatarChatMessages(ctx, userID, avatarID, page)
 	if err != nil {
-		return nil, 0, false, utils.WrapError(err)
+		return nil, "", 0, false, utils.WrapError(err)
 	}
 
-	avatar, err := avatarAdapter.GetByID(avatarID)
+	avatar, err := s.avatarAdapter.GetByID(avatarID)
 	if err != nil {
-		return nil, 0, false, utils.WrapError(err)
+		return nil, "", 0, false, utils.WrapError(err)
 	}
 
-	relation, err := avatarAdapter.GetUserAvatarRelation(userID, avatarID)
+	relation, err := s.avatarAdapter.GetUserAvatarRelation(userID, avatarID)
 	matchingPoint := 0
 	if err == nil {
 		matchingPoint = relation.MatchingPoint
 	}
 
-	existingMatching, _ := matchingAdapter.GetMatchingByUsers(userID, avatar.UserID)
+	existingMatching, _ := s.matchingAdapter.GetMatchingByUsers(userID, avatar.UserID)
 	isMatched := existingMatching != nil
 
-	return messages, matchingPoint, isMatched, nil
+	return messages, nextCursor, matchingPoint, isMatched, nil
 }
 
-func (s *AvatarChatService) GetStatus(ctx context.Context, userID string, avatarID string) (int, bool, []UnlockedMissionInfo, error) {
-	var avatarAdapter adapter.AvatarAdapter
-	var matchingAdapter adapter.MatchingAdapter
-	var missionAdapter adapter.MissionAdapter
-	var userInfoAdapter adapter.UserInfoAdapter
-
-	if err := s.container.Invoke(func(
-		aa adapter.AvatarAdapter,
-		mta adapter.MatchingAdapter,
-		ma adapter.MissionAdapter,
-		uia adapter.UserInfoAdapter,
-	) error {
-		avatarAdapter = aa
-		matchingAdapter = mta
-		missionAdapter = ma
-		userInfoAdapter = uia
-		return nil
-	}); err != nil {
-		return 0, false, nil, utils.WrapError(err)
+// MarkAsRead アバターとのチャットをmessageIDまで既読にする。messageIDが空なら最新のメッセージまで
+func (s *AvatarChatService) MarkAsRead(ctx context.Context, userID string, avatarID string, messageID string) (*response.MarkChatAsReadResponse, error) {
+	if _, err := s.avatarAdapter.GetByID(avatarID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrorRecordNotFound
+		}
+		return nil, utils.WrapError(err)
+	}
+
+	latest, _, err := s.avatarChatAdapter.GetAvatarChatMessages(ctx, userID, avatarID, adapter.ChatPage{Limit: 1})
+	if err != nil {
+		return nil, utils.WrapError(err)
+	}
+	latestID := ""
+	if len(latest) > 0 {
+		latestID = latest[len(latest)-1].ID
+	}
+
+	cursor, err := markChatAsRead(s.chatReadCursorAdapter, userID, models.ChatKindAvatar, avatarID, messageID, latestID)
+	if err != nil {
+		return nil, err
 	}
 
-	avatar, err := avatarAdapter.GetByID(avatarID)
+	unreadCount, err := s.avatarChatAdapter.CountAvatarMessages(ctx, userID, avatarID, cursor.LastReadMessageID)
+	if err != nil {
+		return nil, utils.WrapError(err)
+	}
+
+	// 数えてから書き込むまでに届いたメッセージの分はずれるが、次に既読にしたときに数え直される
+	if err := s.chatSummaryAdapter.SetUnreadCount(ctx, userID, models.ChatKindAvatar, avatarID, unreadCount); err != nil {
+		return nil, utils.WrapError(err)
+	}
+
+	return &response.MarkChatAsReadResponse{
+		LastReadMessageID: cursor.LastReadMessageID,
+		UnreadCount:       unreadCount,
+	}, nil
+}
+
+func (s *AvatarChatService) GetStatus(ctx context.Context, userID string, avatarID string) (int, bool, []UnlockedMissionInfo, error) {
+	avatar, err := s.avatarAdapter.GetByID(avatarID)
 	if err != nil {
 		return 0, false, nil, utils.WrapError(err)
 	}
 
-	relation, err := avatarAdapter.GetUserAvatarRelation(userID, avatarID)
+	relation, err := s.avatarAdapter.GetUserAvatarRelation(userID, avatarID)
 	matchingPoint := 0
 	if err == nil {
 		matchingPoint = relation.MatchingPoint
 	}
 
-	existingMatching, _ := matchingAdapter.GetMatchingByUsers(userID, avatar.UserID)
+	existingMatching, _ := s.matchingAdapter.GetMatchingByUsers(userID, avatar.UserID)
 	isMatched := existingMatching != nil
 
-	missions, err := missionAdapter.GetMissionsByOwnerUserID(avatar.UserID)
+	missions, err := s.missionAdapter.GetMissionsByOwnerUserID(avatar.UserID)
 	if err != nil {
 		return matchingPoint, isMatched, nil, nil
 	}
 
 	var unlockedMissions []UnlockedMissionInfo
 	for _, mission := range missions {
-		existingUnlock, _ := missionAdapter.GetMissionUnlock(mission.ID, userID)
+		existingUnlock, _ := s.missionAdapter.GetMissionUnlock(mission.ID, userID)
 		if existingUnlock == nil {
 			continue
 		}
 
-		userInfo, err := userInfoAdapter.GetByID(mission.UserInfoID)
+		userInfo, err := s.userInfoAdapter.GetByID(mission.UserInfoID)
 		if err != nil {
 			continue
 		}
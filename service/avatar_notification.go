@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/utils"
+)
+
+// notifyUnlockedMissions 新たに解禁されたミッションを、解禁したユーザーに1件ずつ通知する。
+// 解禁自体はコミット済みなので、通知に失敗してもログだけ残して続行する
+func notifyUnlockedMissions(
+	ctx context.Context,
+	notifier Notifier,
+	userAdapter adapter.UserAdapter,
+	missions []models.Mission,
+	userID string,
+	avatar *models.Avatar,
+) {
+	if len(missions) == 0 {
+		return
+	}
+
+	owner, err := userAdapter.GetByID(avatar.UserID)
+	if err != nil {
+		log.Printf("Error getting user %s for mission unlock notification: %v", avatar.UserID, err)
+		return
+	}
+
+	now := time.Now()
+	for _, mission := range missions {
+		if err := notifier.Notify(ctx, models.Notification{
+			ID:       utils.GenerateULID(),
+			UserID:   userID,
+			Type:     models.NotificationTypeMissionUnlock,
+			Title:    "ミッション解放",
+			Message:  fmt.Sprintf("%sさんのプロフィールが新しく見られるようになりました", owner.DisplayName),
+			DeepLink: "/users/" + avatar.UserID + "/profile",
+			Payload: map[string]string{
+				"mission_id":    mission.ID,
+				"user_info_id":  mission.UserInfoID,
+				"avatar_id":     avatar.ID,
+				"owner_user_id": avatar.UserID,
+			},
+			CreatedAt: now,
+		}); err != nil {
+			log.Printf("Error creating mission unlock notification for user %s: %v", userID, err)
+		}
+	}
+}
+
+// notifyAvatarActivity アバターが新しい相手と会話を始めたことを、アバターの持ち主に通知する。
+// 返信は保存済みなので、通知に失敗してもログだけ残して続行する
+func notifyAvatarActivity(
+	ctx context.Context,
+	notifier Notifier,
+	userAdapter adapter.UserAdapter,
+	avatar *models.Avatar,
+	userID string,
+) {
+	user, err := userAdapter.GetByID(userID)
+	if err != nil {
+		log.Printf("Error getting user %s for avatar activity notification: %v", userID, err)
+		return
+	}
+
+	if err := notifier.Notify(ctx, models.Notification{
+		ID:       utils.GenerateULID(),
+		UserID:   avatar.UserID,
+		Type:     models.NotificationTypeAvatarActivity,
+		Title:    "アバターの会話",
+		Message:  fmt.Sprintf("あなたのアバターが%sさんと会話を始めました", user.DisplayName),
+		DeepLink: "/users/" + userID + "/profile",
+		Payload: map[string]string{
+			"avatar_id":       avatar.ID,
+			"partner_user_id": userID,
+		},
+		CreatedAt: time.Now(),
+	}); err != nil {
+		log.Printf("Error creating avatar activity notification for user %s: %v", avatar.UserID, err)
+	}
+}
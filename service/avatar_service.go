@@ -7,43 +7,71 @@ import (
 	"github.com/hackathon-20260110/api/models"
 	"github.com/hackathon-20260110/api/response"
 	"github.com/hackathon-20260110/api/utils"
-	"go.uber.org/dig"
 	"gorm.io/gorm"
 )
 
 type AvatarService struct {
-	container *dig.Container
+	avatarAdapter     adapter.AvatarAdapter
+	userAdapter       adapter.UserAdapter
+	pointEventAdapter adapter.PointEventAdapter
 }
 
-func NewAvatarService(container *dig.Container) *AvatarService {
-	return &AvatarService{container: container}
+func NewAvatarService(
+	avatarAdapter adapter.AvatarAdapter,
+	userAdapter adapter.UserAdapter,
+	pointEventAdapter adapter.PointEventAdapter,
+) *AvatarService {
+	return &AvatarService{
+		avatarAdapter:     avatarAdapter,
+		userAdapter:       userAdapter,
+		pointEventAdapter: pointEventAdapter,
+	}
 }
 
 func (s *AvatarService) GetAvatarList(userID string) ([]response.AvatarWithRelation, error) {
-	var avatarAdapter adapter.AvatarAdapter
-	var userAdapter adapter.UserAdapter
-	if err := s.container.Invoke(func(aa adapter.AvatarAdapter, ua adapter.UserAdapter) error {
-		avatarAdapter = aa
-		userAdapter = ua
-		return nil
-	}); err != nil {
+	currentUser, err := s.userAdapter.GetByID(userID)
+	if err != nil {
 		return nil, err
 	}
 
-	currentUser, err := userAdapter.GetByID(userID)
+	avatars, err := s.avatarAdapter.GetAvatarsByOwnerGenders(userID, currentUser.InterestedGenders())
 	if err != nil {
 		return nil, err
 	}
 
-	avatars, err := avatarAdapter.GetOppositeGenderAvatars(currentUser.Gender)
+	// 持ち主と自分との関係は、アバターの数に関わらずまとめて取得する
+	ownerIDs := make([]string, 0, len(avatars))
+	avatarIDs := make([]string, 0, len(avatars))
+	for _, avatar := range avatars {
+		ownerIDs = append(ownerIDs, avatar.UserID)
+		avatarIDs = append(avatarIDs, avatar.ID)
+	}
+	owners, err := s.userAdapter.GetByIDs(ownerIDs)
+	if err != nil {
+		return nil, err
+	}
+	ownersByID := make(map[string]models.User, len(owners))
+	for _, owner := range owners {
+		ownersByID[owner.ID] = owner
+	}
+	relations, err := s.avatarAdapter.GetUserAvatarRelationsByAvatarIDs(userID, avatarIDs)
 	if err != nil {
 		return nil, err
 	}
+	relationsByAvatarID := make(map[string]models.UserAvatarRelation, len(relations))
+	for _, relation := range relations {
+		relationsByAvatarID[relation.AvatarID] = relation
+	}
 
+	now := time.Now()
 	var result []response.AvatarWithRelation
 	for _, avatar := range avatars {
-		owner, err := userAdapter.GetByID(avatar.UserID)
-		if err != nil {
+		owner, ok := ownersByID[avatar.UserID]
+		if !ok {
+			continue
+		}
+		// 相手の希望にも自分が合う場合だけ表示する
+		if !models.MutuallyAdmits(&currentUser, &owner, now) {
 			continue
 		}
 
@@ -58,12 +86,11 @@ func (s *AvatarService) GetAvatarList(userID string) ([]response.AvatarWithRelat
 				UpdatedAt:         avatar.UpdatedAt,
 			},
 			UserDisplayName: owner.DisplayName,
-			UserAge:         utils.CalculateAge(owner.BirthDate, time.Now()),
+			UserAge:         utils.CalculateAge(owner.BirthDate, now),
 			UserBio:         owner.Bio,
 		}
 
-		relation, err := avatarAdapter.GetUserAvatarRelation(userID, avatar.ID)
-		if err == nil {
+		if relation, ok := relationsByAvatarID[avatar.ID]; ok {
 			avatarResponse.Relation = &response.UserAvatarRelation{
 				ID:            relation.ID,
 				UserID:        relation.UserID,
@@ -80,43 +107,45 @@ func (s *AvatarService) GetAvatarList(userID string) ([]response.AvatarWithRelat
 	return result, nil
 }
 
-func (s *AvatarService) UpdateMatchingPoint(userID, avatarID string, points int) (*response.UpdateMatchingPointResponse, error) {
-	var avatarAdapter adapter.AvatarAdapter
-	if err := s.container.Invoke(func(aa adapter.AvatarAdapter) error {
-		avatarAdapter = aa
-		return nil
-	}); err != nil {
+// GetPointHistory 指定アバターとのマッチングポイントが動いた履歴を新しい順に返す
+func (s *AvatarService) GetPointHistory(userID, avatarID string, limit, offset int) (*response.PointHistoryResponse, error) {
+	if _, err := s.avatarAdapter.GetByID(avatarID); err != nil {
 		return nil, err
 	}
 
-	relation, err := avatarAdapter.GetUserAvatarRelation(userID, avatarID)
+	result := &response.PointHistoryResponse{
+		Events: []response.PointEvent{},
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	relation, err := s.avatarAdapter.GetUserAvatarRelation(userID, avatarID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			relation = models.UserAvatarRelation{
-				ID:            utils.GenerateULID(),
-				UserID:        userID,
-				AvatarID:      avatarID,
-				MatchingPoint: points,
-			}
-			if err := avatarAdapter.CreateUserAvatarRelation(relation); err != nil {
-				return nil, err
-			}
-		} else {
-			return nil, err
-		}
-	} else {
-		relation.MatchingPoint += points
-		if err := avatarAdapter.UpdateUserAvatarRelation(relation); err != nil {
-			return nil, err
+			// まだ一度も会話していない
+			return result, nil
 		}
+		return nil, err
+	}
+	result.MatchingPoint = relation.MatchingPoint
+
+	events, total, err := s.pointEventAdapter.GetPointEventsByRelationID(relation.ID, limit, offset)
+	if err != nil {
+		return nil, err
 	}
 
-	return &response.UpdateMatchingPointResponse{
-		ID:            relation.ID,
-		UserID:        relation.UserID,
-		AvatarID:      relation.AvatarID,
-		MatchingPoint: relation.MatchingPoint,
-		CreatedAt:     relation.CreatedAt,
-		UpdatedAt:     relation.UpdatedAt,
-	}, nil
+	result.Total = int(total)
+	for _, event := range events {
+		result.Events = append(result.Events, response.PointEvent{
+			ID:                 event.ID,
+			Source:             string(event.Source),
+			Delta:              event.Delta,
+			MatchingPointAfter: event.MatchingPointAfter,
+			Reason:             event.Reason,
+			MessageID:          event.MessageID,
+			CreatedAt:          event.CreatedAt,
+		})
+	}
+
+	return result, nil
 }
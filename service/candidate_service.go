@@ -0,0 +1,205 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/response"
+	"github.com/hackathon-20260110/api/utils"
+)
+
+var ErrInvalidCandidateQuery = errors.New("invalid candidate query")
+
+const (
+	// 相性スコア（0〜100）のうち、プロフィールの重なりとマッチングポイントが占める割合
+	candidateInfoOverlapWeight   = 60
+	candidateMatchingPointWeight = 40
+
+	// MaxCandidatePool 相性スコアを計算する候補の上限。ユーザー全員を読み込まないよう、
+	// 条件に合う中から自分とのマッチングポイントの高い順にこの人数だけ取り出して並べ替える。
+	// 超えた場合はレスポンスのTruncatedで知らせる
+	MaxCandidatePool = 200
+)
+
+// CandidateQuery 相手候補一覧の取得条件。MinAge/MaxAgeが0なら制限しない
+type CandidateQuery struct {
+	Limit   int
+	Offset  int
+	MinAge  int
+	MaxAge  int
+	Genders []string
+}
+
+type CandidateService struct {
+	userAdapter      adapter.UserAdapter
+	userInfoAdapter  adapter.UserInfoAdapter
+	candidateAdapter adapter.CandidateAdapter
+}
+
+func NewCandidateService(
+	userAdapter adapter.UserAdapter,
+	userInfoAdapter adapter.UserInfoAdapter,
+	candidateAdapter adapter.CandidateAdapter,
+) *CandidateService {
+	return &CandidateService{
+		userAdapter:      userAdapter,
+		userInfoAdapter:  userInfoAdapter,
+		candidateAdapter: candidateAdapter,
+	}
+}
+
+// GetCandidates 条件に合う相手候補を相性スコアの高い順に返す。対象は MaxCandidatePool 人まで（超えたらTruncated）
+func (s *CandidateService) GetCandidates(userID string, query CandidateQuery) (*response.GetPartnersResponse, error) {
+	if query.MinAge < 0 || query.MaxAge < 0 || (query.MaxAge > 0 && query.MinAge > query.MaxAge) {
+		return nil, ErrInvalidCandidateQuery
+	}
+
+	currentUser, err := s.userAdapter.GetByID(userID)
+	if err != nil {
+		return nil, utils.WrapError(err)
+	}
+
+	// 条件の指定が無い項目は自分の希望で絞り込む。指定があっても希望の外側は下でMutuallyAdmitsに落とされる
+	genders := query.Genders
+	if len(genders) == 0 {
+		genders = currentUser.InterestedGenders()
+	}
+	minAge := query.MinAge
+	if minAge == 0 {
+		minAge = currentUser.PreferredMinAge
+	}
+	maxAge := query.MaxAge
+	if maxAge == 0 {
+		maxAge = currentUser.PreferredMaxAge
+	}
+
+	now := time.Now()
+	filter := adapter.CandidateFilter{
+		UserID:    userID,
+		Genders:   genders,
+		Viewer:    currentUser,
+		ViewerAge: utils.CalculateAge(currentUser.BirthDate, now),
+		// 上限を超えたか分かるよう1人多く取る
+		Limit: MaxCandidatePool + 1,
+	}
+	if minAge > 0 {
+		bornOnOrBefore := now.AddDate(-minAge, 0, 0)
+		filter.BornOnOrBefore = &bornOnOrBefore
+	}
+	if maxAge > 0 {
+		bornAfter := now.AddDate(-(maxAge + 1), 0, 0)
+		filter.BornAfter = &bornAfter
+	}
+
+	candidates, err := s.candidateAdapter.FindCandidates(filter)
+	if err != nil {
+		return nil, utils.WrapError(err)
+	}
+	truncated := len(candidates) > MaxCandidatePool
+	if truncated {
+		candidates = candidates[:MaxCandidatePool]
+	}
+
+	myInfos, err := s.userInfoAdapter.GetByUserID(userID)
+	if err != nil {
+		return nil, utils.WrapError(err)
+	}
+	myTokens := userInfoTokens(myInfos)
+
+	partners := make([]response.Partner, 0, len(candidates))
+	for _, candidate := range candidates {
+		// 絞り込みはSQLで済んでいるが、年齢の境界など細かな判定はモデルの定義に合わせる
+		if !models.MutuallyAdmits(&currentUser, &candidate.User, now) {
+			continue
+		}
+		partners = append(partners, response.Partner{
+			ID:                 candidate.User.ID,
+			AvatarID:           candidate.Avatar.ID,
+			DisplayName:        candidate.User.DisplayName,
+			Age:                utils.CalculateAge(candidate.User.BirthDate, now),
+			Gender:             candidate.User.Gender,
+			ProfileImageURL:    candidate.User.ProfileImageURL,
+			Bio:                candidate.User.Bio,
+			MatchingPoint:      candidate.MatchingPoint,
+			CompatibilityScore: calculateCompatibilityScore(myTokens, userInfoTokens(candidate.UserInfos), candidate.MatchingPoint),
+		})
+	}
+
+	sort.SliceStable(partners, func(i, j int) bool {
+		if partners[i].CompatibilityScore != partners[j].CompatibilityScore {
+			return partners[i].CompatibilityScore > partners[j].CompatibilityScore
+		}
+		if partners[i].MatchingPoint != partners[j].MatchingPoint {
+			return partners[i].MatchingPoint > partners[j].MatchingPoint
+		}
+		return partners[i].ID < partners[j].ID
+	})
+
+	total := len(partners)
+	start := query.Offset
+	if start > total {
+		start = total
+	}
+	end := start + query.Limit
+	if end > total {
+		end = total
+	}
+
+	return &response.GetPartnersResponse{
+		Partners:  partners[start:end],
+		Total:     total,
+		Limit:     query.Limit,
+		Offset:    query.Offset,
+		Truncated: truncated,
+	}, nil
+}
+
+// calculateCompatibilityScore プロフィールの重なり（Jaccard係数）と、
+// 相手のアバターとの会話で貯めたマッチングポイントから0〜100の相性スコアを出す
+func calculateCompatibilityScore(myTokens, theirTokens map[string]bool, matchingPoint int) int {
+	overlap := 0.0
+	if len(myTokens) > 0 && len(theirTokens) > 0 {
+		intersection := 0
+		for token := range myTokens {
+			if theirTokens[token] {
+				intersection++
+			}
+		}
+		union := len(myTokens) + len(theirTokens) - intersection
+		overlap = float64(intersection) / float64(union)
+	}
+
+	score := overlap*candidateInfoOverlapWeight +
+		float64(matchingPoint)/float64(models.MaxMatchingPoint)*candidateMatchingPointWeight
+	return int(score + 0.5)
+}
+
+// userInfoTokens 「映画鑑賞、読書」のような値を語に分け、表記ゆれを抑えて集合にする
+func userInfoTokens(userInfos []*models.UserInfo) map[string]bool {
+	tokens := make(map[string]bool)
+	for _, info := range userInfos {
+		if info.InfoType != models.UserInfoTypeText {
+			continue
+		}
+		for _, token := range strings.FieldsFunc(info.Value, isUserInfoSeparator) {
+			token = strings.ToLower(strings.TrimSpace(token))
+			if token != "" {
+				tokens[token] = true
+			}
+		}
+	}
+	return tokens
+}
+
+func isUserInfoSeparator(r rune) bool {
+	switch r {
+	case '、', '，', ',', '・', '/', '／', '。':
+		return true
+	}
+	return unicode.IsSpace(r)
+}
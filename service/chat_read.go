@@ -0,0 +1,60 @@
+package service
+
+import (
+	"log"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/utils"
+)
+
+// markChatAsRead 既読位置をrequestedIDまで進める。requestedIDが空か最新のメッセージより先なら最新のメッセージまでにする。
+// メッセージが1件も無ければ（latestIDが空なら）何もしない
+func markChatAsRead(
+	chatReadCursorAdapter adapter.ChatReadCursorAdapter,
+	userID string,
+	kind models.ChatKind,
+	partnerID string,
+	requestedID string,
+	latestID string,
+) (*models.ChatReadCursor, error) {
+	cursor, err := chatReadCursorAdapter.Get(userID, kind, partnerID)
+	if err != nil {
+		return nil, utils.WrapError(err)
+	}
+	if latestID == "" {
+		return cursor, nil
+	}
+
+	target := requestedID
+	if target == "" || target > latestID {
+		target = latestID
+	}
+	if cursor.IsRead(target) {
+		return cursor, nil
+	}
+
+	cursor.LastReadMessageID = target
+	if err := chatReadCursorAdapter.Advance(cursor); err != nil {
+		return nil, utils.WrapError(err)
+	}
+	return cursor, nil
+}
+
+// advanceReadCursorOnSend 自分が送ったメッセージまでを既読にする。返信した時点で相手のメッセージは読んでいるため。
+// メッセージは保存済みなので、失敗してもログだけ残す
+func advanceReadCursorOnSend(chatReadCursorAdapter adapter.ChatReadCursorAdapter, userID string, kind models.ChatKind, partnerID string, messageID string) {
+	if err := chatReadCursorAdapter.Advance(&models.ChatReadCursor{
+		UserID:            userID,
+		ChatKind:          kind,
+		PartnerID:         partnerID,
+		LastReadMessageID: messageID,
+	}); err != nil {
+		log.Printf("Error advancing read cursor of user %s for %s chat %s: %v", userID, kind, partnerID, err)
+	}
+}
+
+// chatKey GetChatsで既読位置や集計を引くためのキー
+func chatKey(kind models.ChatKind, partnerID string) string {
+	return string(kind) + ":" + partnerID
+}
@@ -2,21 +2,49 @@ package service
 
 import (
 	"context"
+	"errors"
+	"log"
 	"sort"
 	"time"
 
 	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/models"
 	"github.com/hackathon-20260110/api/response"
 	"github.com/hackathon-20260110/api/utils"
-	"go.uber.org/dig"
+	"gorm.io/gorm"
 )
 
 type ChatService struct {
-	container *dig.Container
+	userChatAdapter       adapter.UserChatAdapter
+	avatarChatAdapter     adapter.AvatarChatAdapter
+	avatarAdapter         adapter.AvatarAdapter
+	userAdapter           adapter.UserAdapter
+	matchingAdapter       adapter.MatchingAdapter
+	chatReadCursorAdapter adapter.ChatReadCursorAdapter
+	chatSummaryAdapter    adapter.ChatSummaryAdapter
+	pointEventAdapter     adapter.PointEventAdapter
 }
 
-func NewChatService(container *dig.Container) *ChatService {
-	return &ChatService{container: container}
+func NewChatService(
+	userChatAdapter adapter.UserChatAdapter,
+	avatarChatAdapter adapter.AvatarChatAdapter,
+	avatarAdapter adapter.AvatarAdapter,
+	userAdapter adapter.UserAdapter,
+	matchingAdapter adapter.MatchingAdapter,
+	chatReadCursorAdapter adapter.ChatReadCursorAdapter,
+	chatSummaryAdapter adapter.ChatSummaryAdapter,
+	pointEventAdapter adapter.PointEventAdapter,
+) *ChatService {
+	return &ChatService{
+		userChatAdapter:       userChatAdapter,
+		avatarChatAdapter:     avatarChatAdapter,
+		avatarAdapter:         avatarAdapter,
+		userAdapter:           userAdapter,
+		matchingAdapter:       matchingAdapter,
+		chatReadCursorAdapter: chatReadCursorAdapter,
+		chatSummaryAdapter:    chatSummaryAdapter,
+		pointEventAdapter:     pointEventAdapter,
+	}
 }
 
 type chatListItem struct {
@@ -26,130 +54,108 @@ type chatListItem struct {
 	PartnerImageURL string
 	LastMessage     string
 	LastMessageAt   time.Time
+	UnreadCount     int
 	MatchingScore   int
 	IsMatched       bool
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 }
 
+// GetChats マッチ済みのユーザーとアバターとのチャットを、最新のメッセージが新しい順に返す。
+// 一覧の件数に関わらずクエリの数が一定になるよう、相手の情報と最新のメッセージはまとめて取得する
 func (s *ChatService) GetChats(ctx context.Context, userID string) (*response.GetChatsResponse, error) {
-	var userChatAdapter adapter.UserChatAdapter
-	var avatarChatAdapter adapter.AvatarChatAdapter
-	var avatarAdapter adapter.AvatarAdapter
-	var userAdapter adapter.UserAdapter
-	var matchingAdapter adapter.MatchingAdapter
-
-	if err := s.container.Invoke(func(
-		uca adapter.UserChatAdapter,
-		aca adapter.AvatarChatAdapter,
-		aa adapter.AvatarAdapter,
-		ua adapter.UserAdapter,
-		ma adapter.MatchingAdapter,
-	) error {
-		userChatAdapter = uca
-		avatarChatAdapter = aca
-		avatarAdapter = aa
-		userAdapter = ua
-		matchingAdapter = ma
-		return nil
-	}); err != nil {
+	matchings, err := s.matchingAdapter.GetMatchingsByUserID(userID)
+	if err != nil {
 		return nil, utils.WrapError(err)
 	}
-
-	var allChats []chatListItem
-
-	// 1. マッチ済みユーザーとのチャットを取得
-	matchedChats, err := s.getMatchedUserChats(ctx, userID, matchingAdapter, userChatAdapter, userAdapter)
+	relations, err := s.avatarAdapter.GetUserAvatarRelationsByUserID(userID)
 	if err != nil {
 		return nil, utils.WrapError(err)
 	}
-	allChats = append(allChats, matchedChats...)
 
-	// マッチ済みユーザーIDのセットを作成（重複防止用）
-	matchedUserIDs := make(map[string]bool)
-	for _, chat := range matchedChats {
-		matchedUserIDs[chat.PartnerID] = true
+	avatarIDs := make([]string, 0, len(relations))
+	for _, relation := range relations {
+		avatarIDs = append(avatarIDs, relation.AvatarID)
 	}
-
-	// 2. アバターとのチャットを取得（マッチ済みは除外）
-	avatarChats, err := s.getAvatarChats(ctx, userID, avatarChatAdapter, avatarAdapter, userAdapter, matchedUserIDs)
+	avatars, err := s.avatarAdapter.GetByIDs(avatarIDs)
 	if err != nil {
 		return nil, utils.WrapError(err)
 	}
-	allChats = append(allChats, avatarChats...)
-
-	// 3. LastMessageAtで降順ソート
-	sort.Slice(allChats, func(i, j int) bool {
-		return allChats[i].LastMessageAt.After(allChats[j].LastMessageAt)
-	})
-
-	// 4. レスポンス形式に変換
-	chats := make([]response.Chat, len(allChats))
-	for i, chat := range allChats {
-		chats[i] = response.Chat{
-			ID:              chat.ID,
-			UserID:          userID,
-			PartnerID:       chat.PartnerID,
-			PartnerName:     chat.PartnerName,
-			PartnerImageURL: chat.PartnerImageURL,
-			LastMessage:     chat.LastMessage,
-			LastMessageAt:   chat.LastMessageAt.Format(time.RFC3339),
-			UnreadCount:     0,
-			MatchingScore:   chat.MatchingScore,
-			IsMatched:       chat.IsMatched,
-			CreatedAt:       chat.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:       chat.UpdatedAt.Format(time.RFC3339),
-		}
+	avatarsByID := make(map[string]models.Avatar, len(avatars))
+	for _, avatar := range avatars {
+		avatarsByID[avatar.ID] = avatar
 	}
 
-	return &response.GetChatsResponse{
-		Chats: chats,
-		Total: len(chats),
-	}, nil
-}
+	// マッチした相手とアバターの持ち主をまとめて取得する
+	userIDs := make([]string, 0, len(matchings)+len(avatars))
+	for _, matching := range matchings {
+		userIDs = append(userIDs, matching.PartnerID(userID))
+	}
+	for _, avatar := range avatars {
+		userIDs = append(userIDs, avatar.UserID)
+	}
+	users, err := s.userAdapter.GetByIDs(userIDs)
+	if err != nil {
+		return nil, utils.WrapError(err)
+	}
+	usersByID := make(map[string]models.User, len(users))
+	for _, user := range users {
+		usersByID[user.ID] = user
+	}
 
-func (s *ChatService) getMatchedUserChats(
-	ctx context.Context,
-	userID string,
-	matchingAdapter adapter.MatchingAdapter,
-	userChatAdapter adapter.UserChatAdapter,
-	userAdapter adapter.UserAdapter,
-) ([]chatListItem, error) {
-	matchings, err := matchingAdapter.GetMatchingsByUserID(userID)
+	summaries, err := s.chatSummaryAdapter.GetChatSummaries(ctx, userID)
 	if err != nil {
-		return nil, err
+		return nil, utils.WrapError(err)
+	}
+	summariesByKey := make(map[string]adapter.ChatSummary, len(summaries))
+	for _, summary := range summaries {
+		summariesByKey[chatKey(summary.ChatKind, summary.PartnerID)] = summary
 	}
 
-	var chats []chatListItem
-	for _, matching := range matchings {
-		partnerID := matching.User1ID
-		if matching.User1ID == userID {
-			partnerID = matching.User2ID
+	rebuilder := &chatSummaryRebuilder{
+		userID:                userID,
+		userChatAdapter:       s.userChatAdapter,
+		avatarChatAdapter:     s.avatarChatAdapter,
+		chatReadCursorAdapter: s.chatReadCursorAdapter,
+		chatSummaryAdapter:    s.chatSummaryAdapter,
+	}
+	getSummary := func(kind models.ChatKind, partnerID string) adapter.ChatSummary {
+		if summary, ok := summariesByKey[chatKey(kind, partnerID)]; ok {
+			return summary
 		}
-
-		partner, err := userAdapter.GetByID(partnerID)
+		summary, err := rebuilder.rebuild(ctx, kind, partnerID)
 		if err != nil {
+			log.Printf("Error rebuilding %s chat summary of user %s with %s: %v", kind, userID, partnerID, err)
+		}
+		return summary
+	}
+
+	var allChats []chatListItem
+
+	// 1. マッチ済みユーザーとのチャット
+	matchedUserIDs := make(map[string]bool)
+	for _, matching := range matchings {
+		partnerID := matching.PartnerID(userID)
+		partner, ok := usersByID[partnerID]
+		if !ok {
 			continue
 		}
+		matchedUserIDs[partnerID] = true
 
-		messages, err := userChatAdapter.GetUserChatMessages(ctx, userID, partnerID)
-		var lastMessage string
-		var lastMessageAt time.Time
-		if err == nil && len(messages) > 0 {
-			lastMsg := messages[len(messages)-1]
-			lastMessage = lastMsg.Message
-			lastMessageAt = lastMsg.CreatedAt
-		} else {
+		summary := getSummary(models.ChatKindUser, partnerID)
+		lastMessageAt := summary.LastMessageAt
+		if summary.LastMessageID == "" {
 			lastMessageAt = matching.CreatedAt
 		}
 
-		chats = append(chats, chatListItem{
+		allChats = append(allChats, chatListItem{
 			ID:              matching.ID,
 			PartnerID:       partnerID,
 			PartnerName:     partner.DisplayName,
 			PartnerImageURL: partner.ProfileImageURL,
-			LastMessage:     lastMessage,
+			LastMessage:     summary.LastMessage,
 			LastMessageAt:   lastMessageAt,
+			UnreadCount:     summary.UnreadCount,
 			MatchingScore:   100,
 			IsMatched:       true,
 			CreatedAt:       matching.CreatedAt,
@@ -157,57 +163,31 @@ func (s *ChatService) getMatchedUserChats(
 		})
 	}
 
-	return chats, nil
-}
-
-func (s *ChatService) getAvatarChats(
-	ctx context.Context,
-	userID string,
-	avatarChatAdapter adapter.AvatarChatAdapter,
-	avatarAdapter adapter.AvatarAdapter,
-	userAdapter adapter.UserAdapter,
-	matchedUserIDs map[string]bool,
-) ([]chatListItem, error) {
-	relations, err := avatarAdapter.GetUserAvatarRelationsByUserID(userID)
-	if err != nil {
-		return nil, err
-	}
-
-	var chats []chatListItem
+	// 2. アバターとのチャット（マッチ済みのユーザーのアバターは除外）
 	for _, relation := range relations {
-		avatar, err := avatarAdapter.GetByID(relation.AvatarID)
-		if err != nil {
+		avatar, ok := avatarsByID[relation.AvatarID]
+		if !ok || matchedUserIDs[avatar.UserID] {
 			continue
 		}
-
-		// 既にマッチ済みのユーザーのアバターはスキップ
-		if matchedUserIDs[avatar.UserID] {
-			continue
-		}
-
-		owner, err := userAdapter.GetByID(avatar.UserID)
-		if err != nil {
+		owner, ok := usersByID[avatar.UserID]
+		if !ok {
 			continue
 		}
 
-		messages, err := avatarChatAdapter.GetAvatarChatMessages(ctx, userID, relation.AvatarID)
-		var lastMessage string
-		var lastMessageAt time.Time
-		if err == nil && len(messages) > 0 {
-			lastMsg := messages[len(messages)-1]
-			lastMessage = lastMsg.Message
-			lastMessageAt = lastMsg.CreatedAt
-		} else {
+		summary := getSummary(models.ChatKindAvatar, relation.AvatarID)
+		lastMessageAt := summary.LastMessageAt
+		if summary.LastMessageID == "" {
 			lastMessageAt = relation.CreatedAt
 		}
 
-		chats = append(chats, chatListItem{
+		allChats = append(allChats, chatListItem{
 			ID:              relation.ID,
 			PartnerID:       relation.AvatarID,
 			PartnerName:     owner.DisplayName,
 			PartnerImageURL: avatar.AvatarIconURL,
-			LastMessage:     lastMessage,
+			LastMessage:     summary.LastMessage,
 			LastMessageAt:   lastMessageAt,
+			UnreadCount:     summary.UnreadCount,
 			MatchingScore:   relation.MatchingPoint,
 			IsMatched:       false,
 			CreatedAt:       relation.CreatedAt,
@@ -215,5 +195,67 @@ func (s *ChatService) getAvatarChats(
 		})
 	}
 
-	return chats, nil
+	// 3. LastMessageAtで降順ソート
+	sort.Slice(allChats, func(i, j int) bool {
+		return allChats[i].LastMessageAt.After(allChats[j].LastMessageAt)
+	})
+
+	// 4. レスポンス形式に変換
+	chats := make([]response.Chat, len(allChats))
+	for i, chat := range allChats {
+		chats[i] = response.Chat{
+			ID:              chat.ID,
+			UserID:          userID,
+			PartnerID:       chat.PartnerID,
+			PartnerName:     chat.PartnerName,
+			PartnerImageURL: chat.PartnerImageURL,
+			LastMessage:     chat.LastMessage,
+			LastMessageAt:   chat.LastMessageAt.Format(time.RFC3339),
+			UnreadCount:     chat.UnreadCount,
+			MatchingScore:   chat.MatchingScore,
+			IsMatched:       chat.IsMatched,
+			CreatedAt:       chat.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:       chat.UpdatedAt.Format(time.RFC3339),
+		}
+	}
+
+	return &response.GetChatsResponse{
+		Chats: chats,
+		Total: len(chats),
+	}, nil
+}
+
+// GetChatScore 相手のアバターとの現在のマッチングポイントと、発生源ごとの内訳をポイント履歴から集計する
+func (s *ChatService) GetChatScore(userID string, partnerUserID string) (*response.ChatScore, error) {
+	partnerAvatar, err := s.avatarAdapter.GetByUserID(partnerUserID)
+	if err != nil {
+		return nil, utils.WrapError(err)
+	}
+
+	score := &response.ChatScore{
+		ChatID:          partnerUserID,
+		MaxScore:        models.MaxMatchingPoint,
+		ScoreBreakdown:  map[string]int{},
+		UnlockThreshold: models.MaxMatchingPoint,
+	}
+
+	relation, err := s.avatarAdapter.GetUserAvatarRelation(userID, partnerAvatar.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return score, nil
+		}
+		return nil, utils.WrapError(err)
+	}
+
+	sums, err := s.pointEventAdapter.SumDeltaBySource(relation.ID)
+	if err != nil {
+		return nil, utils.WrapError(err)
+	}
+	for source, total := range sums {
+		score.ScoreBreakdown[string(source)] = total
+	}
+
+	score.CurrentScore = relation.MatchingPoint
+	score.UpdatedAt = relation.UpdatedAt.Format(time.RFC3339)
+	return score, nil
 }
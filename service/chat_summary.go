@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/utils"
+)
+
+// chatSummaryRebuilder チャット一覧の集計を導入する前の会話について、履歴から集計を作り直す。
+// 作り直すのは一覧で最初に見つかったときの1回だけで、以降はメッセージを書き込むたびに更新される
+type chatSummaryRebuilder struct {
+	userID                string
+	userChatAdapter       adapter.UserChatAdapter
+	avatarChatAdapter     adapter.AvatarChatAdapter
+	chatReadCursorAdapter adapter.ChatReadCursorAdapter
+	chatSummaryAdapter    adapter.ChatSummaryAdapter
+
+	// readCursors 作り直しが必要になったときに初めて読み込む
+	readCursors map[string]models.ChatReadCursor
+}
+
+// rebuild 最新のメッセージと未読件数を数えて集計を作る。失敗してもそこまでに分かった集計を返す
+func (r *chatSummaryRebuilder) rebuild(ctx context.Context, kind models.ChatKind, partnerID string) (adapter.ChatSummary, error) {
+	summary := adapter.ChatSummary{ChatKind: kind, PartnerID: partnerID}
+
+	if r.readCursors == nil {
+		cursors, err := r.chatReadCursorAdapter.GetByUserID(r.userID)
+		if err != nil {
+			return summary, utils.WrapError(err)
+		}
+		r.readCursors = make(map[string]models.ChatReadCursor, len(cursors))
+		for _, cursor := range cursors {
+			r.readCursors[chatKey(cursor.ChatKind, cursor.PartnerID)] = cursor
+		}
+	}
+	cursor := r.readCursors[chatKey(kind, partnerID)]
+
+	switch kind {
+	case models.ChatKindUser:
+		messages, _, err := r.userChatAdapter.GetUserChatMessages(ctx, r.userID, partnerID, adapter.ChatPage{Limit: 1})
+		if err != nil {
+			return summary, utils.WrapError(err)
+		}
+		if len(messages) > 0 {
+			last := messages[len(messages)-1]
+			summary.LastMessageID = last.ID
+			summary.LastMessage = last.Message
+			summary.LastMessageAt = last.CreatedAt
+			if !cursor.IsRead(last.ID) {
+				if summary.UnreadCount, err = r.userChatAdapter.CountMessagesFrom(ctx, r.userID, partnerID, partnerID, cursor.LastReadMessageID); err != nil {
+					return summary, utils.WrapError(err)
+				}
+			}
+		}
+	case models.ChatKindAvatar:
+		messages, _, err := r.avatarChatAdapter.GetAvatarChatMessages(ctx, r.userID, partnerID, adapter.ChatPage{Limit: 1})
+		if err != nil {
+			return summary, utils.WrapError(err)
+		}
+		if len(messages) > 0 {
+			last := messages[len(messages)-1]
+			summary.LastMessageID = last.ID
+			summary.LastMessage = last.Message
+			summary.LastMessageAt = last.CreatedAt
+			if !cursor.IsRead(last.ID) {
+				if summary.UnreadCount, err = r.avatarChatAdapter.CountAvatarMessages(ctx, r.userID, partnerID, cursor.LastReadMessageID); err != nil {
+					return summary, utils.WrapError(err)
+				}
+			}
+		}
+	}
+
+	// メッセージが無い会話も空の集計を作り、次からは作り直さない
+	if err := r.chatSummaryAdapter.CreateChatSummary(ctx, r.userID, summary); err != nil {
+		return summary, utils.WrapError(err)
+	}
+	return summary, nil
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/response"
+	"github.com/hackathon-20260110/api/utils"
+)
+
+type DeviceService struct {
+	deviceTokenAdapter adapter.DeviceTokenAdapter
+}
+
+func NewDeviceService(deviceTokenAdapter adapter.DeviceTokenAdapter) *DeviceService {
+	return &DeviceService{deviceTokenAdapter: deviceTokenAdapter}
+}
+
+// RegisterDevice プッシュ通知の送信先として端末を登録する。同じトークンの再登録は上書きになる
+func (s *DeviceService) RegisterDevice(userID string, token string, platform models.DevicePlatform) (*response.Device, error) {
+	deviceToken, err := s.deviceTokenAdapter.Register(userID, token, platform)
+	if err != nil {
+		return nil, utils.WrapError(err)
+	}
+
+	return &response.Device{
+		ID:        deviceToken.ID,
+		Token:     deviceToken.Token,
+		Platform:  string(deviceToken.Platform),
+		CreatedAt: deviceToken.CreatedAt,
+		UpdatedAt: deviceToken.UpdatedAt,
+	}, nil
+}
+
+// UnregisterDevice ログアウト時などに端末をプッシュ通知の送信先から外す
+func (s *DeviceService) UnregisterDevice(userID string, token string) error {
+	if err := s.deviceTokenAdapter.Delete(userID, token); err != nil {
+		return utils.WrapError(err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/utils"
+)
+
+// notifyNewMatching マッチング成立を双方に通知する。
+// マッチング自体はコミット済みなので、通知に失敗してもログだけ残して続行する
+func notifyNewMatching(
+	ctx context.Context,
+	notifier Notifier,
+	userAdapter adapter.UserAdapter,
+	matching *models.Matching,
+	userID string,
+	partnerUserID string,
+) {
+	user, err := userAdapter.GetByID(userID)
+	if err != nil {
+		log.Printf("Error getting user %s for matching notification: %v", userID, err)
+		return
+	}
+	partner, err := userAdapter.GetByID(partnerUserID)
+	if err != nil {
+		log.Printf("Error getting user %s for matching notification: %v", partnerUserID, err)
+		return
+	}
+
+	now := time.Now()
+	notifications := []models.Notification{
+		{
+			ID:        utils.GenerateULID(),
+			UserID:    userID,
+			Type:      models.NotificationTypeMatch,
+			Title:     "マッチング成立",
+			Message:   fmt.Sprintf("%sさんとマッチングしました！", partner.DisplayName),
+			DeepLink:  "/matches/" + matching.ID,
+			Payload:   map[string]string{"matching_id": matching.ID, "partner_user_id": partnerUserID},
+			CreatedAt: now,
+		},
+		{
+			ID:        utils.GenerateULID(),
+			UserID:    partnerUserID,
+			Type:      models.NotificationTypeMatch,
+			Title:     "マッチング成立",
+			Message:   fmt.Sprintf("%sさんとマッチングしました！", user.DisplayName),
+			DeepLink:  "/matches/" + matching.ID,
+			Payload:   map[string]string{"matching_id": matching.ID, "partner_user_id": userID},
+			CreatedAt: now,
+		},
+	}
+	for _, notification := range notifications {
+		if err := notifier.Notify(ctx, notification); err != nil {
+			log.Printf("Error creating matching notification for user %s: %v", notification.UserID, err)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/utils"
+	"gorm.io/gorm"
+)
+
+// Notifier 通知を届ける。NotificationService が実装する
+type Notifier interface {
+	Notify(ctx context.Context, notification models.Notification) error
+}
+
+// notificationDigestPayload JobTypeNotificationDigest のペイロード
+type notificationDigestPayload struct {
+	UserID string `json:"user_id"`
+}
+
+// Notify 通知設定に従って通知を届ける。
+// オフにされた種類は何もしない。それ以外は受信箱（Firestore）に必ず保存し、
+// プッシュ通知はおやすみ時間中なら保留して、明けた後にダイジェストとしてまとめて送る
+func (s *NotificationService) Notify(ctx context.Context, notification models.Notification) error {
+	if notification.Type == "" {
+		notification.Type = models.NotificationTypeSystem
+	}
+
+	setting, err := s.settingAdapter.GetByUserID(notification.UserID)
+	if err != nil {
+		return utils.WrapError(err)
+	}
+	if !setting.IsTypeEnabled(notification.Type) {
+		return nil
+	}
+
+	if err := s.notificationAdapter.CreateNotification(ctx, notification.UserID, notification); err != nil {
+		return utils.WrapError(err)
+	}
+
+	// ここから先はプッシュ通知。受信箱には保存済みなので、失敗してもログだけ残す
+	if quietUntil, quiet := setting.QuietUntil(time.Now()); quiet {
+		if err := s.settingAdapter.AddSuppressedPush(models.SuppressedPush{
+			UserID:         notification.UserID,
+			NotificationID: notification.ID,
+			Type:           notification.Type,
+			Title:          notification.Title,
+		}); err != nil {
+			log.Printf("Error suppressing push notification %s for user %s: %v", notification.ID, notification.UserID, err)
+			return nil
+		}
+		if err := scheduleNotificationDigest(s.jobAdapter, notification.UserID, quietUntil); err != nil {
+			log.Printf("Error scheduling notification digest for user %s: %v", notification.UserID, err)
+		}
+		return nil
+	}
+
+	data := map[string]string{
+		"notification_id": notification.ID,
+		"type":            string(notification.Type),
+		"deep_link":       notification.DeepLink,
+	}
+	for key, value := range notification.Payload {
+		data[key] = value
+	}
+	pushToDevices(ctx, s.deviceTokenAdapter, s.pushSender, notification.UserID, adapter.PushMessage{
+		Title: notification.Title,
+		Body:  notification.Message,
+		Data:  data,
+	})
+	return nil
+}
+
+// SendNotificationDigest おやすみ時間中に止めていたプッシュ通知をまとめて1通で送るジョブのハンドラー。
+// おやすみ時間が延びていた場合は、新しい終了時刻に送り直す
+func (s *NotificationService) SendNotificationDigest(ctx context.Context, job models.Job) error {
+	var payload notificationDigestPayload
+	if err := json.Unmarshal([]byte(job.Payload), &payload); err != nil {
+		return utils.WrapError(err)
+	}
+
+	setting, err := s.settingAdapter.GetByUserID(payload.UserID)
+	if err != nil {
+		return utils.WrapError(err)
+	}
+	if quietUntil, quiet := setting.QuietUntil(time.Now()); quiet {
+		return utils.WrapError(enqueueNotificationDigest(s.jobAdapter, payload.UserID, quietUntil))
+	}
+
+	pushes, err := s.settingAdapter.TakeSuppressedPushes(payload.UserID)
+	if err != nil {
+		return utils.WrapError(err)
+	}
+	if len(pushes) == 0 {
+		return nil
+	}
+
+	body := fmt.Sprintf("「%s」ほか%d件の通知があります", pushes[len(pushes)-1].Title, len(pushes)-1)
+	if len(pushes) == 1 {
+		body = pushes[0].Title
+	}
+	pushToDevices(ctx, s.deviceTokenAdapter, s.pushSender, payload.UserID, adapter.PushMessage{
+		Title: "おやすみ中のお知らせ",
+		Body:  body,
+		Data: map[string]string{
+			"type":      string(models.NotificationTypeSystem),
+			"deep_link": "/notification",
+			"count":     fmt.Sprint(len(pushes)),
+		},
+	})
+	return nil
+}
+
+// scheduleNotificationDigest 実行待ちのダイジェストが無ければ、おやすみ時間の終了時刻に登録する
+func scheduleNotificationDigest(jobAdapter adapter.JobAdapter, userID string, runAt time.Time) error {
+	latest, err := jobAdapter.GetLatestJobBySubject(models.JobTypeNotificationDigest, userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if latest != nil && latest.Status == models.JobStatusQueued {
+		return nil
+	}
+	return enqueueNotificationDigest(jobAdapter, userID, runAt)
+}
+
+func enqueueNotificationDigest(jobAdapter adapter.JobAdapter, userID string, runAt time.Time) error {
+	payload, err := json.Marshal(notificationDigestPayload{UserID: userID})
+	if err != nil {
+		return err
+	}
+	return jobAdapter.Enqueue(&models.Job{
+		Type:      models.JobTypeNotificationDigest,
+		SubjectID: userID,
+		Payload:   string(payload),
+		RunAt:     runAt,
+	})
+}
+
+// pushToDevices ユーザーの全端末にプッシュ通知を送り、FCMが無効と判定したトークンを削除する
+func pushToDevices(ctx context.Context, deviceTokenAdapter adapter.DeviceTokenAdapter, pushSender adapter.PushSender, userID string, message adapter.PushMessage) {
+	deviceTokens, err := deviceTokenAdapter.GetByUserID(userID)
+	if err != nil {
+		log.Printf("Error getting device tokens for user %s: %v", userID, err)
+		return
+	}
+	if len(deviceTokens) == 0 {
+		return
+	}
+
+	tokens := make([]string, 0, len(deviceTokens))
+	for _, deviceToken := range deviceTokens {
+		tokens = append(tokens, deviceToken.Token)
+	}
+
+	invalidTokens, err := pushSender.Send(ctx, tokens, message)
+	if err != nil {
+		log.Printf("Error sending push notification to user %s: %v", userID, err)
+	}
+	if len(invalidTokens) > 0 {
+		if err := deviceTokenAdapter.DeleteByTokens(invalidTokens); err != nil {
+			log.Printf("Error pruning %d invalid device tokens for user %s: %v", len(invalidTokens), userID, err)
+		}
+	}
+}
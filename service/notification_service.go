@@ -4,31 +4,138 @@ import (
 	"context"
 
 	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/requests"
+	"github.com/hackathon-20260110/api/response"
 	"github.com/hackathon-20260110/api/utils"
-	"go.uber.org/dig"
 )
 
 type NotificationService struct {
-	container *dig.Container
+	notificationAdapter adapter.NotificationAdapter
+	settingAdapter      adapter.NotificationSettingAdapter
+	deviceTokenAdapter  adapter.DeviceTokenAdapter
+	pushSender          adapter.PushSender
+	jobAdapter          adapter.JobAdapter
 }
 
-func NewNotificationService(container *dig.Container) *NotificationService {
-	return &NotificationService{container: container}
+func NewNotificationService(
+	notificationAdapter adapter.NotificationAdapter,
+	settingAdapter adapter.NotificationSettingAdapter,
+	deviceTokenAdapter adapter.DeviceTokenAdapter,
+	pushSender adapter.PushSender,
+	jobAdapter adapter.JobAdapter,
+) *NotificationService {
+	return &NotificationService{
+		notificationAdapter: notificationAdapter,
+		settingAdapter:      settingAdapter,
+		deviceTokenAdapter:  deviceTokenAdapter,
+		pushSender:          pushSender,
+		jobAdapter:          jobAdapter,
+	}
 }
 
 func (s *NotificationService) MarkAsRead(ctx context.Context, userID string, notificationID string) error {
-	var notificationAdapter adapter.NotificationAdapter
-
-	if err := s.container.Invoke(func(na adapter.NotificationAdapter) error {
-		notificationAdapter = na
-		return nil
-	}); err != nil {
+	if err := s.notificationAdapter.MarkAsRead(ctx, userID, notificationID); err != nil {
 		return utils.WrapError(err)
 	}
 
-	if err := notificationAdapter.MarkAsRead(ctx, userID, notificationID); err != nil {
+	return nil
+}
+
+// ListNotifications 通知を新しい順にカーソルでページングして返す
+func (s *NotificationService) ListNotifications(ctx context.Context, userID string, cursor string, limit int) (*response.NotificationListResponse, error) {
+	notifications, nextCursor, err := s.notificationAdapter.ListNotifications(ctx, userID, cursor, limit)
+	if err != nil {
+		return nil, utils.WrapError(err)
+	}
+
+	result := &response.NotificationListResponse{
+		Notifications: make([]response.Notification, 0, len(notifications)),
+		NextCursor:    nextCursor,
+		HasMore:       nextCursor != "",
+	}
+	for _, n := range notifications {
+		result.Notifications = append(result.Notifications, response.Notification{
+			ID:        n.ID,
+			Type:      string(n.Type),
+			Title:     n.Title,
+			Message:   n.Message,
+			DeepLink:  n.DeepLink,
+			Payload:   n.Payload,
+			HasRead:   n.HasRead,
+			CreatedAt: n.CreatedAt,
+		})
+	}
+
+	return result, nil
+}
+
+func (s *NotificationService) GetUnreadCount(ctx context.Context, userID string) (int64, error) {
+	count, err := s.notificationAdapter.CountUnread(ctx, userID)
+	if err != nil {
+		return 0, utils.WrapError(err)
+	}
+
+	return count, nil
+}
+
+// MarkAllAsRead 未読の通知をすべて既読にし、更新した件数を返す
+func (s *NotificationService) MarkAllAsRead(ctx context.Context, userID string) (int, error) {
+	updated, err := s.notificationAdapter.MarkAllAsRead(ctx, userID)
+	if err != nil {
+		return updated, utils.WrapError(err)
+	}
+
+	return updated, nil
+}
+
+func (s *NotificationService) DeleteNotification(ctx context.Context, userID string, notificationID string) error {
+	if err := s.notificationAdapter.DeleteNotification(ctx, userID, notificationID); err != nil {
 		return utils.WrapError(err)
 	}
 
 	return nil
 }
+
+// GetSettings 通知設定を返す。未設定なら既定値
+func (s *NotificationService) GetSettings(userID string) (*response.NotificationSettings, error) {
+	setting, err := s.settingAdapter.GetByUserID(userID)
+	if err != nil {
+		return nil, utils.WrapError(err)
+	}
+
+	return newNotificationSettingsResponse(setting), nil
+}
+
+// UpdateSettings 通知設定を丸ごと置き換える
+func (s *NotificationService) UpdateSettings(userID string, args requests.UpdateNotificationSettingsRequest) (*response.NotificationSettings, error) {
+	setting, err := s.settingAdapter.Save(models.NotificationSetting{
+		UserID:                userID,
+		MatchEnabled:          args.MatchEnabled,
+		MissionUnlockEnabled:  args.MissionUnlockEnabled,
+		MessageEnabled:        args.MessageEnabled,
+		AvatarActivityEnabled: args.AvatarActivityEnabled,
+		QuietHoursEnabled:     args.QuietHoursEnabled,
+		QuietHoursStart:       args.QuietHoursStart,
+		QuietHoursEnd:         args.QuietHoursEnd,
+		Timezone:              args.Timezone,
+	})
+	if err != nil {
+		return nil, utils.WrapError(err)
+	}
+
+	return newNotificationSettingsResponse(setting), nil
+}
+
+func newNotificationSettingsResponse(setting *models.NotificationSetting) *response.NotificationSettings {
+	return &response.NotificationSettings{
+		MatchEnabled:          setting.MatchEnabled,
+		MissionUnlockEnabled:  setting.MissionUnlockEnabled,
+		MessageEnabled:        setting.MessageEnabled,
+		AvatarActivityEnabled: setting.AvatarActivityEnabled,
+		QuietHoursEnabled:     setting.QuietHoursEnabled,
+		QuietHoursStart:       setting.QuietHoursStart,
+		QuietHoursEnd:         setting.QuietHoursEnd,
+		Timezone:              setting.Timezone,
+	}
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
@@ -10,33 +11,40 @@ import (
 
 	"github.com/hackathon-20260110/api/adapter"
 	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/response"
 	"github.com/hackathon-20260110/api/utils"
-	"go.uber.org/dig"
-	"google.golang.org/genai"
+	"gorm.io/gorm"
 )
 
 type OnboardingService struct {
-	container *dig.Container
+	onboardingAdapter adapter.OnboardingAdapter
+	llmAdapter        adapter.LLMAdapter
+	userAdapter       adapter.UserAdapter
+	jobAdapter        adapter.JobAdapter
+	avatarAdapter     adapter.AvatarAdapter
+	userInfoAdapter   adapter.UserInfoAdapter
 }
 
-func NewOnboardingService(container *dig.Container) *OnboardingService {
-	return &OnboardingService{container: container}
+func NewOnboardingService(
+	onboardingAdapter adapter.OnboardingAdapter,
+	llmAdapter adapter.LLMAdapter,
+	userAdapter adapter.UserAdapter,
+	jobAdapter adapter.JobAdapter,
+	avatarAdapter adapter.AvatarAdapter,
+	userInfoAdapter adapter.UserInfoAdapter,
+) *OnboardingService {
+	return &OnboardingService{
+		onboardingAdapter: onboardingAdapter,
+		llmAdapter:        llmAdapter,
+		userAdapter:       userAdapter,
+		jobAdapter:        jobAdapter,
+		avatarAdapter:     avatarAdapter,
+		userInfoAdapter:   userInfoAdapter,
+	}
 }
 
 func (s *OnboardingService) StartOnboardingChat(ctx context.Context, userID string) error {
-	var onboardingAdapter adapter.OnboardingAdapter
-	var llmAdapter adapter.LLMAdapter
-	var userAdapter adapter.UserAdapter
-	if err := s.container.Invoke(func(oa adapter.OnboardingAdapter, la adapter.LLMAdapter, ua adapter.UserAdapter) error {
-		onboardingAdapter = oa
-		llmAdapter = la
-		userAdapter = ua
-		return nil
-	}); err != nil {
-		return utils.WrapError(err)
-	}
-
-	u, err := userAdapter.GetByID(userID)
+	u, err := s.userAdapter.GetByID(userID)
 	if err != nil {
 		return utils.WrapError(err)
 	}
@@ -60,26 +68,16 @@ func (s *OnboardingService) StartOnboardingChat(ctx context.Context, userID stri
 		u.DisplayName, u.Gender, u.BirthDate.Format("2006-01-02"), u.Bio,
 	)
 
-	content := []*genai.Content{
-		{
-			Role: "model",
-			Parts: []*genai.Part{
-				{
-					Text: systemPrompt,
-				},
-			},
-		},
-		{
-			Role: "user",
-			Parts: []*genai.Part{
-				{
-					Text: userPrompt,
-				},
-			},
+	req := adapter.LLMRequest{
+		Model: adapter.LLM_MODEL_TYPE_GEMINI2_5_FLASH,
+		Task:  adapter.LLMTaskOnboardingQuestion,
+		Messages: []adapter.LLMMessage{
+			{Role: adapter.LLMRoleSystem, Text: systemPrompt},
+			{Role: adapter.LLMRoleUser, Text: userPrompt},
 		},
 	}
 
-	resp, err := llmAdapter.CreateChatCompletion(content, adapter.LLM_MODEL_TYPE_GEMINI2_5_FLASH)
+	resp, err := s.llmAdapter.CreateChatCompletion(ctx, req)
 	if err != nil {
 		return utils.WrapError(err)
 	}
@@ -91,29 +89,11 @@ func (s *OnboardingService) StartOnboardingChat(ctx context.Context, userID stri
 		CreatedAt:  time.Now(),
 	}
 
-	return onboardingAdapter.CreateOnboardingChat(ctx, userID, chat)
+	return s.onboardingAdapter.CreateOnboardingChat(ctx, userID, chat)
 }
 
 func (s *OnboardingService) SendOnboardingMessage(ctx context.Context, userID string, userMessage string) error {
-	var onboardingAdapter adapter.OnboardingAdapter
-	var userAdapter adapter.UserAdapter
-	var llmAdapter adapter.LLMAdapter
-	if err := s.container.Invoke(func(oa adapter.OnboardingAdapter, ua adapter.UserAdapter, la adapter.LLMAdapter) error {
-		onboardingAdapter = oa
-		userAdapter = ua
-		llmAdapter = la
-		return nil
-	}); err != nil {
-		return utils.WrapError(err)
-	}
-
-	u, err := userAdapter.GetByID(userID)
-	if err != nil {
-		return utils.WrapError(err)
-	}
-
-	chats, err := onboardingAdapter.GetOnboardingChats(ctx, userID)
-	if err != nil {
+	if _, err := s.userAdapter.GetByID(userID); err != nil {
 		return utils.WrapError(err)
 	}
 
@@ -124,25 +104,54 @@ func (s *OnboardingService) SendOnboardingMessage(ctx context.Context, userID st
 		CreatedAt:  time.Now(),
 	}
 
-	if err := onboardingAdapter.CreateOnboardingChat(ctx, userID, userChat); err != nil {
+	if err := s.onboardingAdapter.CreateOnboardingChat(ctx, userID, userChat); err != nil {
 		return utils.WrapError(err)
 	}
 
-	chats = append(chats, userChat)
-
-	go s.processOnboardingMessageAsync(userID, &u, chats, onboardingAdapter, llmAdapter)
+	// 返信の生成はジョブキューに任せ、再起動やLLMの失敗でも返信が失われないようにする
+	payload, err := json.Marshal(onboardingReplyPayload{UserID: userID, MessageID: userChat.ID})
+	if err != nil {
+		return utils.WrapError(err)
+	}
+	if err := s.jobAdapter.Enqueue(&models.Job{
+		Type:      models.JobTypeOnboardingReply,
+		SubjectID: userID,
+		Payload:   string(payload),
+	}); err != nil {
+		return utils.WrapError(err)
+	}
 
 	return nil
 }
 
-func (s *OnboardingService) processOnboardingMessageAsync(
-	userID string,
-	u *models.User,
-	chats []models.OnboardingChat,
-	onboardingAdapter adapter.OnboardingAdapter,
-	llmAdapter adapter.LLMAdapter,
-) {
-	ctx := context.Background()
+// onboardingReplyPayload JobTypeOnboardingReply のペイロード
+type onboardingReplyPayload struct {
+	UserID    string `json:"user_id"`
+	MessageID string `json:"message_id"`
+}
+
+// ProcessOnboardingReply ユーザーのメッセージに対するシステムの返信を生成するジョブのハンドラー。
+// リトライで同じジョブが再実行されても返信が重複しないよう、返信済みなら何もしない
+func (s *OnboardingService) ProcessOnboardingReply(ctx context.Context, job models.Job) error {
+	var payload onboardingReplyPayload
+	if err := json.Unmarshal([]byte(job.Payload), &payload); err != nil {
+		return utils.WrapError(err)
+	}
+
+	u, err := s.userAdapter.GetByID(payload.UserID)
+	if err != nil {
+		return utils.WrapError(err)
+	}
+
+	// 完了判定にユーザーの発言数を使うため全件読む。10往復で打ち切るので件数は限られる
+	chats, _, err := s.onboardingAdapter.GetOnboardingChats(ctx, payload.UserID, adapter.ChatPage{})
+	if err != nil {
+		return utils.WrapError(err)
+	}
+	// 連続で送られた場合、先に動いたジョブが後のメッセージも含めて返信している
+	if len(chats) == 0 || chats[len(chats)-1].SenderType != models.SenderTypeUser {
+		return nil
+	}
 
 	userMessageCount := 0
 	for _, chat := range chats {
@@ -157,7 +166,7 @@ func (s *OnboardingService) processOnboardingMessageAsync(
 	if rallyCount > 10 {
 		isOnboardingCompleted = true
 	} else {
-		isOnboardingCompleted = s.judgeOnboardingCompletion(chats, llmAdapter)
+		isOnboardingCompleted = s.judgeOnboardingCompletion(ctx, chats, s.llmAdapter)
 	}
 
 	systemPrompt := `
@@ -183,21 +192,11 @@ func (s *OnboardingService) processOnboardingMessageAsync(
 
 	fullPrompt := systemPrompt + "\n" + chatHists
 
-	content := []*genai.Content{
-		{
-			Role: "user",
-			Parts: []*genai.Part{
-				{
-					Text: fullPrompt,
-				},
-			},
-		},
-	}
+	req := adapter.NewLLMPromptRequest(adapter.LLM_MODEL_TYPE_GEMINI2_5_FLASH, adapter.LLMTaskOnboardingQuestion, fullPrompt)
 
-	resp, err := llmAdapter.CreateChatCompletion(content, adapter.LLM_MODEL_TYPE_GEMINI2_5_FLASH)
+	resp, err := s.llmAdapter.CreateChatCompletion(ctx, req)
 	if err != nil {
-		log.Printf("Error generating LLM response for user %s: %v", userID, err)
-		return
+		return utils.WrapError(err)
 	}
 
 	systemChat := models.OnboardingChat{
@@ -208,13 +207,42 @@ func (s *OnboardingService) processOnboardingMessageAsync(
 		CreatedAt:             time.Now(),
 	}
 
-	if err := onboardingAdapter.CreateOnboardingChat(ctx, userID, systemChat); err != nil {
-		log.Printf("Error saving system chat for user %s: %v", userID, err)
-		return
+	if err := s.onboardingAdapter.CreateOnboardingChat(ctx, payload.UserID, systemChat); err != nil {
+		return utils.WrapError(err)
+	}
+	return nil
+}
+
+// GetOnboardingReplyStatus 直近に送ったメッセージへの返信が、生成待ち・失敗・完了のどれかを返す
+func (s *OnboardingService) GetOnboardingReplyStatus(userID string) (*response.OnboardingReplyStatusResponse, error) {
+	job, err := s.jobAdapter.GetLatestJobBySubject(models.JobTypeOnboardingReply, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &response.OnboardingReplyStatusResponse{Status: response.OnboardingReplyStatusNone}, nil
+		}
+		return nil, utils.WrapError(err)
+	}
+
+	result := &response.OnboardingReplyStatusResponse{
+		JobID:     job.ID,
+		Attempts:  job.Attempts,
+		UpdatedAt: &job.UpdatedAt,
+	}
+	switch job.Status {
+	case models.JobStatusSucceeded:
+		result.Status = response.OnboardingReplyStatusDone
+	case models.JobStatusDead:
+		result.Status = response.OnboardingReplyStatusFailed
+	default:
+		result.Status = response.OnboardingReplyStatusPending
+		if job.Status == models.JobStatusQueued && job.Attempts > 0 {
+			result.NextRetryAt = &job.RunAt
+		}
 	}
+	return result, nil
 }
 
-func (s *OnboardingService) judgeOnboardingCompletion(chats []models.OnboardingChat, llmAdapter adapter.LLMAdapter) bool {
+func (s *OnboardingService) judgeOnboardingCompletion(ctx context.Context, chats []models.OnboardingChat, llmAdapter adapter.LLMAdapter) bool {
 	chatHistory := ""
 	for _, chat := range chats {
 		var sender string
@@ -247,18 +275,9 @@ func (s *OnboardingService) judgeOnboardingCompletion(chats []models.OnboardingC
 他の文字は一切出力しないでください。
 `, chatHistory)
 
-	content := []*genai.Content{
-		{
-			Role: "user",
-			Parts: []*genai.Part{
-				{
-					Text: judgePrompt,
-				},
-			},
-		},
-	}
+	req := adapter.NewLLMPromptRequest(adapter.LLM_MODEL_TYPE_GEMINI2_5_FLASH, adapter.LLMTaskOnboardingJudge, judgePrompt)
 
-	resp, err := llmAdapter.CreateChatCompletion(content, adapter.LLM_MODEL_TYPE_GEMINI2_5_FLASH)
+	resp, err := llmAdapter.CreateChatCompletion(ctx, req)
 	if err != nil {
 		log.Printf("Error in LLM-as-a-judge: %v", err)
 		return false
@@ -269,28 +288,13 @@ func (s *OnboardingService) judgeOnboardingCompletion(chats []models.OnboardingC
 }
 
 func (s *OnboardingService) FinishOnboarding(ctx context.Context, userID string) ([]*models.UserInfo, *models.Avatar, error) {
-	var onboardingAdapter adapter.OnboardingAdapter
-	var userAdapter adapter.UserAdapter
-	var avatarAdapter adapter.AvatarAdapter
-	var userInfoAdapter adapter.UserInfoAdapter
-	var llmAdapter adapter.LLMAdapter
-	if err := s.container.Invoke(func(oa adapter.OnboardingAdapter, ua adapter.UserAdapter, aa adapter.AvatarAdapter, uia adapter.UserInfoAdapter, la adapter.LLMAdapter) error {
-		onboardingAdapter = oa
-		userAdapter = ua
-		avatarAdapter = aa
-		userInfoAdapter = uia
-		llmAdapter = la
-		return nil
-	}); err != nil {
-		return nil, nil, utils.WrapError(err)
-	}
-
-	u, err := userAdapter.GetByID(userID)
+	u, err := s.userAdapter.GetByID(userID)
 	if err != nil {
 		return nil, nil, utils.WrapError(err)
 	}
 
-	chats, err := onboardingAdapter.GetOnboardingChats(ctx, userID)
+	// 会話全体からユーザー情報とペルソナを作るため全件読む
+	chats, _, err := s.onboardingAdapter.GetOnboardingChats(ctx, userID, adapter.ChatPage{})
 	if err != nil {
 		return nil, nil, utils.WrapError(err)
 	}
@@ -307,13 +311,23 @@ func (s *OnboardingService) FinishOnboarding(ctx context.Context, userID string)
 		chatHistory += fmt.Sprintf("%s: 「%s」\n", sender, chat.Message)
 	}
 
-	userInfos, err := s.extractUserInfoFromChat(chatHistory, userID, llmAdapter)
+	userInfos, err := s.extractUserInfoFromChat(ctx, chatHistory, userID, s.llmAdapter)
+	if err != nil {
+		return nil, nil, utils.WrapError(err)
+	}
+
+	// 保存より先にLLMの処理を終え、失敗時にUserInfoだけが残らないようにする
+	persona, err := s.synthesizeAvatarPersona(ctx, u, chatHistory, s.llmAdapter)
+	if err != nil {
+		return nil, nil, utils.WrapError(err)
+	}
+	traitsJSON, err := json.Marshal(persona.Traits)
 	if err != nil {
 		return nil, nil, utils.WrapError(err)
 	}
 
 	if len(userInfos) > 0 {
-		_, err = userInfoAdapter.CreateMany(userInfos)
+		_, err = s.userInfoAdapter.CreateMany(userInfos)
 		if err != nil {
 			return nil, nil, utils.WrapError(err)
 		}
@@ -323,17 +337,17 @@ func (s *OnboardingService) FinishOnboarding(ctx context.Context, userID string)
 		ID:                utils.GenerateULID(),
 		UserID:            userID,
 		AvatarIconURL:     u.ProfileImageURL,
-		Prompt:            "",
-		PersonalityTraits: "{}",
+		Prompt:            strings.TrimSpace(persona.PersonaPrompt),
+		PersonalityTraits: string(traitsJSON),
 	}
 
-	_, err = avatarAdapter.Create(avatar)
+	_, err = s.avatarAdapter.Create(avatar)
 	if err != nil {
 		return nil, nil, utils.WrapError(err)
 	}
 
 	u.IsOnboardingCompleted = true
-	_, err = userAdapter.Update(u)
+	_, err = s.userAdapter.Update(u)
 	if err != nil {
 		return nil, nil, utils.WrapError(err)
 	}
@@ -341,7 +355,35 @@ func (s *OnboardingService) FinishOnboarding(ctx context.Context, userID string)
 	return userInfos, &avatar, nil
 }
 
-func (s *OnboardingService) extractUserInfoFromChat(chatHistory string, userID string, llmAdapter adapter.LLMAdapter) ([]*models.UserInfo, error) {
+// onboardingExtraction チャット履歴から抽出したプロフィール項目の構造化出力
+type onboardingExtraction struct {
+	Items []struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	} `json:"items"`
+}
+
+func (e *onboardingExtraction) LLMSchema() *adapter.LLMSchema {
+	return &adapter.LLMSchema{
+		Type: adapter.LLMSchemaTypeObject,
+		Properties: map[string]*adapter.LLMSchema{
+			"items": {
+				Type: adapter.LLMSchemaTypeArray,
+				Items: &adapter.LLMSchema{
+					Type: adapter.LLMSchemaTypeObject,
+					Properties: map[string]*adapter.LLMSchema{
+						"key":   {Type: adapter.LLMSchemaTypeString, Description: "項目名", MinLength: adapter.LLMSchemaCount(1)},
+						"value": {Type: adapter.LLMSchemaTypeString, Description: "ユーザーが回答した内容", MinLength: adapter.LLMSchemaCount(1)},
+					},
+					Required: []string{"key", "value"},
+				},
+			},
+		},
+		Required: []string{"items"},
+	}
+}
+
+func (s *OnboardingService) extractUserInfoFromChat(ctx context.Context, chatHistory string, userID string, llmAdapter adapter.LLMAdapter) ([]*models.UserInfo, error) {
 	extractPrompt := fmt.Sprintf(`
 # 命令
 あなたはマッチングアプリのオンボーディングチャット履歴を分析し、ユーザーの情報を抽出するアシスタントです。
@@ -370,40 +412,13 @@ func (s *OnboardingService) extractUserInfoFromChat(chatHistory string, userID s
 }
 `, chatHistory)
 
-	content := []*genai.Content{
-		{
-			Role: "user",
-			Parts: []*genai.Part{
-				{
-					Text: extractPrompt,
-				},
-			},
-		},
-	}
+	req := adapter.NewLLMPromptRequest(adapter.LLM_MODEL_TYPE_GEMINI2_5_FLASH, adapter.LLMTaskOnboardingExtraction, extractPrompt)
 
-	resp, err := llmAdapter.CreateChatCompletion(content, adapter.LLM_MODEL_TYPE_GEMINI2_5_FLASH)
-	if err != nil {
+	var result onboardingExtraction
+	if err := llmAdapter.CreateStructuredCompletion(ctx, req, &result); err != nil {
 		return nil, utils.WrapError(err)
 	}
 
-	resp = strings.TrimSpace(resp)
-	resp = strings.TrimPrefix(resp, "```json")
-	resp = strings.TrimPrefix(resp, "```")
-	resp = strings.TrimSuffix(resp, "```")
-	resp = strings.TrimSpace(resp)
-
-	var result struct {
-		Items []struct {
-			Key   string `json:"key"`
-			Value string `json:"value"`
-		} `json:"items"`
-	}
-
-	if err := json.Unmarshal([]byte(resp), &result); err != nil {
-		log.Printf("Failed to parse LLM response as JSON: %v, response: %s", err, resp)
-		return nil, nil
-	}
-
 	userInfos := make([]*models.UserInfo, 0, len(result.Items))
 	for _, item := range result.Items {
 		userInfo := &models.UserInfo{
@@ -418,3 +433,101 @@ func (s *OnboardingService) extractUserInfoFromChat(chatHistory string, userID s
 
 	return userInfos, nil
 }
+
+// onboardingPersona アバターの人格として保存するペルソナプロンプトと性格特性の構造化出力
+type onboardingPersona struct {
+	PersonaPrompt string                   `json:"persona_prompt"`
+	Traits        models.PersonalityTraits `json:"traits"`
+}
+
+func (p *onboardingPersona) LLMSchema() *adapter.LLMSchema {
+	bigFiveScore := func(description string) *adapter.LLMSchema {
+		return &adapter.LLMSchema{
+			Type:        adapter.LLMSchemaTypeInteger,
+			Description: description,
+			Minimum:     adapter.LLMSchemaBound(1),
+			Maximum:     adapter.LLMSchemaBound(5),
+		}
+	}
+	stringList := func(description string) *adapter.LLMSchema {
+		return &adapter.LLMSchema{
+			Type:        adapter.LLMSchemaTypeArray,
+			Description: description,
+			Items:       &adapter.LLMSchema{Type: adapter.LLMSchemaTypeString, MinLength: adapter.LLMSchemaCount(1)},
+		}
+	}
+
+	return &adapter.LLMSchema{
+		Type: adapter.LLMSchemaTypeObject,
+		Properties: map[string]*adapter.LLMSchema{
+			"persona_prompt": {
+				Type:        adapter.LLMSchemaTypeString,
+				Description: "分身AIとして振る舞うための人格の説明（口調、話し方、価値観、避ける話題）",
+				MinLength:   adapter.LLMSchemaCount(1),
+			},
+			"traits": {
+				Type: adapter.LLMSchemaTypeObject,
+				Properties: map[string]*adapter.LLMSchema{
+					"big_five": {
+						Type: adapter.LLMSchemaTypeObject,
+						Properties: map[string]*adapter.LLMSchema{
+							"openness":          bigFiveScore("開放性"),
+							"conscientiousness": bigFiveScore("誠実性"),
+							"extraversion":      bigFiveScore("外向性"),
+							"agreeableness":     bigFiveScore("協調性"),
+							"neuroticism":       bigFiveScore("神経症傾向"),
+						},
+						Required: []string{"openness", "conscientiousness", "extraversion", "agreeableness", "neuroticism"},
+					},
+					"interests":       stringList("興味関心"),
+					"dating_values":   stringList("恋愛で大切にしていること"),
+					"speaking_style":  {Type: adapter.LLMSchemaTypeString, Description: "話し方の特徴"},
+					"topics_to_avoid": stringList("避けたい話題"),
+				},
+				Required: []string{"big_five", "interests", "dating_values", "speaking_style", "topics_to_avoid"},
+			},
+		},
+		Required: []string{"persona_prompt", "traits"},
+	}
+}
+
+// synthesizeAvatarPersona オンボーディングのチャット履歴から、アバターが会話で使う人格を作る。
+// 具体的なプロフィール項目は後からミッション報酬として非公開にできるため、ここには含めさせない
+func (s *OnboardingService) synthesizeAvatarPersona(ctx context.Context, u models.User, chatHistory string, llmAdapter adapter.LLMAdapter) (*onboardingPersona, error) {
+	personaPrompt := fmt.Sprintf(`
+# 命令
+あなたはマッチングアプリで、ユーザーの分身AI（アバター）の人格を設計するアシスタントです。
+以下のオンボーディングのチャット履歴から、ユーザー本人らしく会話するための人格を作成してください。
+
+# ユーザー情報
+名前: %s
+性別: %s
+
+# チャット履歴
+%s
+
+# 作成するもの
+- persona_prompt: 分身AIへの指示として使う人格の説明（3〜6文、日本語）
+  - 口調・話し方（一人称、敬語かタメ口か、絵文字の有無など）
+  - 大切にしている価値観
+  - 会話で避けたい話題
+  - 血液型・年収・住所・職場などの具体的なプロフィール情報は書かないでください
+- traits: 性格特性
+  - big_five: ビッグファイブの各因子を1〜5の整数で
+  - interests: 興味関心
+  - dating_values: 恋愛で大切にしていること
+  - speaking_style: 話し方の特徴を一言で
+  - topics_to_avoid: 避けたい話題（なければ空配列）
+
+# 出力形式
+必ず有効なJSONのみを出力し、他の文字は一切出力しないでください。
+`, u.DisplayName, u.Gender, chatHistory)
+
+	req := adapter.NewLLMPromptRequest(adapter.LLM_MODEL_TYPE_GEMINI2_5_FLASH, adapter.LLMTaskOnboardingPersona, personaPrompt)
+
+	var persona onboardingPersona
+	if err := llmAdapter.CreateStructuredCompletion(ctx, req, &persona); err != nil {
+		return nil, utils.WrapError(err)
+	}
+	return &persona, nil
+}
@@ -10,16 +10,25 @@ import (
 	"github.com/hackathon-20260110/api/requests"
 	"github.com/hackathon-20260110/api/response"
 	"github.com/hackathon-20260110/api/utils"
-	"go.uber.org/dig"
 	"gorm.io/gorm"
 )
 
 type ProfileService struct {
-	container *dig.Container
+	r2Adapter      adapter.R2Adapter
+	profileAdapter adapter.ProfileAdapter
+	userAdapter    adapter.UserAdapter
 }
 
-func NewProfileService(container *dig.Container) *ProfileService {
-	return &ProfileService{container: container}
+func NewProfileService(
+	r2Adapter adapter.R2Adapter,
+	profileAdapter adapter.ProfileAdapter,
+	userAdapter adapter.UserAdapter,
+) *ProfileService {
+	return &ProfileService{
+		r2Adapter:      r2Adapter,
+		profileAdapter: profileAdapter,
+		userAdapter:    userAdapter,
+	}
 }
 
 // GetPredefinedInfoKeys 事前定義項目一覧を取得
@@ -39,17 +48,8 @@ func (s *ProfileService) GetPredefinedInfoKeys() []response.PredefinedKeyInfo {
 
 // CreateUserInfo プロフィール項目を作成
 func (s *ProfileService) CreateUserInfo(ctx context.Context, userID string, req requests.CreateUserInfoRequest) (*response.UserInfoResponse, error) {
-	var r2Adapter adapter.R2Adapter
 	var db *gorm.DB
 
-	if err := s.container.Invoke(func(ra adapter.R2Adapter, database *gorm.DB) error {
-		r2Adapter = ra
-		db = database
-		return nil
-	}); err != nil {
-		return nil, fmt.Errorf("failed to get dependencies: %w", err)
-	}
-
 	// バリデーション: 事前定義項目か確認
 	predefinedKey := s.findPredefinedKey(req.Key)
 	if predefinedKey == nil {
@@ -83,7 +83,7 @@ func (s *ProfileService) CreateUserInfo(ctx context.Context, userID string, req
 		}
 
 		objectKey := fmt.Sprintf("users/%s/info/%s%s", userID, utils.GenerateULID(), imageData.Extension)
-		url, err := r2Adapter.UploadImage(imageData.Data, objectKey, imageData.ContentType)
+		url, err := s.r2Adapter.UploadImage(imageData.Data, objectKey, imageData.ContentType)
 		if err != nil {
 			return nil, fmt.Errorf("failed to upload image: %w", err)
 		}
@@ -143,19 +143,8 @@ func (s *ProfileService) CreateUserInfo(ctx context.Context, userID string, req
 
 // UpdateUserInfo プロフィール項目を更新
 func (s *ProfileService) UpdateUserInfo(ctx context.Context, userID string, infoID string, req requests.UpdateUserInfoRequest) (*response.UserInfoResponse, error) {
-	var profileAdapter adapter.ProfileAdapter
-	var r2Adapter adapter.R2Adapter
-
-	if err := s.container.Invoke(func(pa adapter.ProfileAdapter, ra adapter.R2Adapter) error {
-		profileAdapter = pa
-		r2Adapter = ra
-		return nil
-	}); err != nil {
-		return nil, fmt.Errorf("failed to get dependencies: %w", err)
-	}
-
 	// 既存のUserInfoを取得
-	existingInfo, err := profileAdapter.GetUserInfoByID(infoID)
+	existingInfo, err := s.profileAdapter.GetUserInfoByID(infoID)
 	if err != nil {
 		return nil, fmt.Errorf("user info not found: %w", err)
 	}
@@ -174,7 +163,7 @@ func (s *ProfileService) UpdateUserInfo(ctx context.Context, userID string, info
 		}
 
 		objectKey := fmt.Sprintf("users/%s/info/%s%s", userID, utils.GenerateULID(), imageData.Extension)
-		url, err := r2Adapter.UploadImage(imageData.Data, objectKey, imageData.ContentType)
+		url, err := s.r2Adapter.UploadImage(imageData.Data, objectKey, imageData.ContentType)
 		if err != nil {
 			return nil, fmt.Errorf("failed to upload image: %w", err)
 		}
@@ -186,7 +175,7 @@ func (s *ProfileService) UpdateUserInfo(ctx context.Context, userID string, info
 	existingInfo.IsMissionReward = req.IsMission
 	existingInfo.UpdatedAt = time.Now()
 
-	updatedInfo, err := profileAdapter.UpdateUserInfo(infoID, existingInfo)
+	updatedInfo, err := s.profileAdapter.UpdateUserInfo(infoID, existingInfo)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update user info: %w", err)
 	}
@@ -194,7 +183,7 @@ func (s *ProfileService) UpdateUserInfo(ctx context.Context, userID string, info
 	// ミッション更新
 	var mission *models.Mission
 	if req.IsMission && req.MissionConfig != nil {
-		existingMission, err := profileAdapter.GetMissionByUserInfoID(infoID)
+		existingMission, err := s.profileAdapter.GetMissionByUserInfoID(infoID)
 		if err == nil {
 			// 既存のミッションを更新
 			existingMission.ThresholdPointCondition = &req.MissionConfig.ThresholdPoint
@@ -202,7 +191,7 @@ func (s *ProfileService) UpdateUserInfo(ctx context.Context, userID string, info
 				existingMission.UnlockCondition = &req.MissionConfig.UnlockCondition
 			}
 			existingMission.UpdatedAt = time.Now()
-			updated, err := profileAdapter.UpdateMission(existingMission.ID, existingMission)
+			updated, err := s.profileAdapter.UpdateMission(existingMission.ID, existingMission)
 			if err != nil {
 				return nil, fmt.Errorf("failed to update mission: %w", err)
 			}
@@ -220,7 +209,7 @@ func (s *ProfileService) UpdateUserInfo(ctx context.Context, userID string, info
 			if req.MissionConfig.UnlockCondition != "" {
 				newMission.UnlockCondition = &req.MissionConfig.UnlockCondition
 			}
-			created, err := profileAdapter.CreateMission(newMission)
+			created, err := s.profileAdapter.CreateMission(newMission)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create mission: %w", err)
 			}
@@ -228,7 +217,7 @@ func (s *ProfileService) UpdateUserInfo(ctx context.Context, userID string, info
 		}
 	} else if req.IsMission == false {
 		// ミッションを無効化する場合は削除
-		if err := profileAdapter.DeleteMissionByUserInfoID(infoID); err != nil {
+		if err := s.profileAdapter.DeleteMissionByUserInfoID(infoID); err != nil {
 			return nil, fmt.Errorf("failed to delete mission: %w", err)
 		}
 	}
@@ -238,17 +227,8 @@ func (s *ProfileService) UpdateUserInfo(ctx context.Context, userID string, info
 
 // DeleteUserInfo プロフィール項目を削除
 func (s *ProfileService) DeleteUserInfo(ctx context.Context, userID string, infoID string) error {
-	var profileAdapter adapter.ProfileAdapter
-
-	if err := s.container.Invoke(func(pa adapter.ProfileAdapter) error {
-		profileAdapter = pa
-		return nil
-	}); err != nil {
-		return fmt.Errorf("failed to get dependencies: %w", err)
-	}
-
 	// 既存のUserInfoを取得
-	existingInfo, err := profileAdapter.GetUserInfoByID(infoID)
+	existingInfo, err := s.profileAdapter.GetUserInfoByID(infoID)
 	if err != nil {
 		return fmt.Errorf("user info not found: %w", err)
 	}
@@ -259,7 +239,7 @@ func (s *ProfileService) DeleteUserInfo(ctx context.Context, userID string, info
 	}
 
 	// 削除（関連するMissionも削除される）
-	if err := profileAdapter.DeleteUserInfo(infoID); err != nil {
+	if err := s.profileAdapter.DeleteUserInfo(infoID); err != nil {
 		return fmt.Errorf("failed to delete user info: %w", err)
 	}
 
@@ -268,31 +248,20 @@ func (s *ProfileService) DeleteUserInfo(ctx context.Context, userID string, info
 
 // GetUserProfile ユーザーのプロフィール情報を取得
 func (s *ProfileService) GetUserProfile(ctx context.Context, targetUserID string, viewerUserID string) (*response.UserProfileResponse, error) {
-	var profileAdapter adapter.ProfileAdapter
-	var userAdapter adapter.UserAdapter
-
-	if err := s.container.Invoke(func(pa adapter.ProfileAdapter, ua adapter.UserAdapter) error {
-		profileAdapter = pa
-		userAdapter = ua
-		return nil
-	}); err != nil {
-		return nil, fmt.Errorf("failed to get dependencies: %w", err)
-	}
-
 	// 1. 基本情報取得
-	user, err := userAdapter.GetByID(targetUserID)
+	user, err := s.userAdapter.GetByID(targetUserID)
 	if err != nil {
 		return nil, fmt.Errorf("user not found: %w", err)
 	}
 
 	// 2. UserInfo一覧取得
-	userInfoList, err := profileAdapter.GetUserInfoByUserID(targetUserID)
+	userInfoList, err := s.profileAdapter.GetUserInfoByUserID(targetUserID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user info list: %w", err)
 	}
 
 	// 3. Mission一覧取得
-	missions, err := profileAdapter.GetMissionsByOwnerID(targetUserID)
+	missions, err := s.profileAdapter.GetMissionsByOwnerID(targetUserID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get missions: %w", err)
 	}
@@ -307,7 +276,7 @@ func (s *ProfileService) GetUserProfile(ctx context.Context, targetUserID string
 		for i, m := range missions {
 			missionIDs[i] = m.ID
 		}
-		unlocks, err := profileAdapter.GetMissionUnlocksByUserID(viewerUserID, missionIDs)
+		unlocks, err := s.profileAdapter.GetMissionUnlocksByUserID(viewerUserID, missionIDs)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get mission unlocks: %w", err)
 		}
@@ -318,7 +287,7 @@ func (s *ProfileService) GetUserProfile(ctx context.Context, targetUserID string
 		}
 
 		// マッチングポイントを取得して解禁判定
-		matchingScore, err := profileAdapter.GetMatchingScore(ctx, viewerUserID, targetUserID)
+		matchingScore, err := s.profileAdapter.GetMatchingScore(ctx, viewerUserID, targetUserID)
 		if err != nil {
 			// マッチングポイント取得に失敗した場合は0として扱う
 			matchingScore = 0
@@ -336,7 +305,7 @@ func (s *ProfileService) GetUserProfile(ctx context.Context, targetUserID string
 						CreatedAt:      time.Now(),
 						UpdatedAt:      time.Now(),
 					}
-					if _, err := profileAdapter.CreateMissionUnlock(unlock); err == nil {
+					if _, err := s.profileAdapter.CreateMissionUnlock(unlock); err == nil {
 						unlockedMissions[mission.ID] = true
 					}
 				}
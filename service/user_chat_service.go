@@ -2,20 +2,43 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
 	"time"
 
 	"github.com/hackathon-20260110/api/adapter"
 	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/response"
 	"github.com/hackathon-20260110/api/utils"
-	"go.uber.org/dig"
+	"gorm.io/gorm"
 )
 
 type UserChatService struct {
-	container *dig.Container
+	matchingAdapter       adapter.MatchingAdapter
+	userAdapter           adapter.UserAdapter
+	userChatAdapter       adapter.UserChatAdapter
+	chatReadCursorAdapter adapter.ChatReadCursorAdapter
+	chatSummaryAdapter    adapter.ChatSummaryAdapter
+	notifier              Notifier
 }
 
-func NewUserChatService(container *dig.Container) *UserChatService {
-	return &UserChatService{container: container}
+func NewUserChatService(
+	matchingAdapter adapter.MatchingAdapter,
+	userAdapter adapter.UserAdapter,
+	userChatAdapter adapter.UserChatAdapter,
+	chatReadCursorAdapter adapter.ChatReadCursorAdapter,
+	chatSummaryAdapter adapter.ChatSummaryAdapter,
+	notifier Notifier,
+) *UserChatService {
+	return &UserChatService{
+		matchingAdapter:       matchingAdapter,
+		userAdapter:           userAdapter,
+		userChatAdapter:       userChatAdapter,
+		chatReadCursorAdapter: chatReadCursorAdapter,
+		chatSummaryAdapter:    chatSummaryAdapter,
+		notifier:              notifier,
+	}
 }
 
 type MatchedUserInfo struct {
@@ -28,21 +51,7 @@ type MatchedUserInfo struct {
 }
 
 func (s *UserChatService) GetMatchedUsers(ctx context.Context, userID string) ([]MatchedUserInfo, error) {
-	var matchingAdapter adapter.MatchingAdapter
-	var userAdapter adapter.UserAdapter
-
-	if err := s.container.Invoke(func(
-		ma adapter.MatchingAdapter,
-		ua adapter.UserAdapter,
-	) error {
-		matchingAdapter = ma
-		userAdapter = ua
-		return nil
-	}); err != nil {
-		return nil, utils.WrapError(err)
-	}
-
-	matchings, err := matchingAdapter.GetMatchingsByUserID(userID)
+	matchings, err := s.matchingAdapter.GetMatchingsByUserID(userID)
 	if err != nil {
 		return nil, utils.WrapError(err)
 	}
@@ -56,7 +65,7 @@ func (s *UserChatService) GetMatchedUsers(ctx context.Context, userID string) ([
 			partnerID = matching.User1ID
 		}
 
-		partner, err := userAdapter.GetByID(partnerID)
+		partner, err := s.userAdapter.GetByID(partnerID)
 		if err != nil {
 			continue
 		}
@@ -75,25 +84,99 @@ func (s *UserChatService) GetMatchedUsers(ctx context.Context, userID string) ([
 }
 
 func (s *UserChatService) SendMessage(ctx context.Context, senderID string, partnerID string, content string) (*adapter.UserChatMessage, error) {
-	var userChatAdapter adapter.UserChatAdapter
-	var matchingAdapter adapter.MatchingAdapter
-
-	if err := s.container.Invoke(func(
-		uca adapter.UserChatAdapter,
-		ma adapter.MatchingAdapter,
-	) error {
-		userChatAdapter = uca
-		matchingAdapter = ma
-		return nil
-	}); err != nil {
+	matching, err := s.matchingAdapter.GetMatchingByUsers(senderID, partnerID)
+	if err != nil {
 		return nil, utils.WrapError(err)
 	}
 
-	_, err := matchingAdapter.GetMatchingByUsers(senderID, partnerID)
+	return s.sendAndNotify(ctx, matching, senderID, partnerID, content)
+}
+
+// GetMessages pageの範囲のメッセージを古い順に返す。相手が既読にした最後のメッセージIDも返す
+func (s *UserChatService) GetMessages(ctx context.Context, userID string, partnerID string, page adapter.ChatPage) ([]adapter.UserChatMessage, string, string, error) {
+	_, err := s.matchingAdapter.GetMatchingByUsers(userID, partnerID)
+	if err != nil {
+		return nil, "", "", utils.WrapError(err)
+	}
+
+	messages, nextCursor, err := s.userChatAdapter.GetUserChatMessages(ctx, userID, partnerID, page)
+	if err != nil {
+		return nil, "", "", utils.WrapError(err)
+	}
+
+	partnerCursor, err := s.chatReadCursorAdapter.Get(partnerID, models.ChatKindUser, userID)
+	if err != nil {
+		return nil, "", "", utils.WrapError(err)
+	}
+
+	return messages, nextCursor, partnerCursor.LastReadMessageID, nil
+}
+
+// MarkAsRead 相手とのチャットをmessageIDまで既読にする。messageIDが空なら最新のメッセージまで
+func (s *UserChatService) MarkAsRead(ctx context.Context, userID string, partnerID string, messageID string) (*response.MarkChatAsReadResponse, error) {
+	if _, err := s.matchingAdapter.GetMatchingByUsers(userID, partnerID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotMatchingMember
+		}
+		return nil, utils.WrapError(err)
+	}
+
+	latest, _, err := s.userChatAdapter.GetUserChatMessages(ctx, userID, partnerID, adapter.ChatPage{Limit: 1})
+	if err != nil {
+		return nil, utils.WrapError(err)
+	}
+	latestID := ""
+	if len(latest) > 0 {
+		latestID = latest[len(latest)-1].ID
+	}
+
+	cursor, err := markChatAsRead(s.chatReadCursorAdapter, userID, models.ChatKindUser, partnerID, messageID, latestID)
+	if err != nil {
+		return nil, err
+	}
+
+	unreadCount, err := s.userChatAdapter.CountMessagesFrom(ctx, userID, partnerID, partnerID, cursor.LastReadMessageID)
 	if err != nil {
 		return nil, utils.WrapError(err)
 	}
 
+	// 数えてから書き込むまでに届いたメッセージの分はずれるが、次に既読にしたときに数え直される
+	if err := s.chatSummaryAdapter.SetUnreadCount(ctx, userID, models.ChatKindUser, partnerID, unreadCount); err != nil {
+		return nil, utils.WrapError(err)
+	}
+
+	return &response.MarkChatAsReadResponse{
+		LastReadMessageID: cursor.LastReadMessageID,
+		UnreadCount:       unreadCount,
+	}, nil
+}
+
+// ErrNotMatchingMember マッチングの当事者以外がメッセージを読み書きしようとした
+var ErrNotMatchingMember = errors.New("user_chat: not a member of the matching")
+
+// matchMessagePreviewLength 新着メッセージ通知に載せる本文の最大文字数
+const matchMessagePreviewLength = 50
+
+// SendMatchMessage マッチングIDを指定して相手にメッセージを送り、相手に通知する
+func (s *UserChatService) SendMatchMessage(ctx context.Context, senderID string, matchingID string, content string) (*response.SendMatchMessageResponse, error) {
+	matching, partnerID, err := getMatchingAsMember(s.matchingAdapter, matchingID, senderID)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := s.sendAndNotify(ctx, matching, senderID, partnerID, content)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.SendMatchMessageResponse{
+		Message: newMatchMessageResponse(matching.ID, *message),
+	}, nil
+}
+
+// sendAndNotify メッセージを保存して送信者の既読位置を進め、相手に通知する。
+// 旧エンドポイントとマッチングID指定のエンドポイントはどちらもここを通す
+func (s *UserChatService) sendAndNotify(ctx context.Context, matching *models.Matching, senderID string, partnerID string, content string) (*adapter.UserChatMessage, error) {
 	message := adapter.UserChatMessage{
 		ID:         utils.GenerateULID(),
 		SenderID:   senderID,
@@ -101,38 +184,111 @@ func (s *UserChatService) SendMessage(ctx context.Context, senderID string, part
 		Message:    content,
 		CreatedAt:  time.Now(),
 	}
-
-	if err := userChatAdapter.CreateUserChatMessage(ctx, senderID, partnerID, message); err != nil {
+	if err := s.userChatAdapter.CreateUserChatMessage(ctx, senderID, partnerID, message); err != nil {
 		return nil, utils.WrapError(err)
 	}
+	advanceReadCursorOnSend(s.chatReadCursorAdapter, senderID, models.ChatKindUser, partnerID, message.ID)
+
+	notifyNewMatchMessage(ctx, s.notifier, s.userAdapter, matching, partnerID, message)
 
 	return &message, nil
 }
 
-func (s *UserChatService) GetMessages(ctx context.Context, userID string, partnerID string) ([]adapter.UserChatMessage, error) {
-	var userChatAdapter adapter.UserChatAdapter
-	var matchingAdapter adapter.MatchingAdapter
-
-	if err := s.container.Invoke(func(
-		uca adapter.UserChatAdapter,
-		ma adapter.MatchingAdapter,
-	) error {
-		userChatAdapter = uca
-		matchingAdapter = ma
-		return nil
-	}); err != nil {
-		return nil, utils.WrapError(err)
+// GetMatchMessages マッチングIDを指定して、pageの範囲のメッセージを古い順に返す
+func (s *UserChatService) GetMatchMessages(ctx context.Context, userID string, matchingID string, page adapter.ChatPage) (*response.GetMatchMessagesResponse, error) {
+	matching, partnerID, err := getMatchingAsMember(s.matchingAdapter, matchingID, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	_, err := matchingAdapter.GetMatchingByUsers(userID, partnerID)
+	messages, nextCursor, err := s.userChatAdapter.GetUserChatMessages(ctx, userID, partnerID, page)
 	if err != nil {
 		return nil, utils.WrapError(err)
 	}
 
-	messages, err := userChatAdapter.GetUserChatMessages(ctx, userID, partnerID)
+	responseMessages := make([]response.MatchMessage, 0, len(messages))
+	for _, message := range messages {
+		responseMessages = append(responseMessages, newMatchMessageResponse(matching.ID, message))
+	}
+
+	partnerCursor, err := s.chatReadCursorAdapter.Get(partnerID, models.ChatKindUser, userID)
 	if err != nil {
 		return nil, utils.WrapError(err)
 	}
 
-	return messages, nil
+	return &response.GetMatchMessagesResponse{
+		Messages:                 responseMessages,
+		NextCursor:               nextCursor,
+		Limit:                    page.Limit,
+		PartnerLastReadMessageID: partnerCursor.LastReadMessageID,
+	}, nil
+}
+
+// getMatchingAsMember マッチングを取得し、userIDが当事者であれば相手のユーザーIDとともに返す
+func getMatchingAsMember(matchingAdapter adapter.MatchingAdapter, matchingID string, userID string) (*models.Matching, string, error) {
+	matching, err := matchingAdapter.GetByID(matchingID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", utils.ErrorRecordNotFound
+		}
+		return nil, "", utils.WrapError(err)
+	}
+
+	switch userID {
+	case matching.User1ID:
+		return matching, matching.User2ID, nil
+	case matching.User2ID:
+		return matching, matching.User1ID, nil
+	default:
+		return nil, "", ErrNotMatchingMember
+	}
+}
+
+// notifyNewMatchMessage 新着メッセージを受信者に通知する。
+// メッセージは保存済みなので、通知に失敗してもログだけ残して続行する
+func notifyNewMatchMessage(
+	ctx context.Context,
+	notifier Notifier,
+	userAdapter adapter.UserAdapter,
+	matching *models.Matching,
+	recipientID string,
+	message adapter.UserChatMessage,
+) {
+	sender, err := userAdapter.GetByID(message.SenderID)
+	if err != nil {
+		log.Printf("Error getting user %s for message notification: %v", message.SenderID, err)
+		return
+	}
+
+	preview := message.Message
+	if runes := []rune(preview); len(runes) > matchMessagePreviewLength {
+		preview = string(runes[:matchMessagePreviewLength]) + "…"
+	}
+
+	if err := notifier.Notify(ctx, models.Notification{
+		ID:       utils.GenerateULID(),
+		UserID:   recipientID,
+		Type:     models.NotificationTypeMessage,
+		Title:    fmt.Sprintf("%sさんからメッセージが届きました", sender.DisplayName),
+		Message:  preview,
+		DeepLink: "/matches/" + matching.ID,
+		Payload: map[string]string{
+			"matching_id":    matching.ID,
+			"message_id":     message.ID,
+			"sender_user_id": message.SenderID,
+		},
+		CreatedAt: message.CreatedAt,
+	}); err != nil {
+		log.Printf("Error creating message notification for user %s: %v", recipientID, err)
+	}
+}
+
+func newMatchMessageResponse(matchingID string, message adapter.UserChatMessage) response.MatchMessage {
+	return response.MatchMessage{
+		ID:        message.ID,
+		MatchID:   matchingID,
+		SenderID:  message.SenderID,
+		Content:   message.Message,
+		CreatedAt: message.CreatedAt.Format(time.RFC3339),
+	}
 }
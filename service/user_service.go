@@ -9,28 +9,23 @@ import (
 	"github.com/hackathon-20260110/api/requests"
 	"github.com/hackathon-20260110/api/response"
 	"github.com/hackathon-20260110/api/utils"
-	"go.uber.org/dig"
 	"gorm.io/gorm"
 )
 
 type UserService struct {
-	container *dig.Container
+	userAdapter adapter.UserAdapter
+	r2Adapter   adapter.R2Adapter
 }
 
-func NewUserService(container *dig.Container) *UserService {
-	return &UserService{container: container}
+func NewUserService(userAdapter adapter.UserAdapter, r2Adapter adapter.R2Adapter) *UserService {
+	return &UserService{
+		userAdapter: userAdapter,
+		r2Adapter:   r2Adapter,
+	}
 }
 
 func (s *UserService) GetUserByID(id string) (response.User, error) {
-	var userAdapter adapter.UserAdapter
-	if err := s.container.Invoke(func(adapter adapter.UserAdapter) error {
-		userAdapter = adapter
-		return nil
-	}); err != nil {
-		return response.User{}, utils.WrapError(err)
-	}
-
-	u, err := userAdapter.GetByID(id)
+	u, err := s.userAdapter.GetByID(id)
 	if err != nil {
 		return response.User{}, utils.WrapError(err)
 	}
@@ -43,15 +38,7 @@ func (s *UserService) GetUserByID(id string) (response.User, error) {
 // GetUserByIDOrNil ユーザーIDでユーザーを取得する。存在しない場合はnilを返す。
 // 他のエラーが発生した場合はエラーを返す。
 func (s *UserService) GetUserByIDOrNil(id string) (*response.User, error) {
-	var userAdapter adapter.UserAdapter
-	if err := s.container.Invoke(func(adapter adapter.UserAdapter) error {
-		userAdapter = adapter
-		return nil
-	}); err != nil {
-		return nil, err
-	}
-
-	u, err := userAdapter.GetByID(id)
+	u, err := s.userAdapter.GetByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -65,27 +52,23 @@ func (s *UserService) GetUserByIDOrNil(id string) (*response.User, error) {
 }
 
 func (s *UserService) UpsertUser(userID string, args requests.CreateUserRequest) (response.User, error) {
-	var userAdapter adapter.UserAdapter
-	var r2Adapter adapter.R2Adapter
-	if err := s.container.Invoke(func(ua adapter.UserAdapter, ra adapter.R2Adapter) error {
-		userAdapter = ua
-		r2Adapter = ra
-		return nil
-	}); err != nil {
-		return response.User{}, err
-	}
-
 	imageData, err := utils.DecodeImageDataURI(args.ProfileImageBase64)
 	if err != nil {
 		return response.User{}, utils.WrapError(err)
 	}
 
 	objectKey := userID + imageData.Extension
-	url, err := r2Adapter.UploadImage(imageData.Data, objectKey, imageData.ContentType)
+	url, err := s.r2Adapter.UploadImage(imageData.Data, objectKey, imageData.ContentType)
 	if err != nil {
 		return response.User{}, utils.WrapError(err)
 	}
 
+	// nilのままSaveするとJSONシリアライザが空文字を書き込み、jsonbのNOT NULL列で失敗する
+	interestedInGenders := args.InterestedInGenders
+	if interestedInGenders == nil {
+		interestedInGenders = []string{}
+	}
+
 	user := models.User{
 		ID:                    userID,
 		DisplayName:           args.DisplayName,
@@ -94,14 +77,20 @@ func (s *UserService) UpsertUser(userID string, args requests.CreateUserRequest)
 		Bio:                   args.Bio,
 		ProfileImageURL:       url,
 		IsOnboardingCompleted: false,
+		InterestedInGenders:   interestedInGenders,
+		PreferredMinAge:       args.PreferredMinAge,
+		PreferredMaxAge:       args.PreferredMaxAge,
+		MaxDistanceKm:         args.MaxDistanceKm,
+		Latitude:              args.Latitude,
+		Longitude:             args.Longitude,
 		CreatedAt:             time.Now(),
 		UpdatedAt:             time.Now(),
 	}
 
 	var r response.User
-	_, err = userAdapter.GetByID(userID)
+	_, err = s.userAdapter.GetByID(userID)
 	if err == utils.ErrorRecordNotFound {
-		nu, err := userAdapter.Create(user)
+		nu, err := s.userAdapter.Create(user)
 		if err != nil {
 			return response.User{}, utils.WrapError(err)
 		}
@@ -109,7 +98,7 @@ func (s *UserService) UpsertUser(userID string, args requests.CreateUserRequest)
 	} else if err != nil {
 		return response.User{}, utils.WrapError(err)
 	} else {
-		nu, err := userAdapter.Update(user)
+		nu, err := s.userAdapter.Update(user)
 		if err != nil {
 			return response.User{}, utils.WrapError(err)
 		}
@@ -0,0 +1,150 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/middleware"
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/service"
+	"github.com/hackathon-20260110/api/tests/mock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAdminService_AdjustMatchingPoint_RecordsAuditAndNotifiesMatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAvatarAdapter := mock.NewMockAvatarAdapter(ctrl)
+	mockUserAdapter := mock.NewMockUserAdapter(ctrl)
+	mockPointEventAdapter := mock.NewMockPointEventAdapter(ctrl)
+
+	container := dig.New()
+	require.NoError(t, container.Provide(func() adapter.AvatarAdapter { return mockAvatarAdapter }))
+	require.NoError(t, container.Provide(func() adapter.UserAdapter { return mockUserAdapter }))
+	require.NoError(t, container.Provide(func() adapter.PointEventAdapter { return mockPointEventAdapter }))
+	delivery := provideNotificationDelivery(t, ctrl, container)
+	expectDefaultNotificationSettings(delivery)
+
+	mockAvatarAdapter.EXPECT().GetByID("avatar-1").Return(&models.Avatar{ID: "avatar-1", UserID: "owner"}, nil)
+	mockUserAdapter.EXPECT().GetByID("user-1").Return(models.User{ID: "user-1", DisplayName: "太郎"}, nil).Times(2)
+	mockUserAdapter.EXPECT().GetByID("owner").Return(models.User{ID: "owner", DisplayName: "花子"}, nil)
+	mockPointEventAdapter.EXPECT().ApplyAdminAdjustment("admin-1", "user-1", "avatar-1", gomock.Any()).
+		DoAndReturn(func(adminUserID, userID, avatarID string, event models.PointEvent) (*adapter.PointChangeResult, *models.PointAdjustmentAudit, error) {
+			assert.Equal(t, models.PointEventSourceManual, event.Source)
+			assert.Equal(t, 30, event.Delta)
+			assert.Equal(t, "障害で失われたポイントの補填", event.Reason)
+			event.ID = "ev-1"
+			event.Delta = 20
+			return &adapter.PointChangeResult{
+				Relation:    models.UserAvatarRelation{ID: "rel-1", UserID: userID, AvatarID: avatarID, MatchingPoint: models.MaxMatchingPoint},
+				Event:       event,
+				IsMatched:   true,
+				NewMatching: &models.Matching{ID: "match-1"},
+			}, &models.PointAdjustmentAudit{
+				ID:             "audit-1",
+				AdminUserID:    adminUserID,
+				PointEventID:   event.ID,
+				RequestedDelta: 30,
+				Reason:         event.Reason,
+			}, nil
+		})
+	delivery.notification.EXPECT().CreateNotification(gomock.Any(), "user-1", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, userID string, notification models.Notification) error {
+			assert.Equal(t, models.NotificationTypeMatch, notification.Type)
+			assert.Equal(t, "/matches/match-1", notification.DeepLink)
+			assert.Equal(t, "owner", notification.Payload["partner_user_id"])
+			return nil
+		})
+	delivery.notification.EXPECT().CreateNotification(gomock.Any(), "owner", gomock.Any()).Return(nil)
+
+	s := newService[*service.AdminService](t, container, service.NewAdminService)
+	result, err := s.AdjustMatchingPoint(context.Background(), "admin-1", "user-1", "avatar-1", 30, "障害で失われたポイントの補填")
+
+	require.NoError(t, err)
+	assert.Equal(t, models.MaxMatchingPoint, result.Relation.MatchingPoint)
+	assert.Equal(t, 20, result.Event.Delta)
+	assert.Equal(t, "manual", result.Event.Source)
+	assert.Equal(t, "audit-1", result.Audit.ID)
+	assert.Equal(t, "admin-1", result.Audit.AdminUserID)
+	assert.Equal(t, 30, result.Audit.RequestedDelta)
+	assert.True(t, result.IsMatched)
+}
+
+func TestAdminService_AdjustMatchingPoint_NotifiesUnlockedMissions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAvatarAdapter := mock.NewMockAvatarAdapter(ctrl)
+	mockUserAdapter := mock.NewMockUserAdapter(ctrl)
+	mockPointEventAdapter := mock.NewMockPointEventAdapter(ctrl)
+
+	container := dig.New()
+	require.NoError(t, container.Provide(func() adapter.AvatarAdapter { return mockAvatarAdapter }))
+	require.NoError(t, container.Provide(func() adapter.UserAdapter { return mockUserAdapter }))
+	require.NoError(t, container.Provide(func() adapter.PointEventAdapter { return mockPointEventAdapter }))
+	delivery := provideNotificationDelivery(t, ctrl, container)
+	expectDefaultNotificationSettings(delivery)
+
+	mockAvatarAdapter.EXPECT().GetByID("avatar-1").Return(&models.Avatar{ID: "avatar-1", UserID: "owner"}, nil)
+	mockUserAdapter.EXPECT().GetByID("user-1").Return(models.User{ID: "user-1", DisplayName: "太郎"}, nil)
+	mockUserAdapter.EXPECT().GetByID("owner").Return(models.User{ID: "owner", DisplayName: "花子"}, nil)
+	mockPointEventAdapter.EXPECT().ApplyAdminAdjustment("admin-1", "user-1", "avatar-1", gomock.Any()).
+		DoAndReturn(func(adminUserID, userID, avatarID string, event models.PointEvent) (*adapter.PointChangeResult, *models.PointAdjustmentAudit, error) {
+			return &adapter.PointChangeResult{
+				Relation:         models.UserAvatarRelation{ID: "rel-1", UserID: userID, AvatarID: avatarID, MatchingPoint: 60},
+				Event:            event,
+				UnlockedMissions: []models.Mission{{ID: "mission-1", MissionOwnerUserID: "owner", UserInfoID: "info-1"}},
+			}, &models.PointAdjustmentAudit{ID: "audit-1"}, nil
+		})
+	delivery.notification.EXPECT().CreateNotification(gomock.Any(), "user-1", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, userID string, notification models.Notification) error {
+			assert.Equal(t, models.NotificationTypeMissionUnlock, notification.Type)
+			assert.Equal(t, "花子さんのプロフィールが新しく見られるようになりました", notification.Message)
+			assert.Equal(t, "/users/owner/profile", notification.DeepLink)
+			assert.Equal(t, "mission-1", notification.Payload["mission_id"])
+			assert.Equal(t, "info-1", notification.Payload["user_info_id"])
+			return nil
+		})
+
+	s := newService[*service.AdminService](t, container, service.NewAdminService)
+	_, err := s.AdjustMatchingPoint(context.Background(), "admin-1", "user-1", "avatar-1", 20, "補填")
+
+	require.NoError(t, err)
+}
+
+func TestRequireRole(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       string
+		wantStatus int
+	}{
+		{name: "admin", role: middleware.RoleAdmin, wantStatus: http.StatusOK},
+		{name: "no role", role: "", wantStatus: http.StatusForbidden},
+		{name: "other role", role: "moderator", wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodPost, "/admin/users/user-1/avatars/avatar-1/matching-points", nil), rec)
+			if tt.role != "" {
+				c.Set("role", tt.role)
+			}
+
+			handler := middleware.RequireRole(middleware.RoleAdmin)(func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			})
+			require.NoError(t, handler(c))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/service"
+	"github.com/hackathon-20260110/api/tests/mock"
+	"github.com/hackathon-20260110/api/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// avatarChatHistory 交互に話したn件の履歴。IDは送信順のULID
+func avatarChatHistory(n int) []adapter.AvatarChatMessage {
+	base := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	messages := make([]adapter.AvatarChatMessage, 0, n)
+	for i := 0; i < n; i++ {
+		senderType := models.SenderTypeUser
+		if i%2 == 1 {
+			senderType = models.SenderTypeAvatarAI
+		}
+		messages = append(messages, adapter.AvatarChatMessage{
+			ID:         utils.GenerateULID(),
+			SenderType: senderType,
+			Message:    "メッセージ",
+			CreatedAt:  base.Add(time.Duration(i) * time.Minute),
+		})
+	}
+	return messages
+}
+
+// expectAvatarChatTurnWithMemory 履歴と記憶を指定して、1往復に必要な期待値を設定する
+func expectAvatarChatTurnWithMemory(m *avatarChatTestMocks, history []adapter.AvatarChatMessage, memory *models.AvatarChatMemory) {
+	m.avatar.EXPECT().GetByID("avatar-1").Return(&models.Avatar{ID: "avatar-1", UserID: "owner"}, nil)
+	m.user.EXPECT().GetByID("owner").Return(models.User{ID: "owner", DisplayName: "花子"}, nil)
+	m.userInfo.EXPECT().GetByUserID("owner").Return(nil, nil)
+	m.avatarChat.EXPECT().CreateAvatarChatMessage(gomock.Any(), "user-1", "avatar-1", gomock.Any()).Return(nil).Times(2)
+	m.avatarChat.EXPECT().GetAvatarChatMessages(gomock.Any(), "user-1", "avatar-1", gomock.Any()).Return(history, "", nil)
+	m.memory.EXPECT().Get("user-1", "avatar-1").Return(memory, nil)
+	m.mission.EXPECT().GetMissionsByOwnerUserID("owner").Return(nil, nil)
+	m.mission.EXPECT().GetMissionUnlocksByUserID("user-1").Return(nil, nil)
+	m.pointEvent.EXPECT().ApplyPointChange("user-1", "avatar-1", gomock.Any()).
+		DoAndReturn(func(userID, avatarID string, event models.PointEvent) (*adapter.PointChangeResult, error) {
+			return &adapter.PointChangeResult{Relation: models.UserAvatarRelation{ID: "rel-1", MatchingPoint: 3}, Event: event}, nil
+		})
+}
+
+func TestAvatarChatService_SendMessage_PromptIncludesMemory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	llm := mock.NewMockLLMAdapter(ctrl)
+	container, m := newAvatarChatTestContainer(t, ctrl, llm)
+
+	history := avatarChatHistory(4)
+	expectAvatarChatTurnWithMemory(m, history, &models.AvatarChatMemory{
+		UserID:          "user-1",
+		AvatarID:        "avatar-1",
+		Summary:         "仕事の話から、週末の過ごし方の話になった",
+		Facts:           []string{"職業は看護師", "週末は山に登る"},
+		SummarizedUntil: history[1].ID,
+	})
+
+	var prompt string
+	llm.EXPECT().CreateStructuredCompletion(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, req adapter.LLMRequest, out adapter.LLMStructuredOutput) error {
+			for _, msg := range req.Messages {
+				prompt += msg.Text
+			}
+			*out.(*service.LLMChatResponse) = service.LLMChatResponse{Message: "お仕事お疲れさま！", PointChange: 3, Reason: "普通の会話"}
+			return nil
+		})
+
+	s := newService[*service.AvatarChatService](t, container, service.NewAvatarChatService)
+	_, err := s.SendMessage(context.Background(), "user-1", "avatar-1", "夜勤明けです")
+
+	require.NoError(t, err)
+	assert.Contains(t, prompt, "仕事の話から、週末の過ごし方の話になった")
+	assert.Contains(t, prompt, "- 職業は看護師")
+	assert.Contains(t, prompt, "- 週末は山に登る")
+}
+
+func TestAvatarChatService_SendMessage_SchedulesSummaryWhenUnsummarizedPilesUp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container, m := newAvatarChatTestContainer(t, ctrl, adapter.NewScriptedLLMAdapter())
+
+	// 今回の返信を含めて10往復分が要約されていない
+	expectAvatarChatTurnWithMemory(m, avatarChatHistory(19), &models.AvatarChatMemory{UserID: "user-1", AvatarID: "avatar-1"})
+	m.delivery.job.EXPECT().EnqueueIfNotActive(gomock.Any()).DoAndReturn(func(job *models.Job) (bool, error) {
+		assert.Equal(t, models.JobTypeAvatarChatSummary, job.Type)
+		assert.Equal(t, "user-1:avatar-1", job.SubjectID)
+		assert.JSONEq(t, `{"user_id":"user-1","avatar_id":"avatar-1"}`, job.Payload)
+		return true, nil
+	})
+
+	s := newService[*service.AvatarChatService](t, container, service.NewAvatarChatService)
+	_, err := s.SendMessage(context.Background(), "user-1", "avatar-1", "こんにちは")
+
+	require.NoError(t, err)
+}
+
+func TestAvatarChatService_SendMessage_SkipsSummaryWhileMemoryIsFresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container, m := newAvatarChatTestContainer(t, ctrl, adapter.NewScriptedLLMAdapter())
+
+	// 直近の履歴は要約済みで、新しいのはこの往復の2件だけ
+	history := avatarChatHistory(29)
+	expectAvatarChatTurnWithMemory(m, history, &models.AvatarChatMemory{
+		UserID:          "user-1",
+		AvatarID:        "avatar-1",
+		Summary:         "自己紹介をした",
+		SummarizedUntil: history[27].ID,
+	})
+
+	s := newService[*service.AvatarChatService](t, container, service.NewAvatarChatService)
+	_, err := s.SendMessage(context.Background(), "user-1", "avatar-1", "こんにちは")
+
+	require.NoError(t, err)
+}
+
+func TestAvatarChatService_SummarizeAvatarChat_FoldsBatchesFromLastSummary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	llm := adapter.NewScriptedLLMAdapterWithScripts(map[adapter.LLMTask][]string{
+		adapter.LLMTaskAvatarChatSummary: {
+			`{"summary": "仕事の話をした", "facts": ["職業は看護師"]}`,
+			`{"summary": "仕事の話から登山の話になった", "facts": ["職業は看護師", "週末は山に登る", "職業は看護師", " "]}`,
+		},
+	})
+	container, m := newAvatarChatTestContainer(t, ctrl, llm)
+
+	history := avatarChatHistory(5)
+	firstBatch := history[1:3]
+	secondBatch := history[3:]
+
+	m.avatar.EXPECT().GetByID("avatar-1").Return(&models.Avatar{ID: "avatar-1", UserID: "owner"}, nil)
+	m.user.EXPECT().GetByID("owner").Return(models.User{ID: "owner", DisplayName: "花子"}, nil)
+	m.memory.EXPECT().Get("user-1", "avatar-1").Return(&models.AvatarChatMemory{
+		UserID:          "user-1",
+		AvatarID:        "avatar-1",
+		Summary:         "挨拶をした",
+		SummarizedUntil: history[0].ID,
+	}, nil)
+	gomock.InOrder(
+		m.avatarChat.EXPECT().GetAvatarChatMessages(gomock.Any(), "user-1", "avatar-1", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, userID, avatarID string, page adapter.ChatPage) ([]adapter.AvatarChatMessage, string, error) {
+				assert.Equal(t, history[0].ID, page.After)
+				assert.True(t, page.FromOldest)
+				return firstBatch, firstBatch[len(firstBatch)-1].ID, nil
+			}),
+		m.memory.EXPECT().Save(gomock.Any()).DoAndReturn(func(memory *models.AvatarChatMemory) error {
+			assert.Equal(t, "仕事の話をした", memory.Summary)
+			assert.Equal(t, firstBatch[len(firstBatch)-1].ID, memory.SummarizedUntil)
+			return nil
+		}),
+		m.avatarChat.EXPECT().GetAvatarChatMessages(gomock.Any(), "user-1", "avatar-1", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, userID, avatarID string, page adapter.ChatPage) ([]adapter.AvatarChatMessage, string, error) {
+				assert.Equal(t, firstBatch[len(firstBatch)-1].ID, page.After)
+				return secondBatch, "", nil
+			}),
+		m.memory.EXPECT().Save(gomock.Any()).DoAndReturn(func(memory *models.AvatarChatMemory) error {
+			assert.Equal(t, "仕事の話から登山の話になった", memory.Summary)
+			assert.Equal(t, []string{"職業は看護師", "週末は山に登る"}, memory.Facts)
+			assert.Equal(t, history[len(history)-1].ID, memory.SummarizedUntil)
+			return nil
+		}),
+	)
+
+	payload, err := json.Marshal(map[string]string{"user_id": "user-1", "avatar_id": "avatar-1"})
+	require.NoError(t, err)
+
+	s := newService[*service.AvatarChatService](t, container, service.NewAvatarChatService)
+	err = s.SummarizeAvatarChat(context.Background(), models.Job{ID: "job-1", Type: models.JobTypeAvatarChatSummary, Payload: string(payload)})
+
+	require.NoError(t, err)
+}
@@ -0,0 +1,301 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/service"
+	"github.com/hackathon-20260110/api/tests/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+	"go.uber.org/mock/gomock"
+)
+
+type avatarChatTestMocks struct {
+	avatarChat   *mock.MockAvatarChatAdapter
+	memory       *mock.MockAvatarChatMemoryAdapter
+	avatar       *mock.MockAvatarAdapter
+	user         *mock.MockUserAdapter
+	userInfo     *mock.MockUserInfoAdapter
+	mission      *mock.MockMissionAdapter
+	matching     *mock.MockMatchingAdapter
+	notification *mock.MockNotificationAdapter
+	delivery     *notificationDeliveryTestMocks
+	pointEvent   *mock.MockPointEventAdapter
+	readCursor   *mock.MockChatReadCursorAdapter
+	summary      *mock.MockChatSummaryAdapter
+}
+
+func newAvatarChatTestContainer(t *testing.T, ctrl *gomock.Controller, llm adapter.LLMAdapter) (*dig.Container, *avatarChatTestMocks) {
+	m := &avatarChatTestMocks{
+		avatarChat: mock.NewMockAvatarChatAdapter(ctrl),
+		memory:     mock.NewMockAvatarChatMemoryAdapter(ctrl),
+		avatar:     mock.NewMockAvatarAdapter(ctrl),
+		user:       mock.NewMockUserAdapter(ctrl),
+		userInfo:   mock.NewMockUserInfoAdapter(ctrl),
+		mission:    mock.NewMockMissionAdapter(ctrl),
+		matching:   mock.NewMockMatchingAdapter(ctrl),
+		pointEvent: mock.NewMockPointEventAdapter(ctrl),
+		readCursor: mock.NewMockChatReadCursorAdapter(ctrl),
+		summary:    mock.NewMockChatSummaryAdapter(ctrl),
+	}
+
+	container := dig.New()
+	require.NoError(t, container.Provide(func() adapter.AvatarChatAdapter { return m.avatarChat }))
+	require.NoError(t, container.Provide(func() adapter.AvatarChatMemoryAdapter { return m.memory }))
+	require.NoError(t, container.Provide(func() adapter.AvatarAdapter { return m.avatar }))
+	require.NoError(t, container.Provide(func() adapter.UserAdapter { return m.user }))
+	require.NoError(t, container.Provide(func() adapter.UserInfoAdapter { return m.userInfo }))
+	require.NoError(t, container.Provide(func() adapter.MissionAdapter { return m.mission }))
+	require.NoError(t, container.Provide(func() adapter.MatchingAdapter { return m.matching }))
+	m.delivery = provideNotificationDelivery(t, ctrl, container)
+	m.notification = m.delivery.notification
+	expectDefaultNotificationSettings(m.delivery)
+	require.NoError(t, container.Provide(func() adapter.PointEventAdapter { return m.pointEvent }))
+	require.NoError(t, container.Provide(func() adapter.LLMAdapter { return llm }))
+	require.NoError(t, container.Provide(func() adapter.ChatReadCursorAdapter { return m.readCursor }))
+	require.NoError(t, container.Provide(func() adapter.ChatSummaryAdapter { return m.summary }))
+
+	return container, m
+}
+
+// expectAvatarChatTurn 返信生成までに呼ばれるアダプターの期待値を設定し、保存された返信を受け取る
+func expectAvatarChatTurn(t *testing.T, m *avatarChatTestMocks, ownerInfos []*models.UserInfo, missions []models.Mission, saved *[]adapter.AvatarChatMessage) {
+	m.avatar.EXPECT().GetByID("avatar-1").Return(&models.Avatar{ID: "avatar-1", UserID: "owner"}, nil)
+	m.user.EXPECT().GetByID("owner").Return(models.User{ID: "owner", DisplayName: "花子"}, nil)
+	m.userInfo.EXPECT().GetByUserID("owner").Return(ownerInfos, nil)
+	m.avatarChat.EXPECT().CreateAvatarChatMessage(gomock.Any(), "user-1", "avatar-1", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, userID, avatarID string, msg adapter.AvatarChatMessage) error {
+			*saved = append(*saved, msg)
+			return nil
+		}).Times(2)
+	m.avatarChat.EXPECT().GetAvatarChatMessages(gomock.Any(), "user-1", "avatar-1", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, userID, avatarID string, page adapter.ChatPage) ([]adapter.AvatarChatMessage, string, error) {
+			// プロンプトには直近の履歴だけを使う
+			if page.Limit == 0 || page.Before != "" || page.After != "" {
+				return nil, "", fmt.Errorf("unexpected chat page: %+v", page)
+			}
+			return *saved, "", nil
+		})
+	m.memory.EXPECT().Get("user-1", "avatar-1").Return(&models.AvatarChatMemory{UserID: "user-1", AvatarID: "avatar-1"}, nil)
+	m.mission.EXPECT().GetMissionsByOwnerUserID("owner").Return(missions, nil)
+	m.mission.EXPECT().GetMissionUnlocksByUserID("user-1").Return(nil, nil)
+	expectAvatarActivityNotification(t, m)
+}
+
+// expectAvatarActivityNotification 会話の最初の返信で、アバターの持ち主に会話が始まったことを通知する
+func expectAvatarActivityNotification(t *testing.T, m *avatarChatTestMocks) {
+	m.user.EXPECT().GetByID("user-1").Return(models.User{ID: "user-1", DisplayName: "太郎"}, nil)
+	m.notification.EXPECT().CreateNotification(gomock.Any(), "owner", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, userID string, notification models.Notification) error {
+			assert.Equal(t, models.NotificationTypeAvatarActivity, notification.Type)
+			assert.Equal(t, "あなたのアバターが太郎さんと会話を始めました", notification.Message)
+			assert.Equal(t, "avatar-1", notification.Payload["avatar_id"])
+			assert.Equal(t, "user-1", notification.Payload["partner_user_id"])
+			return nil
+		})
+}
+
+func TestAvatarChatService_SendMessageStream_StreamsThenPersists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	llm := adapter.NewScriptedLLMAdapterWithScripts(map[adapter.LLMTask][]string{
+		adapter.LLMTaskAvatarChatStream:     {"私も登山が好きです！どの山に登りましたか？"},
+		adapter.LLMTaskAvatarChatEvaluation: {`{"point_change": 6, "reason": "共通の趣味が見つかった"}`},
+	})
+	container, m := newAvatarChatTestContainer(t, ctrl, llm)
+
+	var saved []adapter.AvatarChatMessage
+	expectAvatarChatTurn(t, m, []*models.UserInfo{{ID: "info-1", Key: "趣味", Value: "登山"}}, nil, &saved)
+	m.pointEvent.EXPECT().ApplyPointChange("user-1", "avatar-1", gomock.Any()).
+		DoAndReturn(func(userID, avatarID string, event models.PointEvent) (*adapter.PointChangeResult, error) {
+			assert.Equal(t, models.PointEventSourceAvatarChat, event.Source)
+			assert.Equal(t, 6, event.Delta)
+			assert.Equal(t, "共通の趣味が見つかった", event.Reason)
+			require.NotNil(t, event.MessageID)
+			assert.Equal(t, saved[1].ID, *event.MessageID)
+			return &adapter.PointChangeResult{Relation: models.UserAvatarRelation{ID: "rel-1", MatchingPoint: 46}, Event: event}, nil
+		})
+
+	var chunks []string
+	s := newService[*service.AvatarChatService](t, container, service.NewAvatarChatService)
+	result, err := s.SendMessageStream(context.Background(), "user-1", "avatar-1", "登山が趣味です", func(chunk string) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Greater(t, len(chunks), 1)
+	assert.Equal(t, "私も登山が好きです！どの山に登りましたか？", strings.Join(chunks, ""))
+	assert.Equal(t, 6, result.PointChange)
+	assert.Equal(t, 46, result.MatchingPoint)
+	assert.False(t, result.IsMatched)
+
+	// ユーザーの発言→アバターの返信の順で保存される
+	require.Len(t, saved, 2)
+	assert.Equal(t, models.SenderTypeUser, saved[0].SenderType)
+	assert.Equal(t, models.SenderTypeAvatarAI, saved[1].SenderType)
+	assert.Equal(t, result.AvatarResponse.Message, saved[1].Message)
+}
+
+func TestAvatarChatService_SendMessageStream_StopsForwardingLockedInfo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	llm := adapter.NewScriptedLLMAdapterWithScripts(map[adapter.LLMTask][]string{
+		adapter.LLMTaskAvatarChatStream:     {"実は血液型はAB型なんです。よろしくね"},
+		adapter.LLMTaskAvatarChatEvaluation: {`{"point_change": 2, "reason": "普通の会話"}`},
+	})
+	container, m := newAvatarChatTestContainer(t, ctrl, llm)
+
+	var saved []adapter.AvatarChatMessage
+	ownerInfos := []*models.UserInfo{{ID: "info-secret", Key: "血液型", Value: "AB型", IsMissionReward: true}}
+	missions := []models.Mission{{ID: "mission-1", MissionOwnerUserID: "owner", UserInfoID: "info-secret"}}
+	expectAvatarChatTurn(t, m, ownerInfos, missions, &saved)
+	m.pointEvent.EXPECT().ApplyPointChange("user-1", "avatar-1", gomock.Any()).
+		DoAndReturn(func(userID, avatarID string, event models.PointEvent) (*adapter.PointChangeResult, error) {
+			return &adapter.PointChangeResult{Relation: models.UserAvatarRelation{ID: "rel-1", MatchingPoint: 42}, Event: event}, nil
+		})
+
+	var streamed string
+	s := newService[*service.AvatarChatService](t, container, service.NewAvatarChatService)
+	result, err := s.SendMessageStream(context.Background(), "user-1", "avatar-1", "血液型は？", func(chunk string) error {
+		streamed += chunk
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.NotContains(t, streamed, "AB型")
+	assert.NotContains(t, result.AvatarResponse.Message, "AB型")
+	assert.NotContains(t, saved[1].Message, "AB型")
+}
+
+func TestAvatarChatService_SendMessageStream_HoldsBackLockedValueSplitAcrossChunks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// 4文字ずつ「趣味は登」「山です」に分かれ、未公開の「登山」が断片の境目をまたぐ
+	llm := adapter.NewScriptedLLMAdapterWithScripts(map[adapter.LLMTask][]string{
+		adapter.LLMTaskAvatarChatStream:     {"趣味は登山です"},
+		adapter.LLMTaskAvatarChatEvaluation: {`{"point_change": 2, "reason": "普通の会話"}`},
+	})
+	container, m := newAvatarChatTestContainer(t, ctrl, llm)
+
+	var saved []adapter.AvatarChatMessage
+	ownerInfos := []*models.UserInfo{{ID: "info-secret", Key: "趣味", Value: "登山", IsMissionReward: true}}
+	missions := []models.Mission{{ID: "mission-1", MissionOwnerUserID: "owner", UserInfoID: "info-secret"}}
+	expectAvatarChatTurn(t, m, ownerInfos, missions, &saved)
+	m.pointEvent.EXPECT().ApplyPointChange("user-1", "avatar-1", gomock.Any()).
+		DoAndReturn(func(userID, avatarID string, event models.PointEvent) (*adapter.PointChangeResult, error) {
+			return &adapter.PointChangeResult{Relation: models.UserAvatarRelation{ID: "rel-1", MatchingPoint: 42}, Event: event}, nil
+		})
+
+	var streamed string
+	s := newService[*service.AvatarChatService](t, container, service.NewAvatarChatService)
+	result, err := s.SendMessageStream(context.Background(), "user-1", "avatar-1", "趣味は？", func(chunk string) error {
+		streamed += chunk
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "趣味は", streamed)
+	assert.NotContains(t, result.AvatarResponse.Message, "登山")
+}
+
+func TestAvatarChatService_SendMessageStream_FlushesHeldBackTail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	llm := adapter.NewScriptedLLMAdapterWithScripts(map[adapter.LLMTask][]string{
+		adapter.LLMTaskAvatarChatStream:     {"趣味は読書です"},
+		adapter.LLMTaskAvatarChatEvaluation: {`{"point_change": 2, "reason": "普通の会話"}`},
+	})
+	container, m := newAvatarChatTestContainer(t, ctrl, llm)
+
+	var saved []adapter.AvatarChatMessage
+	ownerInfos := []*models.UserInfo{{ID: "info-secret", Key: "血液型", Value: "AB型", IsMissionReward: true}}
+	missions := []models.Mission{{ID: "mission-1", MissionOwnerUserID: "owner", UserInfoID: "info-secret"}}
+	expectAvatarChatTurn(t, m, ownerInfos, missions, &saved)
+	m.pointEvent.EXPECT().ApplyPointChange("user-1", "avatar-1", gomock.Any()).
+		DoAndReturn(func(userID, avatarID string, event models.PointEvent) (*adapter.PointChangeResult, error) {
+			return &adapter.PointChangeResult{Relation: models.UserAvatarRelation{ID: "rel-1", MatchingPoint: 42}, Event: event}, nil
+		})
+
+	var streamed string
+	s := newService[*service.AvatarChatService](t, container, service.NewAvatarChatService)
+	result, err := s.SendMessageStream(context.Background(), "user-1", "avatar-1", "趣味は？", func(chunk string) error {
+		streamed += chunk
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "趣味は読書です", streamed)
+	assert.Equal(t, "趣味は読書です", result.AvatarResponse.Message)
+}
+
+func TestAvatarChatService_SendMessage_UsesPersonaAndRedactsLockedValues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	llm := mock.NewMockLLMAdapter(ctrl)
+	container, m := newAvatarChatTestContainer(t, ctrl, llm)
+
+	var saved []adapter.AvatarChatMessage
+	ownerInfos := []*models.UserInfo{
+		{ID: "info-hobby", Key: "趣味", Value: "登山"},
+		{ID: "info-secret", Key: "血液型", Value: "AB型", IsMissionReward: true},
+	}
+	missions := []models.Mission{{ID: "mission-1", MissionOwnerUserID: "owner", UserInfoID: "info-secret"}}
+	m.avatar.EXPECT().GetByID("avatar-1").Return(&models.Avatar{
+		ID:                "avatar-1",
+		UserID:            "owner",
+		Prompt:            "おっとりした話し方をします。AB型らしいマイペースさがあります。",
+		PersonalityTraits: `{"big_five": {"openness": 4}, "interests": ["登山"], "speaking_style": "タメ口", "dating_values": ["誠実さ"], "topics_to_avoid": ["仕事"]}`,
+	}, nil)
+	m.user.EXPECT().GetByID("owner").Return(models.User{ID: "owner", DisplayName: "花子"}, nil)
+	m.userInfo.EXPECT().GetByUserID("owner").Return(ownerInfos, nil)
+	m.avatarChat.EXPECT().CreateAvatarChatMessage(gomock.Any(), "user-1", "avatar-1", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, userID, avatarID string, msg adapter.AvatarChatMessage) error {
+			saved = append(saved, msg)
+			return nil
+		}).Times(2)
+	m.avatarChat.EXPECT().GetAvatarChatMessages(gomock.Any(), "user-1", "avatar-1", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, userID, avatarID string, page adapter.ChatPage) ([]adapter.AvatarChatMessage, string, error) {
+			return saved, "", nil
+		})
+	m.memory.EXPECT().Get("user-1", "avatar-1").Return(&models.AvatarChatMemory{UserID: "user-1", AvatarID: "avatar-1"}, nil)
+	m.mission.EXPECT().GetMissionsByOwnerUserID("owner").Return(missions, nil)
+	m.mission.EXPECT().GetMissionUnlocksByUserID("user-1").Return(nil, nil)
+	expectAvatarActivityNotification(t, m)
+	m.pointEvent.EXPECT().ApplyPointChange("user-1", "avatar-1", gomock.Any()).
+		DoAndReturn(func(userID, avatarID string, event models.PointEvent) (*adapter.PointChangeResult, error) {
+			return &adapter.PointChangeResult{Relation: models.UserAvatarRelation{ID: "rel-1", MatchingPoint: 3}, Event: event}, nil
+		})
+
+	var prompt string
+	llm.EXPECT().CreateStructuredCompletion(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, req adapter.LLMRequest, out adapter.LLMStructuredOutput) error {
+			for _, msg := range req.Messages {
+				prompt += msg.Text
+			}
+			*out.(*service.LLMChatResponse) = service.LLMChatResponse{Message: "そうなんだ！", PointChange: 3, Reason: "普通の会話"}
+			return nil
+		})
+
+	s := newService[*service.AvatarChatService](t, container, service.NewAvatarChatService)
+	_, err := s.SendMessage(context.Background(), "user-1", "avatar-1", "こんにちは")
+
+	require.NoError(t, err)
+	assert.Contains(t, prompt, "おっとりした話し方をします")
+	assert.Contains(t, prompt, "話し方: タメ口")
+	assert.Contains(t, prompt, "避けたい話題: 仕事")
+	// ペルソナがある場合はUserInfoの一覧を渡さない
+	assert.NotContains(t, prompt, "趣味: 登山")
+	assert.NotContains(t, prompt, "AB型")
+}
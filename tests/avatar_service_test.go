@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/service"
+	"github.com/hackathon-20260110/api/tests/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestAvatarService_GetPointHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAvatarAdapter := mock.NewMockAvatarAdapter(ctrl)
+	mockPointEventAdapter := mock.NewMockPointEventAdapter(ctrl)
+
+	messageID := "msg-1"
+	createdAt := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	mockAvatarAdapter.EXPECT().GetByID("avatar-1").Return(&models.Avatar{ID: "avatar-1"}, nil)
+	mockAvatarAdapter.EXPECT().GetUserAvatarRelation("user-1", "avatar-1").
+		Return(models.UserAvatarRelation{ID: "rel-1", MatchingPoint: 45}, nil)
+	mockPointEventAdapter.EXPECT().GetPointEventsByRelationID("rel-1", 20, 0).Return([]models.PointEvent{
+		{ID: "ev-2", Source: models.PointEventSourceAvatarChat, Delta: 5, MatchingPointAfter: 45, Reason: "共通の趣味", MessageID: &messageID, CreatedAt: createdAt},
+		{ID: "ev-1", Source: models.PointEventSourceDiagnosis, Delta: 40, MatchingPointAfter: 40, Reason: "相性診断", CreatedAt: createdAt},
+	}, int64(2), nil)
+
+	s := service.NewAvatarService(mockAvatarAdapter, mock.NewMockUserAdapter(ctrl), mockPointEventAdapter)
+	result, err := s.GetPointHistory("user-1", "avatar-1", 20, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, 45, result.MatchingPoint)
+	assert.Equal(t, 2, result.Total)
+	require.Len(t, result.Events, 2)
+	assert.Equal(t, "avatar_chat", result.Events[0].Source)
+	assert.Equal(t, &messageID, result.Events[0].MessageID)
+	assert.Equal(t, "diagnosis", result.Events[1].Source)
+}
+
+func TestAvatarService_GetPointHistory_NoRelationYet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAvatarAdapter := mock.NewMockAvatarAdapter(ctrl)
+	mockAvatarAdapter.EXPECT().GetByID("avatar-1").Return(&models.Avatar{ID: "avatar-1"}, nil)
+	mockAvatarAdapter.EXPECT().GetUserAvatarRelation("user-1", "avatar-1").
+		Return(models.UserAvatarRelation{}, gorm.ErrRecordNotFound)
+
+	s := service.NewAvatarService(mockAvatarAdapter, mock.NewMockUserAdapter(ctrl), mock.NewMockPointEventAdapter(ctrl))
+	result, err := s.GetPointHistory("user-1", "avatar-1", 20, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.MatchingPoint)
+	assert.Empty(t, result.Events)
+}
+
+func TestAvatarService_GetAvatarList_LoadsOwnersAndRelationsInBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAvatarAdapter := mock.NewMockAvatarAdapter(ctrl)
+	mockUserAdapter := mock.NewMockUserAdapter(ctrl)
+
+	birthDate := time.Date(1998, 4, 1, 0, 0, 0, 0, time.UTC)
+	mockUserAdapter.EXPECT().GetByID("user-1").Return(models.User{ID: "user-1", Gender: models.GenderMale, BirthDate: birthDate}, nil)
+	mockAvatarAdapter.EXPECT().GetAvatarsByOwnerGenders("user-1", []string{models.GenderFemale}).Return([]models.Avatar{
+		{ID: "avatar-2", UserID: "user-2"},
+		{ID: "avatar-3", UserID: "user-3"},
+		{ID: "avatar-4", UserID: "user-4"},
+	}, nil)
+	// アバターの数に関わらず、持ち主と関係は1回ずつまとめて取得する
+	mockUserAdapter.EXPECT().GetByIDs([]string{"user-2", "user-3", "user-4"}).Return([]models.User{
+		{ID: "user-2", DisplayName: "花子", Gender: models.GenderFemale, BirthDate: birthDate},
+		{ID: "user-3", DisplayName: "桜", Gender: models.GenderFemale, BirthDate: birthDate, InterestedInGenders: []string{models.GenderFemale}},
+	}, nil)
+	mockAvatarAdapter.EXPECT().GetUserAvatarRelationsByAvatarIDs("user-1", []string{"avatar-2", "avatar-3", "avatar-4"}).
+		Return([]models.UserAvatarRelation{{ID: "rel-2", UserID: "user-1", AvatarID: "avatar-2", MatchingPoint: 30}}, nil)
+
+	s := service.NewAvatarService(mockAvatarAdapter, mockUserAdapter, mock.NewMockPointEventAdapter(ctrl))
+	result, err := s.GetAvatarList("user-1")
+
+	require.NoError(t, err)
+	// 相手の希望に合わないアバターと、持ち主が見つからないアバターは表示しない
+	require.Len(t, result, 1)
+	assert.Equal(t, "avatar-2", result[0].Avatar.ID)
+	assert.Equal(t, "花子", result[0].UserDisplayName)
+	require.NotNil(t, result[0].Relation)
+	assert.Equal(t, 30, result[0].Relation.MatchingPoint)
+}
@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCandidateAdapter_FindCandidatesFiltersInSQL お互いの希望・距離・ブロック・マッチングの条件をSQLで絞り込み、
+// マッチングポイントの高い順に上限まで返すことを実際のPostgresで確かめる。TEST_DATABASE_URL が必要（CIでは必須）
+func TestCandidateAdapter_FindCandidatesFiltersInSQL(t *testing.T) {
+	db := openTestDatabase(t)
+
+	now := time.Now()
+	tokyo := func() (*float64, *float64) { lat, lon := 35.68, 139.76; return &lat, &lon }
+	yokohama := func() (*float64, *float64) { lat, lon := 35.44, 139.64; return &lat, &lon }
+	osaka := func() (*float64, *float64) { lat, lon := 34.69, 135.50; return &lat, &lon }
+	km := func(v int) *int { return &v }
+
+	meLat, meLon := tokyo()
+	me := models.User{ID: utils.GenerateULID(), Gender: models.GenderMale, BirthDate: now.AddDate(-30, 0, 0), Latitude: meLat, Longitude: meLon, MaxDistanceKm: km(100)}
+	newCandidate := func(mutate func(u *models.User)) models.User {
+		u := models.User{ID: utils.GenerateULID(), Gender: models.GenderFemale, BirthDate: now.AddDate(-28, 0, 0)}
+		mutate(&u)
+		return u
+	}
+	ok := newCandidate(func(u *models.User) { u.Latitude, u.Longitude = tokyo() })
+	noLocation := newCandidate(func(u *models.User) {})
+	likesWomen := newCandidate(func(u *models.User) { u.InterestedInGenders = []string{models.GenderFemale} })
+	wantsYounger := newCandidate(func(u *models.User) { u.PreferredMaxAge = 29 })
+	tooFar := newCandidate(func(u *models.User) { u.Latitude, u.Longitude = osaka() })
+	// 東京と横浜は約30km。自分の希望（100km）には入るが、相手の希望（10km）には入らない
+	wantsCloser := newCandidate(func(u *models.User) { u.Latitude, u.Longitude = yokohama(); u.MaxDistanceKm = km(10) })
+	blocked := newCandidate(func(u *models.User) {})
+	matched := newCandidate(func(u *models.User) {})
+	male := newCandidate(func(u *models.User) { u.Gender = models.GenderMale })
+	users := []models.User{me, ok, noLocation, likesWomen, wantsYounger, tooFar, wantsCloser, blocked, matched, male}
+	require.NoError(t, db.Create(&users).Error)
+
+	avatarIDs := make(map[string]string, len(users))
+	for _, user := range users[1:] {
+		avatar := models.Avatar{ID: utils.GenerateULID(), UserID: user.ID, PersonalityTraits: "{}"}
+		require.NoError(t, db.Create(&avatar).Error)
+		avatarIDs[user.ID] = avatar.ID
+	}
+	require.NoError(t, db.Create(&models.UserBlock{ID: utils.GenerateULID(), BlockerUserID: blocked.ID, BlockedUserID: me.ID}).Error)
+	user1ID, user2ID := me.ID, matched.ID
+	if user1ID > user2ID {
+		user1ID, user2ID = user2ID, user1ID
+	}
+	require.NoError(t, db.Create(&models.Matching{ID: utils.GenerateULID(), User1ID: user1ID, User2ID: user2ID}).Error)
+	require.NoError(t, db.Create(&models.UserAvatarRelation{ID: utils.GenerateULID(), UserID: me.ID, AvatarID: avatarIDs[noLocation.ID], MatchingPoint: 30}).Error)
+
+	filter := adapter.CandidateFilter{
+		UserID:    me.ID,
+		Genders:   me.InterestedGenders(),
+		Viewer:    me,
+		ViewerAge: 30,
+	}
+	candidates, err := adapter.NewCandidateAdapter(db).FindCandidates(filter)
+	require.NoError(t, err)
+
+	created := make(map[string]bool, len(users))
+	for _, user := range users {
+		created[user.ID] = true
+	}
+	var ids []string
+	for _, candidate := range candidates {
+		if created[candidate.User.ID] {
+			ids = append(ids, candidate.User.ID)
+		}
+	}
+	// マッチングポイントの高い順、同じならID順
+	assert.Equal(t, []string{noLocation.ID, ok.ID}, ids)
+	assert.Equal(t, 30, candidates[0].MatchingPoint)
+
+	filter.Limit = 1
+	candidates, err = adapter.NewCandidateAdapter(db).FindCandidates(filter)
+	require.NoError(t, err)
+	assert.Len(t, candidates, 1)
+}
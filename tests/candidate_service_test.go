@@ -0,0 +1,182 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/service"
+	"github.com/hackathon-20260110/api/tests/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+	"go.uber.org/mock/gomock"
+)
+
+func newCandidateServiceTestContainer(t *testing.T, ctrl *gomock.Controller) (*dig.Container, *mock.MockUserAdapter, *mock.MockUserInfoAdapter, *mock.MockCandidateAdapter) {
+	mockUserAdapter := mock.NewMockUserAdapter(ctrl)
+	mockUserInfoAdapter := mock.NewMockUserInfoAdapter(ctrl)
+	mockCandidateAdapter := mock.NewMockCandidateAdapter(ctrl)
+
+	container := dig.New()
+	require.NoError(t, container.Provide(func() adapter.UserAdapter { return mockUserAdapter }))
+	require.NoError(t, container.Provide(func() adapter.UserInfoAdapter { return mockUserInfoAdapter }))
+	require.NoError(t, container.Provide(func() adapter.CandidateAdapter { return mockCandidateAdapter }))
+	return container, mockUserAdapter, mockUserInfoAdapter, mockCandidateAdapter
+}
+
+func TestCandidateService_GetCandidates_RanksByCompatibility(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container, mockUserAdapter, mockUserInfoAdapter, mockCandidateAdapter := newCandidateServiceTestContainer(t, ctrl)
+
+	mockUserAdapter.EXPECT().GetByID("me").Return(models.User{ID: "me", Gender: "male"}, nil)
+	mockUserInfoAdapter.EXPECT().GetByUserID("me").Return([]*models.UserInfo{
+		{InfoType: models.UserInfoTypeText, Key: "趣味", Value: "登山、映画鑑賞"},
+		{InfoType: models.UserInfoTypeText, Key: "好きな食べ物", Value: "ラーメン"},
+	}, nil)
+	mockCandidateAdapter.EXPECT().FindCandidates(gomock.Any()).DoAndReturn(func(filter adapter.CandidateFilter) ([]adapter.Candidate, error) {
+		assert.Equal(t, "me", filter.UserID)
+		assert.Equal(t, []string{"female"}, filter.Genders)
+		assert.Nil(t, filter.BornOnOrBefore)
+		assert.Nil(t, filter.BornAfter)
+		// 相手の希望もSQLで判定できるよう自分の情報を渡し、読み込む人数を抑える
+		assert.Equal(t, "male", filter.Viewer.Gender)
+		assert.Equal(t, service.MaxCandidatePool+1, filter.Limit)
+		return []adapter.Candidate{
+			{
+				User:   models.User{ID: "a", DisplayName: "共通点なし", Gender: "female"},
+				Avatar: models.Avatar{ID: "avatar-a"},
+				UserInfos: []*models.UserInfo{
+					{InfoType: models.UserInfoTypeText, Key: "趣味", Value: "ゲーム"},
+				},
+			},
+			{
+				User:   models.User{ID: "b", DisplayName: "趣味が同じ", Gender: "female"},
+				Avatar: models.Avatar{ID: "avatar-b"},
+				UserInfos: []*models.UserInfo{
+					{InfoType: models.UserInfoTypeText, Key: "趣味", Value: "登山・映画鑑賞"},
+				},
+			},
+			{
+				User:          models.User{ID: "c", DisplayName: "よく話している", Gender: "female"},
+				Avatar:        models.Avatar{ID: "avatar-c"},
+				MatchingPoint: 80,
+			},
+		}, nil
+	})
+
+	s := newService[*service.CandidateService](t, container, service.NewCandidateService)
+	result, err := s.GetCandidates("me", service.CandidateQuery{Limit: 2})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Total)
+	assert.False(t, result.Truncated)
+	require.Len(t, result.Partners, 2)
+	// 趣味2/3一致で40点、ポイント80で32点
+	assert.Equal(t, "b", result.Partners[0].ID)
+	assert.Equal(t, 40, result.Partners[0].CompatibilityScore)
+	assert.Equal(t, "c", result.Partners[1].ID)
+	assert.Equal(t, 32, result.Partners[1].CompatibilityScore)
+	assert.Equal(t, "avatar-c", result.Partners[1].AvatarID)
+}
+
+func TestCandidateService_GetCandidates_AppliesFilters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container, mockUserAdapter, mockUserInfoAdapter, mockCandidateAdapter := newCandidateServiceTestContainer(t, ctrl)
+
+	mockUserAdapter.EXPECT().GetByID("me").Return(models.User{ID: "me", Gender: "female", InterestedInGenders: []string{"female", "other"}}, nil)
+	mockUserInfoAdapter.EXPECT().GetByUserID("me").Return(nil, nil)
+	mockCandidateAdapter.EXPECT().FindCandidates(gomock.Any()).DoAndReturn(func(filter adapter.CandidateFilter) ([]adapter.Candidate, error) {
+		assert.Equal(t, []string{"female", "other"}, filter.Genders)
+		require.NotNil(t, filter.BornOnOrBefore)
+		require.NotNil(t, filter.BornAfter)
+		// 25歳以上30歳以下
+		assert.WithinDuration(t, time.Now().AddDate(-25, 0, 0), *filter.BornOnOrBefore, time.Minute)
+		assert.WithinDuration(t, time.Now().AddDate(-31, 0, 0), *filter.BornAfter, time.Minute)
+		return []adapter.Candidate{{User: models.User{ID: "a", Gender: "other", BirthDate: time.Now().AddDate(-28, 0, 0), InterestedInGenders: []string{"female"}}}}, nil
+	})
+
+	s := newService[*service.CandidateService](t, container, service.NewCandidateService)
+	result, err := s.GetCandidates("me", service.CandidateQuery{Limit: 20, Offset: 5, MinAge: 25, MaxAge: 30, Genders: []string{"female", "other"}})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Total)
+	assert.Empty(t, result.Partners)
+}
+
+func TestCandidateService_GetCandidates_RejectsInvertedAgeRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container, _, _, _ := newCandidateServiceTestContainer(t, ctrl)
+
+	s := newService[*service.CandidateService](t, container, service.NewCandidateService)
+	_, err := s.GetCandidates("me", service.CandidateQuery{Limit: 20, MinAge: 40, MaxAge: 30})
+
+	assert.ErrorIs(t, err, service.ErrInvalidCandidateQuery)
+}
+
+func TestCandidateService_GetCandidates_RequiresMutualPreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container, mockUserAdapter, mockUserInfoAdapter, mockCandidateAdapter := newCandidateServiceTestContainer(t, ctrl)
+
+	now := time.Now()
+	me := models.User{ID: "me", Gender: "male", BirthDate: now.AddDate(-30, 0, 0), PreferredMinAge: 25, PreferredMaxAge: 35}
+	mockUserAdapter.EXPECT().GetByID("me").Return(me, nil)
+	mockUserInfoAdapter.EXPECT().GetByUserID("me").Return(nil, nil)
+	mockCandidateAdapter.EXPECT().FindCandidates(gomock.Any()).DoAndReturn(func(filter adapter.CandidateFilter) ([]adapter.Candidate, error) {
+		// 条件の指定が無いので自分の希望（異性・25〜35歳）で絞り込む
+		assert.Equal(t, []string{"female"}, filter.Genders)
+		require.NotNil(t, filter.BornOnOrBefore)
+		require.NotNil(t, filter.BornAfter)
+		assert.WithinDuration(t, now.AddDate(-25, 0, 0), *filter.BornOnOrBefore, time.Minute)
+		assert.WithinDuration(t, now.AddDate(-36, 0, 0), *filter.BornAfter, time.Minute)
+		assert.Equal(t, 30, filter.ViewerAge)
+		return []adapter.Candidate{
+			{User: models.User{ID: "likes-women", Gender: "female", BirthDate: now.AddDate(-28, 0, 0), InterestedInGenders: []string{"female"}}},
+			{User: models.User{ID: "wants-younger", Gender: "female", BirthDate: now.AddDate(-28, 0, 0), PreferredMaxAge: 29}},
+			{User: models.User{ID: "ok", Gender: "female", BirthDate: now.AddDate(-28, 0, 0)}},
+		}, nil
+	})
+
+	s := newService[*service.CandidateService](t, container, service.NewCandidateService)
+	result, err := s.GetCandidates("me", service.CandidateQuery{Limit: 20})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Total)
+	require.Len(t, result.Partners, 1)
+	assert.Equal(t, "ok", result.Partners[0].ID)
+}
+
+func TestCandidateService_GetCandidates_ReportsTruncatedPool(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container, mockUserAdapter, mockUserInfoAdapter, mockCandidateAdapter := newCandidateServiceTestContainer(t, ctrl)
+
+	mockUserAdapter.EXPECT().GetByID("me").Return(models.User{ID: "me", Gender: "male"}, nil)
+	mockUserInfoAdapter.EXPECT().GetByUserID("me").Return(nil, nil)
+	mockCandidateAdapter.EXPECT().FindCandidates(gomock.Any()).DoAndReturn(func(filter adapter.CandidateFilter) ([]adapter.Candidate, error) {
+		candidates := make([]adapter.Candidate, filter.Limit)
+		for i := range candidates {
+			candidates[i] = adapter.Candidate{User: models.User{ID: fmt.Sprintf("user-%03d", i), Gender: "female"}}
+		}
+		return candidates, nil
+	})
+
+	s := newService[*service.CandidateService](t, container, service.NewCandidateService)
+	result, err := s.GetCandidates("me", service.CandidateQuery{Limit: 20, Offset: service.MaxCandidatePool})
+
+	require.NoError(t, err)
+	assert.True(t, result.Truncated)
+	assert.Equal(t, service.MaxCandidatePool, result.Total)
+	assert.Empty(t, result.Partners)
+}
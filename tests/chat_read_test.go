@@ -0,0 +1,264 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/service"
+	"github.com/hackathon-20260110/api/tests/mock"
+	"github.com/hackathon-20260110/api/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type chatReadTestMocks struct {
+	userChat   *mock.MockUserChatAdapter
+	avatarChat *mock.MockAvatarChatAdapter
+	avatar     *mock.MockAvatarAdapter
+	user       *mock.MockUserAdapter
+	matching   *mock.MockMatchingAdapter
+	readCursor *mock.MockChatReadCursorAdapter
+	summary    *mock.MockChatSummaryAdapter
+}
+
+func newChatReadTestContainer(t *testing.T, ctrl *gomock.Controller) (*dig.Container, *chatReadTestMocks) {
+	m := &chatReadTestMocks{
+		userChat:   mock.NewMockUserChatAdapter(ctrl),
+		avatarChat: mock.NewMockAvatarChatAdapter(ctrl),
+		avatar:     mock.NewMockAvatarAdapter(ctrl),
+		user:       mock.NewMockUserAdapter(ctrl),
+		matching:   mock.NewMockMatchingAdapter(ctrl),
+		readCursor: mock.NewMockChatReadCursorAdapter(ctrl),
+		summary:    mock.NewMockChatSummaryAdapter(ctrl),
+	}
+
+	container := dig.New()
+	require.NoError(t, container.Provide(func() adapter.UserChatAdapter { return m.userChat }))
+	require.NoError(t, container.Provide(func() adapter.AvatarChatAdapter { return m.avatarChat }))
+	require.NoError(t, container.Provide(func() adapter.AvatarAdapter { return m.avatar }))
+	require.NoError(t, container.Provide(func() adapter.UserAdapter { return m.user }))
+	require.NoError(t, container.Provide(func() adapter.MatchingAdapter { return m.matching }))
+	require.NoError(t, container.Provide(func() adapter.ChatReadCursorAdapter { return m.readCursor }))
+	require.NoError(t, container.Provide(func() adapter.ChatSummaryAdapter { return m.summary }))
+	// 既読の処理では使わないが、サービスの組み立てに必要なもの
+	require.NoError(t, container.Provide(func() adapter.AvatarChatMemoryAdapter { return mock.NewMockAvatarChatMemoryAdapter(ctrl) }))
+	require.NoError(t, container.Provide(func() adapter.UserInfoAdapter { return mock.NewMockUserInfoAdapter(ctrl) }))
+	require.NoError(t, container.Provide(func() adapter.MissionAdapter { return mock.NewMockMissionAdapter(ctrl) }))
+	require.NoError(t, container.Provide(func() adapter.PointEventAdapter { return mock.NewMockPointEventAdapter(ctrl) }))
+	require.NoError(t, container.Provide(func() adapter.LLMAdapter { return mock.NewMockLLMAdapter(ctrl) }))
+	provideNotificationDelivery(t, ctrl, container)
+	return container, m
+}
+
+// chatMessageIDs 送信順に並んだn個のメッセージID
+func chatMessageIDs(n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = utils.GenerateULID()
+	}
+	return ids
+}
+
+func TestUserChatService_MarkAsRead_ClampsToLatestMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container, m := newChatReadTestContainer(t, ctrl)
+	ids := chatMessageIDs(2)
+
+	m.matching.EXPECT().GetMatchingByUsers("user-1", "user-2").Return(&models.Matching{ID: "match-1", User1ID: "user-1", User2ID: "user-2"}, nil)
+	m.userChat.EXPECT().GetUserChatMessages(gomock.Any(), "user-1", "user-2", adapter.ChatPage{Limit: 1}).
+		Return([]adapter.UserChatMessage{{ID: ids[1], SenderID: "user-2"}}, ids[1], nil)
+	m.readCursor.EXPECT().Get("user-1", models.ChatKindUser, "user-2").
+		Return(&models.ChatReadCursor{UserID: "user-1", ChatKind: models.ChatKindUser, PartnerID: "user-2"}, nil)
+	m.readCursor.EXPECT().Advance(gomock.Any()).DoAndReturn(func(cursor *models.ChatReadCursor) error {
+		assert.Equal(t, ids[1], cursor.LastReadMessageID)
+		return nil
+	})
+	m.userChat.EXPECT().CountMessagesFrom(gomock.Any(), "user-1", "user-2", "user-2", ids[1]).Return(0, nil)
+	m.summary.EXPECT().SetUnreadCount(gomock.Any(), "user-1", models.ChatKindUser, "user-2", 0).Return(nil)
+
+	// まだ存在しない未来のIDを指定しても、最新のメッセージまでしか既読にならない
+	s := newService[*service.UserChatService](t, container, service.NewUserChatService)
+	result, err := s.MarkAsRead(context.Background(), "user-1", "user-2", "7ZZZZZZZZZZZZZZZZZZZZZZZZZ")
+
+	require.NoError(t, err)
+	assert.Equal(t, ids[1], result.LastReadMessageID)
+	assert.Equal(t, 0, result.UnreadCount)
+}
+
+func TestUserChatService_MarkAsRead_DoesNotMoveBackwards(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container, m := newChatReadTestContainer(t, ctrl)
+	ids := chatMessageIDs(3)
+
+	m.matching.EXPECT().GetMatchingByUsers("user-1", "user-2").Return(&models.Matching{ID: "match-1", User1ID: "user-1", User2ID: "user-2"}, nil)
+	m.userChat.EXPECT().GetUserChatMessages(gomock.Any(), "user-1", "user-2", adapter.ChatPage{Limit: 1}).
+		Return([]adapter.UserChatMessage{{ID: ids[2], SenderID: "user-2"}}, ids[2], nil)
+	m.readCursor.EXPECT().Get("user-1", models.ChatKindUser, "user-2").
+		Return(&models.ChatReadCursor{UserID: "user-1", ChatKind: models.ChatKindUser, PartnerID: "user-2", LastReadMessageID: ids[1]}, nil)
+	m.userChat.EXPECT().CountMessagesFrom(gomock.Any(), "user-1", "user-2", "user-2", ids[1]).Return(1, nil)
+	m.summary.EXPECT().SetUnreadCount(gomock.Any(), "user-1", models.ChatKindUser, "user-2", 1).Return(nil)
+
+	// 別の端末で先まで読んでいれば、古い位置の既読は無視する
+	s := newService[*service.UserChatService](t, container, service.NewUserChatService)
+	result, err := s.MarkAsRead(context.Background(), "user-1", "user-2", ids[0])
+
+	require.NoError(t, err)
+	assert.Equal(t, ids[1], result.LastReadMessageID)
+	assert.Equal(t, 1, result.UnreadCount)
+}
+
+func TestUserChatService_MarkAsRead_RejectsUnmatchedPartner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container, m := newChatReadTestContainer(t, ctrl)
+
+	m.matching.EXPECT().GetMatchingByUsers("user-1", "stranger").Return(nil, gorm.ErrRecordNotFound)
+
+	s := newService[*service.UserChatService](t, container, service.NewUserChatService)
+	_, err := s.MarkAsRead(context.Background(), "user-1", "stranger", "")
+
+	assert.ErrorIs(t, err, service.ErrNotMatchingMember)
+}
+
+func TestAvatarChatService_MarkAsRead_AdvancesToLatestAvatarMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container, m := newChatReadTestContainer(t, ctrl)
+	ids := chatMessageIDs(1)
+
+	m.avatar.EXPECT().GetByID("avatar-1").Return(&models.Avatar{ID: "avatar-1", UserID: "owner"}, nil)
+	m.avatarChat.EXPECT().GetAvatarChatMessages(gomock.Any(), "user-1", "avatar-1", adapter.ChatPage{Limit: 1}).
+		Return([]adapter.AvatarChatMessage{{ID: ids[0], SenderType: models.SenderTypeAvatarAI}}, "", nil)
+	m.readCursor.EXPECT().Get("user-1", models.ChatKindAvatar, "avatar-1").
+		Return(&models.ChatReadCursor{UserID: "user-1", ChatKind: models.ChatKindAvatar, PartnerID: "avatar-1"}, nil)
+	m.readCursor.EXPECT().Advance(gomock.Any()).DoAndReturn(func(cursor *models.ChatReadCursor) error {
+		assert.Equal(t, models.ChatKindAvatar, cursor.ChatKind)
+		assert.Equal(t, ids[0], cursor.LastReadMessageID)
+		return nil
+	})
+	m.avatarChat.EXPECT().CountAvatarMessages(gomock.Any(), "user-1", "avatar-1", ids[0]).Return(0, nil)
+	m.summary.EXPECT().SetUnreadCount(gomock.Any(), "user-1", models.ChatKindAvatar, "avatar-1", 0).Return(nil)
+
+	s := newService[*service.AvatarChatService](t, container, service.NewAvatarChatService)
+	result, err := s.MarkAsRead(context.Background(), "user-1", "avatar-1", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, ids[0], result.LastReadMessageID)
+}
+
+func TestAvatarChatService_MarkAsRead_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container, m := newChatReadTestContainer(t, ctrl)
+
+	m.avatar.EXPECT().GetByID("missing").Return(nil, gorm.ErrRecordNotFound)
+
+	s := newService[*service.AvatarChatService](t, container, service.NewAvatarChatService)
+	_, err := s.MarkAsRead(context.Background(), "user-1", "missing", "")
+
+	assert.ErrorIs(t, err, utils.ErrorRecordNotFound)
+}
+
+func TestChatService_GetChats_ReadsSummariesInBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container, m := newChatReadTestContainer(t, ctrl)
+	ids := chatMessageIDs(2)
+	base := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	// 相手が何人いても、相手の情報と最新のメッセージはまとめて1回ずつ取得する
+	m.matching.EXPECT().GetMatchingsByUserID("user-1").Return([]models.Matching{
+		{ID: "match-1", User1ID: "user-1", User2ID: "user-2", CreatedAt: base},
+		{ID: "match-2", User1ID: "user-0", User2ID: "user-1", CreatedAt: base.Add(time.Hour)},
+	}, nil)
+	m.avatar.EXPECT().GetUserAvatarRelationsByUserID("user-1").Return([]models.UserAvatarRelation{
+		{ID: "rel-2", UserID: "user-1", AvatarID: "avatar-2", MatchingPoint: 100, CreatedAt: base},
+		{ID: "rel-3", UserID: "user-1", AvatarID: "avatar-3", MatchingPoint: 40, CreatedAt: base},
+	}, nil)
+	m.avatar.EXPECT().GetByIDs(gomock.InAnyOrder([]string{"avatar-2", "avatar-3"})).Return([]models.Avatar{
+		{ID: "avatar-2", UserID: "user-2"},
+		{ID: "avatar-3", UserID: "user-3", AvatarIconURL: "https://example.com/avatar-3.png"},
+	}, nil)
+	m.user.EXPECT().GetByIDs(gomock.Any()).DoAndReturn(func(userIDs []string) ([]models.User, error) {
+		assert.ElementsMatch(t, []string{"user-2", "user-0", "user-2", "user-3"}, userIDs)
+		return []models.User{
+			{ID: "user-0", DisplayName: "次郎"},
+			{ID: "user-2", DisplayName: "花子"},
+			{ID: "user-3", DisplayName: "太郎"},
+		}, nil
+	})
+	m.summary.EXPECT().GetChatSummaries(gomock.Any(), "user-1").Return([]adapter.ChatSummary{
+		{ChatKind: models.ChatKindUser, PartnerID: "user-2", LastMessageID: ids[0], LastMessage: "またね", LastMessageAt: base.Add(2 * time.Hour), UnreadCount: 2},
+		{ChatKind: models.ChatKindUser, PartnerID: "user-0"},
+		{ChatKind: models.ChatKindAvatar, PartnerID: "avatar-3", LastMessageID: ids[1], LastMessage: "おやすみ", LastMessageAt: base.Add(3 * time.Hour)},
+	}, nil)
+
+	s := newService[*service.ChatService](t, container, service.NewChatService)
+	result, err := s.GetChats(context.Background(), "user-1")
+
+	require.NoError(t, err)
+	// マッチ済みの花子さんのアバターとのチャットは一覧に出さない
+	require.Len(t, result.Chats, 3)
+	assert.Equal(t, "avatar-3", result.Chats[0].PartnerID)
+	assert.Equal(t, "おやすみ", result.Chats[0].LastMessage)
+	assert.Equal(t, "https://example.com/avatar-3.png", result.Chats[0].PartnerImageURL)
+	assert.Equal(t, 0, result.Chats[0].UnreadCount)
+	assert.Equal(t, "user-2", result.Chats[1].PartnerID)
+	assert.Equal(t, 2, result.Chats[1].UnreadCount)
+	// メッセージがまだ無いチャットはマッチした日時で並ぶ
+	assert.Equal(t, "user-0", result.Chats[2].PartnerID)
+	assert.Equal(t, base.Add(time.Hour).Format(time.RFC3339), result.Chats[2].LastMessageAt)
+}
+
+func TestChatService_GetChats_RebuildsMissingSummaryFromHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container, m := newChatReadTestContainer(t, ctrl)
+	ids := chatMessageIDs(3)
+	base := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	m.matching.EXPECT().GetMatchingsByUserID("user-1").Return([]models.Matching{{ID: "match-1", User1ID: "user-1", User2ID: "user-2", CreatedAt: base}}, nil)
+	m.avatar.EXPECT().GetUserAvatarRelationsByUserID("user-1").Return(nil, nil)
+	m.avatar.EXPECT().GetByIDs([]string{}).Return(nil, nil)
+	m.user.EXPECT().GetByIDs([]string{"user-2"}).Return([]models.User{{ID: "user-2", DisplayName: "花子"}}, nil)
+	m.summary.EXPECT().GetChatSummaries(gomock.Any(), "user-1").Return(nil, nil)
+
+	// 集計を導入する前の会話は、履歴と既読位置から1回だけ作り直す
+	m.readCursor.EXPECT().GetByUserID("user-1").Return([]models.ChatReadCursor{
+		{UserID: "user-1", ChatKind: models.ChatKindUser, PartnerID: "user-2", LastReadMessageID: ids[0]},
+	}, nil)
+	m.userChat.EXPECT().GetUserChatMessages(gomock.Any(), "user-1", "user-2", adapter.ChatPage{Limit: 1}).
+		Return([]adapter.UserChatMessage{{ID: ids[2], SenderID: "user-2", Message: "またね", CreatedAt: base.Add(time.Hour)}}, ids[2], nil)
+	m.userChat.EXPECT().CountMessagesFrom(gomock.Any(), "user-1", "user-2", "user-2", ids[0]).Return(2, nil)
+	m.summary.EXPECT().CreateChatSummary(gomock.Any(), "user-1", adapter.ChatSummary{
+		ChatKind:      models.ChatKindUser,
+		PartnerID:     "user-2",
+		LastMessageID: ids[2],
+		LastMessage:   "またね",
+		LastMessageAt: base.Add(time.Hour),
+		UnreadCount:   2,
+	}).Return(nil)
+
+	s := newService[*service.ChatService](t, container, service.NewChatService)
+	result, err := s.GetChats(context.Background(), "user-1")
+
+	require.NoError(t, err)
+	require.Len(t, result.Chats, 1)
+	assert.Equal(t, "またね", result.Chats[0].LastMessage)
+	assert.Equal(t, 2, result.Chats[0].UnreadCount)
+}
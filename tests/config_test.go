@@ -0,0 +1,219 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hackathon-20260110/api/config"
+	"github.com/hackathon-20260110/api/driver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setConfigEnv 起動に必要な値がすべて揃ったdevの環境変数を設定し、overridesで上書きする（空文字は未設定として扱われる）
+func setConfigEnv(t *testing.T, overrides map[string]string) {
+	t.Helper()
+	env := map[string]string{
+		"FLAVOR":                   "dev",
+		"PORT":                     "",
+		"DB_HOST":                  "localhost",
+		"DB_PORT":                  "5432",
+		"DB_USER":                  "postgres",
+		"DB_PASSWORD":              "password",
+		"DB_NAME":                  "app",
+		"DATABASE_URL":             "",
+		"DB_MAX_OPEN_CONNS":        "",
+		"DB_MAX_IDLE_CONNS":        "",
+		"DB_CONN_MAX_LIFETIME":     "",
+		"DB_CONN_MAX_IDLE_TIME":    "",
+		"FIREBASE_SA_PROJECT_ID":   "project",
+		"FIREBASE_SA_PRIVATE_KEY":  "private-key",
+		"FIREBASE_SA_CLIENT_EMAIL": "sa@example.com",
+		"GOOGLE_API_KEY":           "",
+		"GEMINI_API_KEY":           "gemini-key",
+		"R2_ACCESS_KEY_ID":         "access-key",
+		"R2_SECRET_ACCESS_KEY":     "secret-key",
+		"R2_ENDPOINT":              "https://r2.example.com",
+		"R2_PUBLIC_BASE_URL":       "https://cdn.example.com",
+		"LLM_PROVIDER":             "",
+		"PUSH_PROVIDER":            "",
+		"JOB_WORKER_COUNT":         "",
+		"LOCAL_ASSETS_DIR":         "",
+		"AUTH_PROVIDER":            "",
+		"LOCAL_AUTH_ALGORITHM":     "",
+		"LOCAL_AUTH_SECRET":        "",
+		"LOCAL_AUTH_PRIVATE_KEY":   "",
+		"LOCAL_AUTH_PUBLIC_KEY":    "",
+		"LOCAL_AUTH_TOKEN_TTL":     "",
+	}
+	for key, value := range overrides {
+		env[key] = value
+	}
+	for key, value := range env {
+		t.Setenv(key, value)
+	}
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	setConfigEnv(t, nil)
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	assert.Equal(t, config.FlavorDev, cfg.Flavor)
+	assert.Equal(t, "8080", cfg.Port)
+	assert.Equal(t, 10, cfg.Database.MaxOpenConns)
+	assert.Equal(t, 5, cfg.Database.MaxIdleConns)
+	assert.Equal(t, 30*time.Minute, cfg.Database.ConnMaxLifetime)
+	assert.Equal(t, 5*time.Minute, cfg.Database.ConnMaxIdleTime)
+	assert.Equal(t, "gemini-key", cfg.GenAI.APIKey)
+	assert.Equal(t, config.LLMProviderGemini, cfg.LLMProvider)
+	assert.Equal(t, config.PushProviderFCM, cfg.PushProvider)
+	assert.Equal(t, 2, cfg.JobWorkerCount)
+	assert.Equal(t, config.AuthProviderFirebase, cfg.Auth.Provider)
+}
+
+func TestLoad_GoogleAPIKeyTakesPrecedence(t *testing.T) {
+	setConfigEnv(t, map[string]string{"GOOGLE_API_KEY": "google-key"})
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "google-key", cfg.GenAI.APIKey)
+}
+
+func TestLoad_LocalFlavorRunsWithoutExternalServices(t *testing.T) {
+	setConfigEnv(t, map[string]string{
+		"FLAVOR":                   "local",
+		"GEMINI_API_KEY":           "",
+		"FIREBASE_SA_PROJECT_ID":   "",
+		"FIREBASE_SA_PRIVATE_KEY":  "",
+		"FIREBASE_SA_CLIENT_EMAIL": "",
+		"R2_ACCESS_KEY_ID":         "",
+		"R2_SECRET_ACCESS_KEY":     "",
+		"R2_ENDPOINT":              "",
+		"R2_PUBLIC_BASE_URL":       "",
+		"LOCAL_ASSETS_DIR":         "",
+		"LOCAL_AUTH_SECRET":        "",
+		"PORT":                     "3000",
+	})
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	assert.True(t, cfg.IsLocal())
+	assert.Equal(t, config.LLMProviderScripted, cfg.LLMProvider)
+	assert.Equal(t, config.PushProviderRecording, cfg.PushProvider)
+	assert.Equal(t, "http://localhost:3000/local-assets", cfg.R2.PublicBaseURL)
+	assert.Equal(t, "tmp/local-assets", cfg.Local.AssetsDir)
+	assert.Equal(t, config.AuthProviderLocal, cfg.Auth.Provider)
+	assert.Equal(t, config.LocalAuthHS256, cfg.Auth.Local.Algorithm)
+	assert.NotEmpty(t, cfg.Auth.Local.Secret)
+	assert.Equal(t, time.Hour, cfg.Auth.Local.TokenTTL)
+}
+
+func TestLoad_LocalFlavorRejectsFCM(t *testing.T) {
+	setConfigEnv(t, map[string]string{"FLAVOR": "local", "PUSH_PROVIDER": "fcm"})
+
+	_, err := config.Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "PUSH_PROVIDER: fcm is not available in local flavor")
+}
+
+func TestLoad_LocalAuthProvider(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string]string
+		wantErr   string
+	}{
+		{name: "dev with secret", overrides: map[string]string{"AUTH_PROVIDER": "local", "LOCAL_AUTH_SECRET": "secret"}},
+		{name: "dev without secret", overrides: map[string]string{"AUTH_PROVIDER": "local"}, wantErr: "LOCAL_AUTH_SECRET: required"},
+		{name: "RS256 without keys", overrides: map[string]string{"AUTH_PROVIDER": "local", "LOCAL_AUTH_ALGORITHM": "RS256"}, wantErr: "LOCAL_AUTH_PRIVATE_KEY or LOCAL_AUTH_PUBLIC_KEY"},
+		{name: "unknown algorithm", overrides: map[string]string{"AUTH_PROVIDER": "local", "LOCAL_AUTH_SECRET": "secret", "LOCAL_AUTH_ALGORITHM": "none"}, wantErr: "LOCAL_AUTH_ALGORITHM"},
+		{name: "prd", overrides: map[string]string{"FLAVOR": "prd", "DATABASE_URL": "postgres://db", "AUTH_PROVIDER": "local", "LOCAL_AUTH_SECRET": "secret"}, wantErr: "AUTH_PROVIDER"},
+		{name: "firebase in local flavor", overrides: map[string]string{"FLAVOR": "local", "AUTH_PROVIDER": "firebase"}, wantErr: "AUTH_PROVIDER"},
+		{name: "unknown provider", overrides: map[string]string{"AUTH_PROVIDER": "oidc"}, wantErr: `AUTH_PROVIDER: unknown provider "oidc"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setConfigEnv(t, tt.overrides)
+
+			cfg, err := config.Load()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, config.AuthProviderLocal, cfg.Auth.Provider)
+		})
+	}
+}
+
+func TestLoad_AggregatesErrors(t *testing.T) {
+	setConfigEnv(t, map[string]string{
+		"DB_HOST":                 "",
+		"DB_MAX_OPEN_CONNS":       "ten",
+		"DB_CONN_MAX_LIFETIME":    "forever",
+		"FIREBASE_SA_PRIVATE_KEY": "",
+		"GEMINI_API_KEY":          "",
+		"R2_PUBLIC_BASE_URL":      "",
+		"PUSH_PROVIDER":           "apns",
+		"PORT":                    "http",
+	})
+
+	_, err := config.Load()
+	require.Error(t, err)
+	for _, want := range []string{
+		"DB_HOST: required",
+		"DB_MAX_OPEN_CONNS",
+		"DB_CONN_MAX_LIFETIME",
+		"FIREBASE_SA_PRIVATE_KEY: required",
+		"GOOGLE_API_KEY or GEMINI_API_KEY",
+		"R2_PUBLIC_BASE_URL: required",
+		"PUSH_PROVIDER",
+		"PORT",
+	} {
+		assert.Contains(t, err.Error(), want)
+	}
+}
+
+func TestLoad_PrdRequiresDatabaseURL(t *testing.T) {
+	setConfigEnv(t, map[string]string{"FLAVOR": "prd"})
+
+	_, err := config.Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DATABASE_URL: required")
+}
+
+func TestLoad_UnknownFlavor(t *testing.T) {
+	setConfigEnv(t, map[string]string{"FLAVOR": "staging"})
+
+	_, err := config.Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `FLAVOR: unknown flavor "staging"`)
+}
+
+func TestLoadDatabase_IgnoresOtherSettings(t *testing.T) {
+	setConfigEnv(t, map[string]string{
+		"FIREBASE_SA_PROJECT_ID": "",
+		"R2_ACCESS_KEY_ID":       "",
+		"GEMINI_API_KEY":         "",
+	})
+
+	cfg, err := config.LoadDatabase()
+	require.NoError(t, err)
+	assert.Equal(t, "localhost", cfg.Database.Host)
+}
+
+func TestDrivers_ReturnErrorsInsteadOfExiting(t *testing.T) {
+	cfg := &config.Config{Flavor: config.FlavorDev}
+
+	_, err := driver.NewFirestore(cfg)
+	assert.ErrorIs(t, err, driver.ErrFirebaseCredentialsNotSet)
+
+	assert.ErrorIs(t, driver.NewFirebaseAuth(cfg), driver.ErrFirebaseCredentialsNotSet)
+
+	_, err = driver.NewGenAIClient(cfg)
+	assert.ErrorIs(t, err, driver.ErrGenAIAPIKeyNotSet)
+
+	_, err = driver.NewPsql(&config.Config{Flavor: "staging"})
+	assert.Error(t, err)
+}
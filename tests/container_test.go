@@ -0,0 +1,71 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/service"
+	"github.com/hackathon-20260110/api/tests/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+	"go.uber.org/mock/gomock"
+)
+
+// newService テスト用コンテナに登録したモックからサービスを組み立てる
+func newService[T any](t *testing.T, container *dig.Container, constructor interface{}) T {
+	t.Helper()
+	require.NoError(t, container.Provide(constructor))
+	return resolve[T](t, container)
+}
+
+// resolve コンテナに登録済みの値を取り出す
+func resolve[T any](t *testing.T, container *dig.Container) T {
+	t.Helper()
+	var resolved T
+	require.NoError(t, container.Invoke(func(v T) {
+		resolved = v
+	}))
+	return resolved
+}
+
+func newUserServiceBenchmarkContainer(b *testing.B) *dig.Container {
+	ctrl := gomock.NewController(b)
+	mockUserAdapter := mock.NewMockUserAdapter(ctrl)
+	mockUserAdapter.EXPECT().GetByID("user-1").Return(models.User{ID: "user-1", DisplayName: "花子"}, nil).AnyTimes()
+
+	container := dig.New()
+	require.NoError(b, container.Provide(func() adapter.UserAdapter { return mockUserAdapter }))
+	require.NoError(b, container.Provide(func() adapter.R2Adapter { return mock.NewMockR2Adapter(ctrl) }))
+	require.NoError(b, container.Provide(service.NewUserService))
+	return container
+}
+
+// BenchmarkUserService_ResolvePerRequest 以前のように、リクエストのたびにコンテナから依存を解決してサービスを作る場合
+func BenchmarkUserService_ResolvePerRequest(b *testing.B) {
+	container := newUserServiceBenchmarkContainer(b)
+
+	for b.Loop() {
+		if err := container.Invoke(func(ua adapter.UserAdapter, ra adapter.R2Adapter) error {
+			_, err := service.NewUserService(ua, ra).GetUserByID("user-1")
+			return err
+		}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkUserService_Injected 起動時に1度だけ組み立てたサービスを使い回す場合
+func BenchmarkUserService_Injected(b *testing.B) {
+	container := newUserServiceBenchmarkContainer(b)
+	var s *service.UserService
+	require.NoError(b, container.Invoke(func(us *service.UserService) {
+		s = us
+	}))
+
+	for b.Loop() {
+		if _, err := s.GetUserByID("user-1"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
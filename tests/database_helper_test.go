@@ -0,0 +1,32 @@
+package tests
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hackathon-20260110/api/migrations"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// openTestDatabase TEST_DATABASE_URL のPostgresに繋ぎ、マイグレーションを適用したDBを返す。
+// 手元では未設定ならスキップするが、CI（CI=true）で未設定の場合はDBのテストが黙って通らないよう失敗にする
+func openTestDatabase(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		if os.Getenv("CI") == "true" {
+			t.Fatal("TEST_DATABASE_URL must be set in CI")
+		}
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	runner, err := migrations.NewRunner(db)
+	require.NoError(t, err)
+	_, err = runner.Up()
+	require.NoError(t, err)
+	return db
+}
@@ -0,0 +1,276 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/service"
+	"github.com/hackathon-20260110/api/tests/mock"
+	"github.com/hackathon-20260110/api/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// nopNotifier 何も届けないNotifier。通知を確認しないテストで使う
+type nopNotifier struct{}
+
+func (nopNotifier) Notify(ctx context.Context, notification models.Notification) error {
+	return nil
+}
+
+func llmRequestText(req adapter.LLMRequest) string {
+	text := ""
+	for _, msg := range req.Messages {
+		text += msg.Text
+	}
+	return text
+}
+
+func TestDiagnosisService_ExecuteDiagnosis_GeneratesConversation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDiagnosisAdapter := mock.NewMockDiagnosisAdapter(ctrl)
+	mockUserAdapter := mock.NewMockUserAdapter(ctrl)
+	mockUserInfoAdapter := mock.NewMockUserInfoAdapter(ctrl)
+	mockLLMAdapter := mock.NewMockLLMAdapter(ctrl)
+
+	userAvatar := models.Avatar{ID: "avatar-me", UserID: "user-me"}
+	targetAvatar := models.Avatar{ID: "avatar-target", UserID: "user-target"}
+
+	mockDiagnosisAdapter.EXPECT().GetByUserID("user-me").Return(userAvatar, nil)
+	mockDiagnosisAdapter.EXPECT().GetAvatarByID("avatar-target").Return(targetAvatar, nil)
+	mockDiagnosisAdapter.EXPECT().CountDiagnosisHistoriesSince("user-me", "avatar-target", gomock.Any()).Return(int64(0), nil)
+	mockUserAdapter.EXPECT().GetByID("user-me").Return(models.User{ID: "user-me", DisplayName: "太郎"}, nil)
+	mockUserAdapter.EXPECT().GetByID("user-target").Return(models.User{ID: "user-target", DisplayName: "花子"}, nil)
+	mockUserInfoAdapter.EXPECT().GetByUserID("user-me").Return([]*models.UserInfo{
+		{Key: "趣味", Value: "登山", InfoType: models.UserInfoTypeText},
+	}, nil)
+	mockUserInfoAdapter.EXPECT().GetByUserID("user-target").Return([]*models.UserInfo{
+		{Key: "趣味", Value: "料理", InfoType: models.UserInfoTypeText},
+		{Key: "血液型", Value: "秘密のAB型", InfoType: models.UserInfoTypeText, IsMissionReward: true},
+	}, nil)
+
+	var prompts []string
+	mockLLMAdapter.EXPECT().
+		CreateChatCompletion(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, req adapter.LLMRequest) (string, error) {
+			assert.Equal(t, adapter.LLMTaskDiagnosisConversation, req.Task)
+			prompt := llmRequestText(req)
+			prompts = append(prompts, prompt)
+			return "「発言" + string(rune('A'+len(prompts)-1)) + "」", nil
+		}).
+		Times(4)
+	mockLLMAdapter.EXPECT().
+		CreateStructuredCompletion(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, req adapter.LLMRequest, out adapter.LLMStructuredOutput) error {
+			assert.Equal(t, adapter.LLMTaskDiagnosisEvaluation, req.Task)
+			// ユーザーが指定したテーマは採点のプロンプトに入れない
+			assert.NotContains(t, llmRequestText(req), "休日の過ごし方")
+			return json.Unmarshal([]byte(`{"score": 4, "reason": "話が弾んだ", "compatibility_factors": [], "improvement_suggestions": []}`), out)
+		})
+
+	// 履歴の保存とポイント加算は同じトランザクションで行う
+	var savedHistory *models.DiagnosisHistory
+	mockPointEventAdapter := mock.NewMockPointEventAdapter(ctrl)
+	mockPointEventAdapter.EXPECT().
+		ApplyDiagnosisResult(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(history *models.DiagnosisHistory, cooldownSince time.Time, event models.PointEvent) (*adapter.PointChangeResult, error) {
+			savedHistory = history
+			assert.Equal(t, "user-me", history.UserID)
+			assert.Equal(t, "avatar-target", history.TargetAvatarID)
+			assert.WithinDuration(t, time.Now().Add(-service.DiagnosisCooldown), cooldownSince, time.Minute)
+			assert.Equal(t, models.PointEventSourceDiagnosis, event.Source)
+			assert.Equal(t, 60, event.Delta)
+			assert.Contains(t, event.Reason, "話が弾んだ")
+			return &adapter.PointChangeResult{Relation: models.UserAvatarRelation{MatchingPoint: 60}, Event: event}, nil
+		})
+
+	diagnosisService := service.NewDiagnosisService(mockDiagnosisAdapter, mockUserAdapter, mockUserInfoAdapter, mockLLMAdapter, mockPointEventAdapter, nopNotifier{})
+	result, err := diagnosisService.ExecuteDiagnosis(context.Background(), "user-me", "avatar-target", 2, "休日の過ごし方")
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, result.DiagnosisScore)
+	assert.Equal(t, 60, result.PointsEarned)
+
+	// 交互に発言し、相手のミッション報酬項目はプロンプトに含まれない
+	require.Len(t, prompts, 4)
+	assert.Contains(t, prompts[0], "登山")
+	assert.Contains(t, prompts[1], "料理")
+	for _, prompt := range prompts {
+		assert.False(t, strings.Contains(prompt, "秘密のAB型"))
+	}
+
+	require.NotNil(t, savedHistory)
+	var conversation models.DiagnosisConversation
+	require.NoError(t, json.Unmarshal([]byte(savedHistory.ConversationData), &conversation))
+	assert.Equal(t, "休日の過ごし方", conversation.Topic)
+	require.Len(t, conversation.Messages, 4)
+	assert.Equal(t, "avatar-me", conversation.Messages[0].SpeakerAvatarID)
+	assert.Equal(t, "avatar-target", conversation.Messages[1].SpeakerAvatarID)
+	assert.Equal(t, "発言A", conversation.Messages[0].Message)
+	assert.Equal(t, "花子", conversation.Messages[3].SpeakerName)
+}
+
+func TestDiagnosisService_ExecuteDiagnosis_InvalidTurnCount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	diagnosisService := service.NewDiagnosisService(
+		mock.NewMockDiagnosisAdapter(ctrl),
+		mock.NewMockUserAdapter(ctrl),
+		mock.NewMockUserInfoAdapter(ctrl),
+		mock.NewMockLLMAdapter(ctrl),
+		mock.NewMockPointEventAdapter(ctrl),
+		nopNotifier{},
+	)
+
+	_, err := diagnosisService.ExecuteDiagnosis(context.Background(), "user-me", "avatar-target", service.MaxDiagnosisTurnCount+1, "")
+	assert.ErrorIs(t, err, service.ErrInvalidDiagnosisTurnCount)
+}
+
+func TestDiagnosisService_ExecuteDiagnosis_TopicTooLong(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	diagnosisService := service.NewDiagnosisService(
+		mock.NewMockDiagnosisAdapter(ctrl),
+		mock.NewMockUserAdapter(ctrl),
+		mock.NewMockUserInfoAdapter(ctrl),
+		mock.NewMockLLMAdapter(ctrl),
+		mock.NewMockPointEventAdapter(ctrl),
+		nopNotifier{},
+	)
+
+	topic := strings.Repeat("あ", service.MaxDiagnosisTopicRunes+1)
+	_, err := diagnosisService.ExecuteDiagnosis(context.Background(), "user-me", "avatar-target", 0, topic)
+	assert.ErrorIs(t, err, service.ErrInvalidDiagnosisTopic)
+}
+
+func TestDiagnosisService_ExecuteDiagnosis_RejectsRepeatWithinCooldown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDiagnosisAdapter := mock.NewMockDiagnosisAdapter(ctrl)
+	mockDiagnosisAdapter.EXPECT().GetByUserID("user-me").Return(models.Avatar{ID: "avatar-me", UserID: "user-me"}, nil)
+	mockDiagnosisAdapter.EXPECT().GetAvatarByID("avatar-target").Return(models.Avatar{ID: "avatar-target", UserID: "user-target"}, nil)
+	mockDiagnosisAdapter.EXPECT().CountDiagnosisHistoriesSince("user-me", "avatar-target", gomock.Any()).
+		DoAndReturn(func(userID, targetAvatarID string, since time.Time) (int64, error) {
+			assert.WithinDuration(t, time.Now().Add(-service.DiagnosisCooldown), since, time.Minute)
+			return 1, nil
+		})
+
+	// 会話の生成・採点・ポイント加算は行わない
+	diagnosisService := service.NewDiagnosisService(
+		mockDiagnosisAdapter,
+		mock.NewMockUserAdapter(ctrl),
+		mock.NewMockUserInfoAdapter(ctrl),
+		mock.NewMockLLMAdapter(ctrl),
+		mock.NewMockPointEventAdapter(ctrl),
+		nopNotifier{},
+	)
+
+	_, err := diagnosisService.ExecuteDiagnosis(context.Background(), "user-me", "avatar-target", 0, "")
+	assert.ErrorIs(t, err, service.ErrDiagnosisCooldown)
+}
+
+func TestDiagnosisService_ExecuteDiagnosis_RejectsOwnAvatar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDiagnosisAdapter := mock.NewMockDiagnosisAdapter(ctrl)
+	avatar := models.Avatar{ID: "avatar-me", UserID: "user-me"}
+	mockDiagnosisAdapter.EXPECT().GetByUserID("user-me").Return(avatar, nil)
+	mockDiagnosisAdapter.EXPECT().GetAvatarByID("avatar-me").Return(avatar, nil)
+
+	diagnosisService := service.NewDiagnosisService(
+		mockDiagnosisAdapter,
+		mock.NewMockUserAdapter(ctrl),
+		mock.NewMockUserInfoAdapter(ctrl),
+		mock.NewMockLLMAdapter(ctrl),
+		mock.NewMockPointEventAdapter(ctrl),
+		nopNotifier{},
+	)
+
+	_, err := diagnosisService.ExecuteDiagnosis(context.Background(), "user-me", "avatar-me", 0, "")
+	assert.ErrorIs(t, err, service.ErrSelfDiagnosis)
+}
+
+func TestDiagnosisService_ExecuteDiagnosis_InvalidEvaluationDoesNotAwardPoints(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDiagnosisAdapter := mock.NewMockDiagnosisAdapter(ctrl)
+	mockUserAdapter := mock.NewMockUserAdapter(ctrl)
+	mockUserInfoAdapter := mock.NewMockUserInfoAdapter(ctrl)
+
+	mockDiagnosisAdapter.EXPECT().GetByUserID("user-me").Return(models.Avatar{ID: "avatar-me", UserID: "user-me"}, nil)
+	mockDiagnosisAdapter.EXPECT().GetAvatarByID("avatar-target").Return(models.Avatar{ID: "avatar-target", UserID: "user-target"}, nil)
+	mockDiagnosisAdapter.EXPECT().CountDiagnosisHistoriesSince("user-me", "avatar-target", gomock.Any()).Return(int64(0), nil)
+	mockUserAdapter.EXPECT().GetByID(gomock.Any()).Return(models.User{DisplayName: "太郎"}, nil).Times(2)
+	mockUserInfoAdapter.EXPECT().GetByUserID(gomock.Any()).Return(nil, nil).Times(2)
+
+	// スコアが範囲外のまま修正されない。履歴保存・ポイント加算は呼ばれない
+	llmAdapter := adapter.NewScriptedLLMAdapterWithScripts(map[adapter.LLMTask][]string{
+		adapter.LLMTaskDiagnosisEvaluation: {`{"score": 9, "reason": "最高", "compatibility_factors": [], "improvement_suggestions": []}`},
+	})
+
+	diagnosisService := service.NewDiagnosisService(mockDiagnosisAdapter, mockUserAdapter, mockUserInfoAdapter, llmAdapter, mock.NewMockPointEventAdapter(ctrl), nopNotifier{})
+	_, err := diagnosisService.ExecuteDiagnosis(context.Background(), "user-me", "avatar-target", 1, "")
+
+	assert.ErrorIs(t, err, adapter.ErrLLMInvalidStructuredOutput)
+}
+
+func TestDiagnosisService_ExecuteDiagnosis_TargetAvatarNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDiagnosisAdapter := mock.NewMockDiagnosisAdapter(ctrl)
+	mockDiagnosisAdapter.EXPECT().GetByUserID("user-me").Return(models.Avatar{ID: "avatar-me", UserID: "user-me"}, nil)
+	mockDiagnosisAdapter.EXPECT().GetAvatarByID("avatar-missing").Return(models.Avatar{}, gorm.ErrRecordNotFound)
+
+	diagnosisService := service.NewDiagnosisService(
+		mockDiagnosisAdapter,
+		mock.NewMockUserAdapter(ctrl),
+		mock.NewMockUserInfoAdapter(ctrl),
+		mock.NewMockLLMAdapter(ctrl),
+		mock.NewMockPointEventAdapter(ctrl),
+		nopNotifier{},
+	)
+
+	_, err := diagnosisService.ExecuteDiagnosis(context.Background(), "user-me", "avatar-missing", 0, "")
+	assert.ErrorIs(t, err, utils.ErrorRecordNotFound)
+}
+
+func TestDiagnosisService_ExecuteDiagnosis_ConcurrentRepeatLosesAtSave(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDiagnosisAdapter := mock.NewMockDiagnosisAdapter(ctrl)
+	mockUserAdapter := mock.NewMockUserAdapter(ctrl)
+	mockUserInfoAdapter := mock.NewMockUserInfoAdapter(ctrl)
+
+	mockDiagnosisAdapter.EXPECT().GetByUserID("user-me").Return(models.Avatar{ID: "avatar-me", UserID: "user-me"}, nil)
+	mockDiagnosisAdapter.EXPECT().GetAvatarByID("avatar-target").Return(models.Avatar{ID: "avatar-target", UserID: "user-target"}, nil)
+	mockDiagnosisAdapter.EXPECT().CountDiagnosisHistoriesSince("user-me", "avatar-target", gomock.Any()).Return(int64(0), nil)
+	mockUserAdapter.EXPECT().GetByID(gomock.Any()).Return(models.User{DisplayName: "太郎"}, nil).Times(2)
+	mockUserInfoAdapter.EXPECT().GetByUserID(gomock.Any()).Return(nil, nil).Times(2)
+
+	// 事前の確認の後に別のリクエストが先に保存した場合、保存時の確認で断られポイントも入らない
+	mockPointEventAdapter := mock.NewMockPointEventAdapter(ctrl)
+	mockPointEventAdapter.EXPECT().
+		ApplyDiagnosisResult(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, adapter.ErrRecentDiagnosisExists)
+
+	diagnosisService := service.NewDiagnosisService(mockDiagnosisAdapter, mockUserAdapter, mockUserInfoAdapter, adapter.NewScriptedLLMAdapter(), mockPointEventAdapter, nopNotifier{})
+	_, err := diagnosisService.ExecuteDiagnosis(context.Background(), "user-me", "avatar-target", 1, "")
+
+	assert.ErrorIs(t, err, service.ErrDiagnosisCooldown)
+}
@@ -0,0 +1,71 @@
+package tests
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestJobAdapter_LeaseDeadLettersExhaustedJobAndLeasesNext 回数を使い切った期限切れのジョブをデッドレターにしても、
+// 同じ呼び出しで次の実行可能なジョブを返すことを実際のPostgresで確かめる。TEST_DATABASE_URL が必要（CIでは必須）
+func TestJobAdapter_LeaseDeadLettersExhaustedJobAndLeasesNext(t *testing.T) {
+	db := openTestDatabase(t)
+
+	// 他のテストのジョブを取らないよう、このテストだけの種類にする
+	jobType := models.JobType("lease_test_" + utils.GenerateULID())
+	jobAdapter := adapter.NewJobAdapter(db)
+	expired := time.Now().Add(-time.Minute)
+	exhausted := models.Job{Type: jobType, SubjectID: "exhausted", Status: models.JobStatusRunning, Attempts: 3, MaxAttempts: 3, RunAt: expired.Add(-time.Hour), LeasedBy: "crashed", LeasedUntil: &expired}
+	require.NoError(t, jobAdapter.Enqueue(&exhausted))
+	queued := models.Job{Type: jobType, SubjectID: "queued", RunAt: expired}
+	require.NoError(t, jobAdapter.Enqueue(&queued))
+
+	leased, err := jobAdapter.Lease("worker-1", []models.JobType{jobType}, time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, leased)
+	assert.Equal(t, queued.ID, leased.ID)
+	assert.Equal(t, 1, leased.Attempts)
+
+	var dead models.Job
+	require.NoError(t, db.First(&dead, "id = ?", exhausted.ID).Error)
+	assert.Equal(t, models.JobStatusDead, dead.Status)
+	assert.Equal(t, "lease expired", dead.LastError)
+	assert.Nil(t, dead.LeasedUntil)
+}
+
+// TestJobAdapter_EnqueueIfNotActiveDedupesSummaryJobs 同じ会話の要約ジョブは、実行待ち・実行中のものがある間は
+// 同時に登録しても1件だけになることを確かめる。TEST_DATABASE_URL が必要（CIでは必須）
+func TestJobAdapter_EnqueueIfNotActiveDedupesSummaryJobs(t *testing.T) {
+	db := openTestDatabase(t)
+	jobAdapter := adapter.NewJobAdapter(db)
+	subjectID := utils.GenerateULID() + ":" + utils.GenerateULID()
+
+	var wg sync.WaitGroup
+	var enqueued atomic.Int32
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := jobAdapter.EnqueueIfNotActive(&models.Job{Type: models.JobTypeAvatarChatSummary, SubjectID: subjectID})
+			if assert.NoError(t, err) && ok {
+				enqueued.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), enqueued.Load())
+
+	// 終わったジョブは数えないので、次の要約は登録できる
+	require.NoError(t, db.Model(&models.Job{}).Where("type = ? AND subject_id = ?", models.JobTypeAvatarChatSummary, subjectID).
+		Update("status", models.JobStatusSucceeded).Error)
+	ok, err := jobAdapter.EnqueueIfNotActive(&models.Job{Type: models.JobTypeAvatarChatSummary, SubjectID: subjectID})
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
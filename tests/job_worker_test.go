@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/tests/mock"
+	"github.com/hackathon-20260110/api/worker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPool_RunOnce_CompletesSucceededJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockJobAdapter := mock.NewMockJobAdapter(ctrl)
+	job := &models.Job{ID: "job-1", Type: models.JobTypeOnboardingReply, Attempts: 1, MaxAttempts: 5}
+	mockJobAdapter.EXPECT().Lease("worker-1", []models.JobType{models.JobTypeOnboardingReply}, gomock.Any()).Return(job, nil)
+	mockJobAdapter.EXPECT().Complete("job-1", "worker-1").Return(nil)
+
+	handled := 0
+	pool := worker.NewPool(mockJobAdapter, 1)
+	pool.Register(models.JobTypeOnboardingReply, func(ctx context.Context, job models.Job) error {
+		handled++
+		return nil
+	})
+
+	processed, err := pool.RunOnce(context.Background(), "worker-1")
+
+	require.NoError(t, err)
+	assert.True(t, processed)
+	assert.Equal(t, 1, handled)
+}
+
+func TestPool_RunOnce_RetriesWithBackoff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockJobAdapter := mock.NewMockJobAdapter(ctrl)
+	job := &models.Job{ID: "job-1", Type: models.JobTypeOnboardingReply, Attempts: 2, MaxAttempts: 5}
+	mockJobAdapter.EXPECT().Lease("worker-1", gomock.Any(), gomock.Any()).Return(job, nil)
+	before := time.Now()
+	mockJobAdapter.EXPECT().Fail("job-1", "worker-1", "llm unavailable", gomock.Not(gomock.Nil())).
+		DoAndReturn(func(jobID, workerID, lastError string, retryAt *time.Time) error {
+			assert.WithinDuration(t, before.Add(worker.RetryDelay(2)), *retryAt, time.Second)
+			return nil
+		})
+
+	pool := worker.NewPool(mockJobAdapter, 1)
+	pool.Register(models.JobTypeOnboardingReply, func(ctx context.Context, job models.Job) error {
+		return errors.New("llm unavailable")
+	})
+
+	processed, err := pool.RunOnce(context.Background(), "worker-1")
+
+	require.NoError(t, err)
+	assert.True(t, processed)
+}
+
+func TestPool_RunOnce_DeadLettersAfterMaxAttempts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockJobAdapter := mock.NewMockJobAdapter(ctrl)
+	job := &models.Job{ID: "job-1", Type: models.JobTypeOnboardingReply, Attempts: 5, MaxAttempts: 5}
+	mockJobAdapter.EXPECT().Lease("worker-1", gomock.Any(), gomock.Any()).Return(job, nil)
+	mockJobAdapter.EXPECT().Fail("job-1", "worker-1", gomock.Any(), gomock.Nil()).Return(nil)
+
+	pool := worker.NewPool(mockJobAdapter, 1)
+	pool.Register(models.JobTypeOnboardingReply, func(ctx context.Context, job models.Job) error {
+		panic("unexpected")
+	})
+
+	processed, err := pool.RunOnce(context.Background(), "worker-1")
+
+	require.NoError(t, err)
+	assert.True(t, processed)
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, 5*time.Second, worker.RetryDelay(1))
+	assert.Equal(t, 10*time.Second, worker.RetryDelay(2))
+	assert.Equal(t, 40*time.Second, worker.RetryDelay(4))
+	assert.Equal(t, 5*time.Minute, worker.RetryDelay(20))
+}
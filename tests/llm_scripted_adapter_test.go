@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScriptedLLMAdapter_IsDeterministic(t *testing.T) {
+	llm := adapter.NewScriptedLLMAdapter()
+	req := adapter.NewLLMPromptRequest(adapter.LLM_MODEL_TYPE_GEMINI2_5_FLASH, adapter.LLMTaskAvatarChat, "こんにちは")
+
+	first, err := llm.CreateChatCompletion(context.Background(), req)
+	require.NoError(t, err)
+	second, err := llm.CreateChatCompletion(context.Background(), req)
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+}
+
+func TestScriptedLLMAdapter_ReturnsValidJSONForStructuredTasks(t *testing.T) {
+	llm := adapter.NewScriptedLLMAdapter()
+	ctx := context.Background()
+
+	avatarResp, err := llm.CreateChatCompletion(ctx, adapter.NewLLMPromptRequest(adapter.LLM_MODEL_TYPE_GEMINI2_5_FLASH, adapter.LLMTaskAvatarChat, "prompt"))
+	require.NoError(t, err)
+	var avatarResult struct {
+		Message     string `json:"message"`
+		PointChange int    `json:"point_change"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(avatarResp), &avatarResult))
+	assert.NotEmpty(t, avatarResult.Message)
+	assert.GreaterOrEqual(t, avatarResult.PointChange, -10)
+	assert.LessOrEqual(t, avatarResult.PointChange, 10)
+
+	diagnosisResp, err := llm.CreateChatCompletionJSON(ctx, adapter.NewLLMPromptRequest(adapter.LLM_MODEL_TYPE_GEMINI2_0, adapter.LLMTaskDiagnosisEvaluation, "prompt"))
+	require.NoError(t, err)
+	var diagnosisResult struct {
+		Score int `json:"score"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(diagnosisResp), &diagnosisResult))
+	assert.GreaterOrEqual(t, diagnosisResult.Score, 1)
+	assert.LessOrEqual(t, diagnosisResult.Score, 5)
+
+	extractionResp, err := llm.CreateChatCompletion(ctx, adapter.NewLLMPromptRequest(adapter.LLM_MODEL_TYPE_GEMINI2_5_FLASH, adapter.LLMTaskOnboardingExtraction, "prompt"))
+	require.NoError(t, err)
+	var extractionResult struct {
+		Items []struct {
+			Key   string `json:"key"`
+			Value string `json:"value"`
+		} `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(extractionResp), &extractionResult))
+	assert.NotEmpty(t, extractionResult.Items)
+}
+
+func TestScriptedLLMAdapter_OnboardingJudgeCountsAnswers(t *testing.T) {
+	llm := adapter.NewScriptedLLMAdapter()
+	ctx := context.Background()
+
+	resp, err := llm.CreateChatCompletion(ctx, adapter.NewLLMPromptRequest(adapter.LLM_MODEL_TYPE_GEMINI2_5_FLASH, adapter.LLMTaskOnboardingJudge,
+		"システム: 「趣味は？」\nユーザー: 「登山です」\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "FALSE", resp)
+
+	resp, err = llm.CreateChatCompletion(ctx, adapter.NewLLMPromptRequest(adapter.LLM_MODEL_TYPE_GEMINI2_5_FLASH, adapter.LLMTaskOnboardingJudge,
+		"ユーザー: 「登山です」\nユーザー: 「エンジニアです」\nユーザー: 「誠実な人が好きです」\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "TRUE", resp)
+}
+
+func TestScriptedLLMAdapter_WithScripts(t *testing.T) {
+	llm := adapter.NewScriptedLLMAdapterWithScripts(map[adapter.LLMTask][]string{
+		adapter.LLMTaskDiagnosisConversation: {"一言目", "二言目"},
+	})
+	ctx := context.Background()
+	req := adapter.NewLLMPromptRequest(adapter.LLM_MODEL_TYPE_GEMINI2_5_FLASH, adapter.LLMTaskDiagnosisConversation, "prompt")
+
+	var got []string
+	for i := 0; i < 3; i++ {
+		resp, err := llm.CreateChatCompletion(ctx, req)
+		require.NoError(t, err)
+		got = append(got, resp)
+	}
+
+	assert.Equal(t, []string{"一言目", "二言目", "二言目"}, got)
+}
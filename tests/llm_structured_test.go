@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateStructuredCompletion_RepairsInvalidOutput(t *testing.T) {
+	llm := adapter.NewScriptedLLMAdapterWithScripts(map[adapter.LLMTask][]string{
+		adapter.LLMTaskAvatarChat: {
+			"うまくJSONにできませんでした",
+			`{"message": "こんにちは", "point_change": 25, "reason": "盛り上がった"}`,
+			"```json\n{\"message\": \"こんにちは\", \"point_change\": 7, \"reason\": \"盛り上がった\"}\n```",
+		},
+	})
+	req := adapter.NewLLMPromptRequest(adapter.LLM_MODEL_TYPE_GEMINI2_5_FLASH, adapter.LLMTaskAvatarChat, "prompt")
+
+	var out service.LLMChatResponse
+	require.NoError(t, llm.CreateStructuredCompletion(context.Background(), req, &out))
+
+	assert.Equal(t, "こんにちは", out.Message)
+	assert.Equal(t, 7, out.PointChange)
+}
+
+func TestCreateStructuredCompletion_ReturnsTypedErrorAfterRetries(t *testing.T) {
+	llm := adapter.NewScriptedLLMAdapterWithScripts(map[adapter.LLMTask][]string{
+		adapter.LLMTaskAvatarChat: {`{"message": "こんにちは", "point_change": -30, "reason": "失礼"}`},
+	})
+	req := adapter.NewLLMPromptRequest(adapter.LLM_MODEL_TYPE_GEMINI2_5_FLASH, adapter.LLMTaskAvatarChat, "prompt")
+	req.MaxRepairAttempts = 1
+
+	var out service.LLMChatResponse
+	err := llm.CreateStructuredCompletion(context.Background(), req, &out)
+
+	require.ErrorIs(t, err, adapter.ErrLLMInvalidStructuredOutput)
+	var outputErr *adapter.LLMStructuredOutputError
+	require.True(t, errors.As(err, &outputErr))
+	assert.Equal(t, 2, outputErr.Attempts)
+	assert.Equal(t, adapter.LLMTaskAvatarChat, outputErr.Task)
+	assert.Contains(t, outputErr.Err.Error(), "point_change")
+}
+
+func TestCreateStructuredCompletion_RejectsMissingRequiredField(t *testing.T) {
+	llm := adapter.NewScriptedLLMAdapterWithScripts(map[adapter.LLMTask][]string{
+		adapter.LLMTaskAvatarChat: {`{"message": "こんにちは", "reason": "普通"}`},
+	})
+	req := adapter.NewLLMPromptRequest(adapter.LLM_MODEL_TYPE_GEMINI2_5_FLASH, adapter.LLMTaskAvatarChat, "prompt")
+
+	var out service.LLMChatResponse
+	err := llm.CreateStructuredCompletion(context.Background(), req, &out)
+
+	assert.ErrorIs(t, err, adapter.ErrLLMInvalidStructuredOutput)
+}
+
+func TestCreateStructuredCompletion_ScriptedDefaultsAreValid(t *testing.T) {
+	llm := adapter.NewScriptedLLMAdapter()
+	req := adapter.NewLLMPromptRequest(adapter.LLM_MODEL_TYPE_GEMINI2_5_FLASH, adapter.LLMTaskAvatarChat, "prompt")
+
+	var out service.LLMChatResponse
+	require.NoError(t, llm.CreateStructuredCompletion(context.Background(), req, &out))
+	assert.NotEmpty(t, out.Message)
+}
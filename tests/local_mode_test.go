@@ -0,0 +1,162 @@
+package tests
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/config"
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func avatarMessageIDs(messages []adapter.AvatarChatMessage) []string {
+	ids := make([]string, len(messages))
+	for i, message := range messages {
+		ids[i] = message.ID
+	}
+	return ids
+}
+
+func TestInMemoryAvatarChatAdapter_PagesLikeFirestore(t *testing.T) {
+	ctx := context.Background()
+	store := adapter.NewInMemoryStore()
+	chats := adapter.NewInMemoryAvatarChatAdapter(store)
+
+	// 挿入順に関係なくIDの順に並ぶ
+	for _, id := range []string{"03", "01", "05", "02", "04"} {
+		senderType := models.SenderTypeUser
+		if id == "02" || id == "04" {
+			senderType = models.SenderTypeAvatarAI
+		}
+		require.NoError(t, chats.CreateAvatarChatMessage(ctx, "user-1", "avatar-1", adapter.AvatarChatMessage{ID: id, SenderType: senderType, Message: "m" + id}))
+	}
+
+	tests := []struct {
+		name       string
+		page       adapter.ChatPage
+		wantIDs    []string
+		wantCursor string
+	}{
+		{name: "all", page: adapter.ChatPage{}, wantIDs: []string{"01", "02", "03", "04", "05"}},
+		{name: "latest", page: adapter.ChatPage{Limit: 2}, wantIDs: []string{"04", "05"}, wantCursor: "04"},
+		{name: "before", page: adapter.ChatPage{Limit: 2, Before: "04"}, wantIDs: []string{"02", "03"}, wantCursor: "02"},
+		{name: "before without limit", page: adapter.ChatPage{Before: "03"}, wantIDs: []string{"01", "02"}},
+		{name: "after", page: adapter.ChatPage{Limit: 2, After: "01"}, wantIDs: []string{"02", "03"}, wantCursor: "03"},
+		{name: "after last page", page: adapter.ChatPage{Limit: 2, After: "03"}, wantIDs: []string{"04", "05"}},
+		{name: "from oldest", page: adapter.ChatPage{Limit: 3, FromOldest: true}, wantIDs: []string{"01", "02", "03"}, wantCursor: "03"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messages, cursor, err := chats.GetAvatarChatMessages(ctx, "user-1", "avatar-1", tt.page)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantIDs, avatarMessageIDs(messages))
+			assert.Equal(t, tt.wantCursor, cursor)
+		})
+	}
+
+	count, err := chats.CountAvatarMessages(ctx, "user-1", "avatar-1", "02")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestInMemoryUserChatAdapter_UpdatesChatSummaries(t *testing.T) {
+	ctx := context.Background()
+	store := adapter.NewInMemoryStore()
+	chats := adapter.NewInMemoryUserChatAdapter(store)
+	summaries := adapter.NewInMemoryChatSummaryAdapter(store)
+
+	require.NoError(t, chats.CreateUserChatMessage(ctx, "user-b", "user-a", adapter.UserChatMessage{ID: "01", SenderID: "user-a", Message: "hello"}))
+	require.NoError(t, chats.CreateUserChatMessage(ctx, "user-a", "user-b", adapter.UserChatMessage{ID: "02", SenderID: "user-a", Message: "again"}))
+
+	// 2人のどちらから見ても同じ会話
+	messages, _, err := chats.GetUserChatMessages(ctx, "user-b", "user-a", adapter.ChatPage{})
+	require.NoError(t, err)
+	assert.Len(t, messages, 2)
+
+	received, err := summaries.GetChatSummaries(ctx, "user-b")
+	require.NoError(t, err)
+	require.Len(t, received, 1)
+	assert.Equal(t, "user-a", received[0].PartnerID)
+	assert.Equal(t, "again", received[0].LastMessage)
+	assert.Equal(t, 2, received[0].UnreadCount)
+
+	sent, err := summaries.GetChatSummaries(ctx, "user-a")
+	require.NoError(t, err)
+	require.Len(t, sent, 1)
+	assert.Equal(t, 0, sent[0].UnreadCount)
+
+	require.NoError(t, summaries.SetUnreadCount(ctx, "user-b", models.ChatKindUser, "user-a", 0))
+	received, err = summaries.GetChatSummaries(ctx, "user-b")
+	require.NoError(t, err)
+	assert.Equal(t, 0, received[0].UnreadCount)
+
+	// 既にある集計は作り直さない
+	require.NoError(t, summaries.CreateChatSummary(ctx, "user-b", adapter.ChatSummary{ChatKind: models.ChatKindUser, PartnerID: "user-a", LastMessage: "stale"}))
+	received, err = summaries.GetChatSummaries(ctx, "user-b")
+	require.NoError(t, err)
+	assert.Equal(t, "again", received[0].LastMessage)
+}
+
+func TestInMemoryNotificationAdapter(t *testing.T) {
+	ctx := context.Background()
+	notifications := adapter.NewInMemoryNotificationAdapter(adapter.NewInMemoryStore())
+	base := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	for i, id := range []string{"n1", "n2", "n3"} {
+		require.NoError(t, notifications.CreateNotification(ctx, "user-1", models.Notification{ID: id, UserID: "user-1", Title: id, CreatedAt: base.Add(time.Duration(i) * time.Minute)}))
+	}
+
+	page, cursor, err := notifications.ListNotifications(ctx, "user-1", "", 2)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, "n3", page[0].ID)
+	assert.Equal(t, models.NotificationTypeSystem, page[0].Type)
+	assert.Equal(t, "n2", cursor)
+
+	page, cursor, err = notifications.ListNotifications(ctx, "user-1", cursor, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, "n1", page[0].ID)
+	assert.Empty(t, cursor)
+
+	_, _, err = notifications.ListNotifications(ctx, "user-1", "missing", 2)
+	assert.ErrorIs(t, err, utils.ErrorRecordNotFound)
+
+	require.NoError(t, notifications.MarkAsRead(ctx, "user-1", "n1"))
+	unread, err := notifications.CountUnread(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), unread)
+
+	updated, err := notifications.MarkAllAsRead(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated)
+
+	require.NoError(t, notifications.DeleteNotification(ctx, "user-1", "n1"))
+	assert.ErrorIs(t, notifications.DeleteNotification(ctx, "user-1", "n1"), utils.ErrorRecordNotFound)
+}
+
+func TestLocalR2Adapter_WritesUnderAssetsDir(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{
+		R2:    config.R2Config{PublicBaseURL: "http://localhost:8080/local-assets"},
+		Local: config.LocalConfig{AssetsDir: dir},
+	}
+	r2 := adapter.NewLocalR2Adapter(cfg)
+
+	url, err := r2.UploadImage([]byte("image"), "/users/user-1/avatar.png", "image/png")
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080/local-assets/users/user-1/avatar.png", url)
+
+	data, err := os.ReadFile(filepath.Join(dir, "users", "user-1", "avatar.png"))
+	require.NoError(t, err)
+	assert.Equal(t, "image", string(data))
+
+	_, err = r2.UploadImage([]byte("image"), "../escape.png", "image/png")
+	assert.Error(t, err)
+}
@@ -0,0 +1,311 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/service"
+	"github.com/hackathon-20260110/api/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const concurrentSends = 20
+
+// memoryPointEventAdapter トランザクションをmutexで置き換えたPointEventAdapter。
+// サービスがアダプターの外で読み書きしていないことを確かめるために使う
+type memoryPointEventAdapter struct {
+	mu               sync.Mutex
+	missions         []models.Mission
+	relation         *models.UserAvatarRelation
+	events           []models.PointEvent
+	matchings        int
+	unlockedMissions map[string]bool
+}
+
+func (a *memoryPointEventAdapter) ApplyPointChange(userID, avatarID string, event models.PointEvent) (*adapter.PointChangeResult, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.relation == nil {
+		a.relation = &models.UserAvatarRelation{ID: utils.GenerateULID(), UserID: userID, AvatarID: avatarID}
+	}
+
+	newPoint := a.relation.MatchingPoint + event.Delta
+	if newPoint > models.MaxMatchingPoint {
+		newPoint = models.MaxMatchingPoint
+	}
+	if newPoint < 0 {
+		newPoint = 0
+	}
+	event.Delta = newPoint - a.relation.MatchingPoint
+	event.MatchingPointAfter = newPoint
+	a.relation.MatchingPoint = newPoint
+	a.events = append(a.events, event)
+
+	result := &adapter.PointChangeResult{Relation: *a.relation, Event: event}
+	if newPoint >= models.MaxMatchingPoint {
+		if a.matchings == 0 {
+			result.NewMatching = &models.Matching{ID: utils.GenerateULID()}
+		}
+		a.matchings = 1
+	}
+	result.IsMatched = a.matchings > 0
+
+	for _, mission := range a.missions {
+		if mission.ThresholdPointCondition != nil && newPoint >= *mission.ThresholdPointCondition && !a.unlockedMissions[mission.ID] {
+			a.unlockedMissions[mission.ID] = true
+			result.UnlockedMissions = append(result.UnlockedMissions, mission)
+		}
+	}
+	return result, nil
+}
+
+func (a *memoryPointEventAdapter) ApplyAdminAdjustment(adminUserID, userID, avatarID string, event models.PointEvent) (*adapter.PointChangeResult, *models.PointAdjustmentAudit, error) {
+	result, err := a.ApplyPointChange(userID, avatarID, event)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, &models.PointAdjustmentAudit{ID: utils.GenerateULID(), AdminUserID: adminUserID, PointEventID: result.Event.ID}, nil
+}
+
+func (a *memoryPointEventAdapter) ApplyDiagnosisResult(history *models.DiagnosisHistory, cooldownSince time.Time, event models.PointEvent) (*adapter.PointChangeResult, error) {
+	return a.ApplyPointChange(history.UserID, history.TargetAvatarID, event)
+}
+
+func (a *memoryPointEventAdapter) GetPointEventsByRelationID(relationID string, limit, offset int) ([]models.PointEvent, int64, error) {
+	return nil, 0, nil
+}
+
+func (a *memoryPointEventAdapter) SumDeltaBySource(relationID string) (map[models.PointEventSource]int, error) {
+	return nil, nil
+}
+
+func TestAvatarChatService_ConcurrentSendsDoNotLoseUpdates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	llm := adapter.NewScriptedLLMAdapterWithScripts(map[adapter.LLMTask][]string{
+		adapter.LLMTaskAvatarChat: {`{"message": "いいですね！", "point_change": 10, "reason": "会話が弾んだ"}`},
+	})
+	container, m := newAvatarChatTestContainer(t, ctrl, llm)
+
+	threshold := 50
+	missions := []models.Mission{{ID: "mission-1", MissionOwnerUserID: "owner", UserInfoID: "info-1", ThresholdPointCondition: &threshold}}
+	pointEvents := &memoryPointEventAdapter{missions: missions, unlockedMissions: map[string]bool{}}
+	require.NoError(t, container.Decorate(func(adapter.PointEventAdapter) adapter.PointEventAdapter { return pointEvents }))
+
+	m.avatar.EXPECT().GetByID("avatar-1").Return(&models.Avatar{ID: "avatar-1", UserID: "owner"}, nil).AnyTimes()
+	m.user.EXPECT().GetByID("owner").Return(models.User{ID: "owner", DisplayName: "花子"}, nil).AnyTimes()
+	m.user.EXPECT().GetByID("user-1").Return(models.User{ID: "user-1", DisplayName: "太郎"}, nil).AnyTimes()
+	m.userInfo.EXPECT().GetByUserID("owner").Return(nil, nil).AnyTimes()
+	m.userInfo.EXPECT().GetByID("info-1").Return(&models.UserInfo{ID: "info-1", Key: "趣味", Value: "登山"}, nil).Times(1)
+	m.avatarChat.EXPECT().CreateAvatarChatMessage(gomock.Any(), "user-1", "avatar-1", gomock.Any()).Return(nil).AnyTimes()
+	m.avatarChat.EXPECT().GetAvatarChatMessages(gomock.Any(), "user-1", "avatar-1", gomock.Any()).Return(nil, "", nil).AnyTimes()
+	m.memory.EXPECT().Get("user-1", "avatar-1").Return(&models.AvatarChatMemory{UserID: "user-1", AvatarID: "avatar-1"}, nil).AnyTimes()
+	m.mission.EXPECT().GetMissionsByOwnerUserID("owner").Return(missions, nil).AnyTimes()
+	m.mission.EXPECT().GetMissionUnlocksByUserID("user-1").Return(nil, nil).AnyTimes()
+	// マッチング成立の通知は双方に、ミッション解放の通知は解禁したユーザーに1回ずつだけ
+	var notifiedTypesMu sync.Mutex
+	var notifiedTypes []models.NotificationType
+	m.notification.EXPECT().CreateNotification(gomock.Any(), "user-1", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, userID string, notification models.Notification) error {
+			notifiedTypesMu.Lock()
+			defer notifiedTypesMu.Unlock()
+			notifiedTypes = append(notifiedTypes, notification.Type)
+			return nil
+		}).Times(2)
+	m.notification.EXPECT().CreateNotification(gomock.Any(), "owner", gomock.Any()).Return(nil).Times(1)
+
+	s := newService[*service.AvatarChatService](t, container, service.NewAvatarChatService)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	matchedResults := 0
+	unlockedResults := 0
+	for i := 0; i < concurrentSends; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := s.SendMessage(context.Background(), "user-1", "avatar-1", "こんにちは")
+			if !assert.NoError(t, err) {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if result.IsMatched {
+				matchedResults++
+			}
+			unlockedResults += len(result.UnlockedMissions)
+		}()
+	}
+	wg.Wait()
+
+	require.NotNil(t, pointEvents.relation)
+	assert.Equal(t, models.MaxMatchingPoint, pointEvents.relation.MatchingPoint)
+	assert.Len(t, pointEvents.events, concurrentSends)
+
+	// 上限で切り詰められた分は記録されず、履歴の合計が現在のポイントと一致する
+	total := 0
+	for _, event := range pointEvents.events {
+		total += event.Delta
+	}
+	assert.Equal(t, models.MaxMatchingPoint, total)
+	assert.Equal(t, 1, unlockedResults)
+	assert.ElementsMatch(t, []models.NotificationType{models.NotificationTypeMatch, models.NotificationTypeMissionUnlock}, notifiedTypes)
+	assert.GreaterOrEqual(t, matchedResults, 1)
+}
+
+// TestPointEventAdapter_ConcurrentApplyPointChange 実際のPostgresとマイグレーションで作ったスキーマで、行ロックと制約を確かめる。
+// TEST_DATABASE_URL が必要（CIでは必須）
+func TestPointEventAdapter_ConcurrentApplyPointChange(t *testing.T) {
+	db := openTestDatabase(t)
+
+	userID := utils.GenerateULID()
+	ownerID := utils.GenerateULID()
+	require.NoError(t, db.Create(&[]models.User{{ID: userID}, {ID: ownerID}}).Error)
+	avatar := models.Avatar{ID: utils.GenerateULID(), UserID: ownerID, PersonalityTraits: "{}"}
+	require.NoError(t, db.Create(&avatar).Error)
+	userInfo := models.UserInfo{ID: utils.GenerateULID(), UserID: ownerID, InfoType: models.UserInfoTypeText, Key: "趣味", Value: "登山"}
+	require.NoError(t, db.Create(&userInfo).Error)
+	threshold := 50
+	mission := models.Mission{ID: utils.GenerateULID(), MissionOwnerUserID: ownerID, UserInfoID: userInfo.ID, ThresholdPointCondition: &threshold}
+	require.NoError(t, db.Create(&mission).Error)
+
+	pointEventAdapter := adapter.NewPointEventAdapter(db)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	newMatchings := 0
+	unlocked := 0
+	for i := 0; i < concurrentSends; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := pointEventAdapter.ApplyPointChange(userID, avatar.ID, models.PointEvent{
+				Source: models.PointEventSourceAvatarChat,
+				Delta:  10,
+				Reason: "concurrency test",
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if result.NewMatching != nil {
+				newMatchings++
+			}
+			unlocked += len(result.UnlockedMissions)
+		}()
+	}
+	wg.Wait()
+
+	var relations []models.UserAvatarRelation
+	require.NoError(t, db.Where("user_id = ? AND avatar_id = ?", userID, avatar.ID).Find(&relations).Error)
+	require.Len(t, relations, 1)
+	assert.Equal(t, models.MaxMatchingPoint, relations[0].MatchingPoint)
+
+	var deltaSum int
+	require.NoError(t, db.Model(&models.PointEvent{}).Select("COALESCE(SUM(delta), 0)").Where("relation_id = ?", relations[0].ID).Scan(&deltaSum).Error)
+	assert.Equal(t, models.MaxMatchingPoint, deltaSum)
+
+	var matchingCount, unlockCount int64
+	require.NoError(t, db.Model(&models.Matching{}).Where("user1_id IN ? AND user2_id IN ?", []string{userID, ownerID}, []string{userID, ownerID}).Count(&matchingCount).Error)
+	require.NoError(t, db.Model(&models.MissionUnlock{}).Where("mission_id = ? AND unlocked_user_id = ?", mission.ID, userID).Count(&unlockCount).Error)
+	assert.Equal(t, int64(1), matchingCount)
+	assert.Equal(t, int64(1), unlockCount)
+	assert.Equal(t, 1, newMatchings)
+	assert.Equal(t, 1, unlocked)
+}
+
+// TestPointEventAdapter_CreatesMatchingForMixedCaseUserIDs 大文字小文字の混ざったIDでも、
+// DBの照合順序に関係なくマッチングを作れることを確かめる。TEST_DATABASE_URL が必要（CIでは必須）
+func TestPointEventAdapter_CreatesMatchingForMixedCaseUserIDs(t *testing.T) {
+	db := openTestDatabase(t)
+
+	// バイト順では "Z..." < "a..." だが、en_US.utf8 では "a..." < "Z..." になる
+	suffix := utils.GenerateULID()
+	userID := "Z" + suffix
+	ownerID := "a" + suffix
+	require.NoError(t, db.Create(&[]models.User{{ID: userID}, {ID: ownerID}}).Error)
+	avatar := models.Avatar{ID: utils.GenerateULID(), UserID: ownerID, PersonalityTraits: "{}"}
+	require.NoError(t, db.Create(&avatar).Error)
+
+	result, err := adapter.NewPointEventAdapter(db).ApplyPointChange(userID, avatar.ID, models.PointEvent{
+		Source: models.PointEventSourceAvatarChat,
+		Delta:  models.MaxMatchingPoint,
+		Reason: "collation test",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result.NewMatching)
+	assert.Equal(t, userID, result.NewMatching.User1ID)
+	assert.Equal(t, ownerID, result.NewMatching.User2ID)
+	assert.True(t, result.IsMatched)
+}
+
+// TestPointEventAdapter_ConcurrentApplyDiagnosisResult 同じ相手との診断が同時に保存されても、
+// 間隔の確認がRelationの行ロックで直列化され1件だけ通ることを確かめる。TEST_DATABASE_URL が必要（CIでは必須）
+func TestPointEventAdapter_ConcurrentApplyDiagnosisResult(t *testing.T) {
+	db := openTestDatabase(t)
+
+	userID := utils.GenerateULID()
+	ownerID := utils.GenerateULID()
+	require.NoError(t, db.Create(&[]models.User{{ID: userID}, {ID: ownerID}}).Error)
+	userAvatar := models.Avatar{ID: utils.GenerateULID(), UserID: userID, PersonalityTraits: "{}"}
+	targetAvatar := models.Avatar{ID: utils.GenerateULID(), UserID: ownerID, PersonalityTraits: "{}"}
+	require.NoError(t, db.Create(&[]models.Avatar{userAvatar, targetAvatar}).Error)
+
+	pointEventAdapter := adapter.NewPointEventAdapter(db)
+	cooldownSince := time.Now().Add(-service.DiagnosisCooldown)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	saved, rejected := 0, 0
+	for i := 0; i < concurrentSends; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			history := models.DiagnosisHistory{
+				ID:               utils.GenerateULID(),
+				UserID:           userID,
+				UserAvatarID:     userAvatar.ID,
+				TargetAvatarID:   targetAvatar.ID,
+				ConversationData: "{}",
+				DiagnosisScore:   4,
+				AIAnalysisResult: "{}",
+			}
+			_, err := pointEventAdapter.ApplyDiagnosisResult(&history, cooldownSince, models.PointEvent{
+				Source: models.PointEventSourceDiagnosis,
+				Delta:  60,
+				Reason: "concurrency test",
+			})
+			mu.Lock()
+			defer mu.Unlock()
+			if errors.Is(err, adapter.ErrRecentDiagnosisExists) {
+				rejected++
+				return
+			}
+			if assert.NoError(t, err) {
+				saved++
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, saved)
+	assert.Equal(t, concurrentSends-1, rejected)
+
+	var historyCount int64
+	require.NoError(t, db.Model(&models.DiagnosisHistory{}).Where("user_id = ? AND target_avatar_id = ?", userID, targetAvatar.ID).Count(&historyCount).Error)
+	assert.Equal(t, int64(1), historyCount)
+
+	var relation models.UserAvatarRelation
+	require.NoError(t, db.Where("user_id = ? AND avatar_id = ?", userID, targetAvatar.ID).First(&relation).Error)
+	assert.Equal(t, 60, relation.MatchingPoint)
+}
@@ -0,0 +1,71 @@
+package tests
+
+import (
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/hackathon-20260110/api/migrations"
+	"github.com/hackathon-20260110/api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm/schema"
+)
+
+func TestMigrations_LoadOrdersVersionsWithUpAndDown(t *testing.T) {
+	loaded, err := migrations.Load()
+	require.NoError(t, err)
+	require.NotEmpty(t, loaded)
+
+	assert.Equal(t, int64(1), loaded[0].Version)
+	assert.Equal(t, "baseline", loaded[0].Name)
+	for i, migration := range loaded {
+		assert.NotEmpty(t, migration.Up)
+		assert.NotEmpty(t, migration.Down)
+		if i > 0 {
+			assert.Greater(t, migration.Version, loaded[i-1].Version)
+		}
+	}
+}
+
+// TestMigrations_BaselineCoversModels ベースラインがPostgreSQLに保存するモデルのテーブルと列をすべて作ることを確かめる
+func TestMigrations_BaselineCoversModels(t *testing.T) {
+	loaded, err := migrations.Load()
+	require.NoError(t, err)
+	baseline := loaded[0].Up
+
+	tables := []interface{}{
+		&models.User{},
+		&models.Avatar{},
+		&models.UserInfo{},
+		&models.Mission{},
+		&models.MissionUnlock{},
+		&models.UserAvatarRelation{},
+		&models.PointEvent{},
+		&models.PointAdjustmentAudit{},
+		&models.Matching{},
+		&models.UserBlock{},
+		&models.DiagnosisHistory{},
+		&models.Job{},
+		&models.DeviceToken{},
+		&models.NotificationSetting{},
+		&models.SuppressedPush{},
+		&models.AvatarChatMemory{},
+		&models.ChatReadCursor{},
+	}
+	for _, model := range tables {
+		s, err := schema.Parse(model, &sync.Map{}, schema.NamingStrategy{})
+		require.NoError(t, err)
+
+		table := regexp.MustCompile(`(?s)CREATE TABLE IF NOT EXISTS ` + s.Table + ` \((.*?)\n\);`).FindStringSubmatch(baseline)
+		if !assert.NotNil(t, table, "table %s is not created", s.Table) {
+			continue
+		}
+		for _, field := range s.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			assert.Regexp(t, `\n    `+field.DBName+` `, table[1], "column %s.%s is not created", s.Table, field.DBName)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: adapter/avatar_adapter.go
+//
+// Generated by this command:
+//
+//	mockgen -source=adapter/avatar_adapter.go -destination=tests/mock/avatar_adapter_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	models "github.com/hackathon-20260110/api/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAvatarAdapter is a mock of AvatarAdapter interface.
+type MockAvatarAdapter struct {
+	ctrl     *gomock.Controller
+	recorder *MockAvatarAdapterMockRecorder
+	isgomock struct{}
+}
+
+// MockAvatarAdapterMockRecorder is the mock recorder for MockAvatarAdapter.
+type MockAvatarAdapterMockRecorder struct {
+	mock *MockAvatarAdapter
+}
+
+// NewMockAvatarAdapter creates a new mock instance.
+func NewMockAvatarAdapter(ctrl *gomock.Controller) *MockAvatarAdapter {
+	mock := &MockAvatarAdapter{ctrl: ctrl}
+	mock.recorder = &MockAvatarAdapterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAvatarAdapter) EXPECT() *MockAvatarAdapterMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAvatarAdapter) Create(avatar models.Avatar) (*models.Avatar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", avatar)
+	ret0, _ := ret[0].(*models.Avatar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAvatarAdapterMockRecorder) Create(avatar any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAvatarAdapter)(nil).Create), avatar)
+}
+
+// GetAvatarsByOwnerGenders mocks base method.
+func (m *MockAvatarAdapter) GetAvatarsByOwnerGenders(excludeUserID string, genders []string) ([]models.Avatar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvatarsByOwnerGenders", excludeUserID, genders)
+	ret0, _ := ret[0].([]models.Avatar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvatarsByOwnerGenders indicates an expected call of GetAvatarsByOwnerGenders.
+func (mr *MockAvatarAdapterMockRecorder) GetAvatarsByOwnerGenders(excludeUserID, genders any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvatarsByOwnerGenders", reflect.TypeOf((*MockAvatarAdapter)(nil).GetAvatarsByOwnerGenders), excludeUserID, genders)
+}
+
+// GetByID mocks base method.
+func (m *MockAvatarAdapter) GetByID(id string) (*models.Avatar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*models.Avatar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockAvatarAdapterMockRecorder) GetByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockAvatarAdapter)(nil).GetByID), id)
+}
+
+// GetByIDs mocks base method.
+func (m *MockAvatarAdapter) GetByIDs(ids []string) ([]models.Avatar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ids)
+	ret0, _ := ret[0].([]models.Avatar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockAvatarAdapterMockRecorder) GetByIDs(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockAvatarAdapter)(nil).GetByIDs), ids)
+}
+
+// GetByUserID mocks base method.
+func (m *MockAvatarAdapter) GetByUserID(userID string) (*models.Avatar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", userID)
+	ret0, _ := ret[0].(*models.Avatar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockAvatarAdapterMockRecorder) GetByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockAvatarAdapter)(nil).GetByUserID), userID)
+}
+
+// GetUserAvatarRelation mocks base method.
+func (m *MockAvatarAdapter) GetUserAvatarRelation(userID, avatarID string) (models.UserAvatarRelation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAvatarRelation", userID, avatarID)
+	ret0, _ := ret[0].(models.UserAvatarRelation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAvatarRelation indicates an expected call of GetUserAvatarRelation.
+func (mr *MockAvatarAdapterMockRecorder) GetUserAvatarRelation(userID, avatarID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAvatarRelation", reflect.TypeOf((*MockAvatarAdapter)(nil).GetUserAvatarRelation), userID, avatarID)
+}
+
+// GetUserAvatarRelationsByAvatarIDs mocks base method.
+func (m *MockAvatarAdapter) GetUserAvatarRelationsByAvatarIDs(userID string, avatarIDs []string) ([]models.UserAvatarRelation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAvatarRelationsByAvatarIDs", userID, avatarIDs)
+	ret0, _ := ret[0].([]models.UserAvatarRelation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAvatarRelationsByAvatarIDs indicates an expected call of GetUserAvatarRelationsByAvatarIDs.
+func (mr *MockAvatarAdapterMockRecorder) GetUserAvatarRelationsByAvatarIDs(userID, avatarIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAvatarRelationsByAvatarIDs", reflect.TypeOf((*MockAvatarAdapter)(nil).GetUserAvatarRelationsByAvatarIDs), userID, avatarIDs)
+}
+
+// GetUserAvatarRelationsByUserID mocks base method.
+func (m *MockAvatarAdapter) GetUserAvatarRelationsByUserID(userID string) ([]models.UserAvatarRelation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAvatarRelationsByUserID", userID)
+	ret0, _ := ret[0].([]models.UserAvatarRelation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAvatarRelationsByUserID indicates an expected call of GetUserAvatarRelationsByUserID.
+func (mr *MockAvatarAdapterMockRecorder) GetUserAvatarRelationsByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAvatarRelationsByUserID", reflect.TypeOf((*MockAvatarAdapter)(nil).GetUserAvatarRelationsByUserID), userID)
+}
+
+// Update mocks base method.
+func (m *MockAvatarAdapter) Update(avatar models.Avatar) (*models.Avatar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", avatar)
+	ret0, _ := ret[0].(*models.Avatar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAvatarAdapterMockRecorder) Update(avatar any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAvatarAdapter)(nil).Update), avatar)
+}
@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: adapter/avatar_chat_adapter.go
+//
+// Generated by this command:
+//
+//	mockgen -source=adapter/avatar_chat_adapter.go -destination=tests/mock/avatar_chat_adapter_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	adapter "github.com/hackathon-20260110/api/adapter"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAvatarChatAdapter is a mock of AvatarChatAdapter interface.
+type MockAvatarChatAdapter struct {
+	ctrl     *gomock.Controller
+	recorder *MockAvatarChatAdapterMockRecorder
+	isgomock struct{}
+}
+
+// MockAvatarChatAdapterMockRecorder is the mock recorder for MockAvatarChatAdapter.
+type MockAvatarChatAdapterMockRecorder struct {
+	mock *MockAvatarChatAdapter
+}
+
+// NewMockAvatarChatAdapter creates a new mock instance.
+func NewMockAvatarChatAdapter(ctrl *gomock.Controller) *MockAvatarChatAdapter {
+	mock := &MockAvatarChatAdapter{ctrl: ctrl}
+	mock.recorder = &MockAvatarChatAdapterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAvatarChatAdapter) EXPECT() *MockAvatarChatAdapterMockRecorder {
+	return m.recorder
+}
+
+// CountAvatarMessages mocks base method.
+func (m *MockAvatarChatAdapter) CountAvatarMessages(ctx context.Context, userID, avatarID, after string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAvatarMessages", ctx, userID, avatarID, after)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAvatarMessages indicates an expected call of CountAvatarMessages.
+func (mr *MockAvatarChatAdapterMockRecorder) CountAvatarMessages(ctx, userID, avatarID, after any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAvatarMessages", reflect.TypeOf((*MockAvatarChatAdapter)(nil).CountAvatarMessages), ctx, userID, avatarID, after)
+}
+
+// CreateAvatarChatMessage mocks base method.
+func (m *MockAvatarChatAdapter) CreateAvatarChatMessage(ctx context.Context, userID, avatarID string, message adapter.AvatarChatMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAvatarChatMessage", ctx, userID, avatarID, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAvatarChatMessage indicates an expected call of CreateAvatarChatMessage.
+func (mr *MockAvatarChatAdapterMockRecorder) CreateAvatarChatMessage(ctx, userID, avatarID, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAvatarChatMessage", reflect.TypeOf((*MockAvatarChatAdapter)(nil).CreateAvatarChatMessage), ctx, userID, avatarID, message)
+}
+
+// GetAvatarChatMessages mocks base method.
+func (m *MockAvatarChatAdapter) GetAvatarChatMessages(ctx context.Context, userID, avatarID string, page adapter.ChatPage) ([]adapter.AvatarChatMessage, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvatarChatMessages", ctx, userID, avatarID, page)
+	ret0, _ := ret[0].([]adapter.AvatarChatMessage)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAvatarChatMessages indicates an expected call of GetAvatarChatMessages.
+func (mr *MockAvatarChatAdapterMockRecorder) GetAvatarChatMessages(ctx, userID, avatarID, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvatarChatMessages", reflect.TypeOf((*MockAvatarChatAdapter)(nil).GetAvatarChatMessages), ctx, userID, avatarID, page)
+}
@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: adapter/avatar_chat_memory_adapter.go
+//
+// Generated by this command:
+//
+//	mockgen -source=adapter/avatar_chat_memory_adapter.go -destination=tests/mock/avatar_chat_memory_adapter_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	models "github.com/hackathon-20260110/api/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAvatarChatMemoryAdapter is a mock of AvatarChatMemoryAdapter interface.
+type MockAvatarChatMemoryAdapter struct {
+	ctrl     *gomock.Controller
+	recorder *MockAvatarChatMemoryAdapterMockRecorder
+	isgomock struct{}
+}
+
+// MockAvatarChatMemoryAdapterMockRecorder is the mock recorder for MockAvatarChatMemoryAdapter.
+type MockAvatarChatMemoryAdapterMockRecorder struct {
+	mock *MockAvatarChatMemoryAdapter
+}
+
+// NewMockAvatarChatMemoryAdapter creates a new mock instance.
+func NewMockAvatarChatMemoryAdapter(ctrl *gomock.Controller) *MockAvatarChatMemoryAdapter {
+	mock := &MockAvatarChatMemoryAdapter{ctrl: ctrl}
+	mock.recorder = &MockAvatarChatMemoryAdapterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAvatarChatMemoryAdapter) EXPECT() *MockAvatarChatMemoryAdapterMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockAvatarChatMemoryAdapter) Get(userID, avatarID string) (*models.AvatarChatMemory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", userID, avatarID)
+	ret0, _ := ret[0].(*models.AvatarChatMemory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAvatarChatMemoryAdapterMockRecorder) Get(userID, avatarID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAvatarChatMemoryAdapter)(nil).Get), userID, avatarID)
+}
+
+// Save mocks base method.
+func (m *MockAvatarChatMemoryAdapter) Save(memory *models.AvatarChatMemory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", memory)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockAvatarChatMemoryAdapterMockRecorder) Save(memory any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockAvatarChatMemoryAdapter)(nil).Save), memory)
+}
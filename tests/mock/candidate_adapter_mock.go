@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: adapter/candidate_adapter.go
+//
+// Generated by this command:
+//
+//	mockgen -source=adapter/candidate_adapter.go -destination=tests/mock/candidate_adapter_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	adapter "github.com/hackathon-20260110/api/adapter"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCandidateAdapter is a mock of CandidateAdapter interface.
+type MockCandidateAdapter struct {
+	ctrl     *gomock.Controller
+	recorder *MockCandidateAdapterMockRecorder
+	isgomock struct{}
+}
+
+// MockCandidateAdapterMockRecorder is the mock recorder for MockCandidateAdapter.
+type MockCandidateAdapterMockRecorder struct {
+	mock *MockCandidateAdapter
+}
+
+// NewMockCandidateAdapter creates a new mock instance.
+func NewMockCandidateAdapter(ctrl *gomock.Controller) *MockCandidateAdapter {
+	mock := &MockCandidateAdapter{ctrl: ctrl}
+	mock.recorder = &MockCandidateAdapterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCandidateAdapter) EXPECT() *MockCandidateAdapterMockRecorder {
+	return m.recorder
+}
+
+// FindCandidates mocks base method.
+func (m *MockCandidateAdapter) FindCandidates(filter adapter.CandidateFilter) ([]adapter.Candidate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCandidates", filter)
+	ret0, _ := ret[0].([]adapter.Candidate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCandidates indicates an expected call of FindCandidates.
+func (mr *MockCandidateAdapterMockRecorder) FindCandidates(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCandidates", reflect.TypeOf((*MockCandidateAdapter)(nil).FindCandidates), filter)
+}
@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: adapter/chat_read_cursor_adapter.go
+//
+// Generated by this command:
+//
+//	mockgen -source=adapter/chat_read_cursor_adapter.go -destination=tests/mock/chat_read_cursor_adapter_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	models "github.com/hackathon-20260110/api/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockChatReadCursorAdapter is a mock of ChatReadCursorAdapter interface.
+type MockChatReadCursorAdapter struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatReadCursorAdapterMockRecorder
+	isgomock struct{}
+}
+
+// MockChatReadCursorAdapterMockRecorder is the mock recorder for MockChatReadCursorAdapter.
+type MockChatReadCursorAdapterMockRecorder struct {
+	mock *MockChatReadCursorAdapter
+}
+
+// NewMockChatReadCursorAdapter creates a new mock instance.
+func NewMockChatReadCursorAdapter(ctrl *gomock.Controller) *MockChatReadCursorAdapter {
+	mock := &MockChatReadCursorAdapter{ctrl: ctrl}
+	mock.recorder = &MockChatReadCursorAdapterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatReadCursorAdapter) EXPECT() *MockChatReadCursorAdapterMockRecorder {
+	return m.recorder
+}
+
+// Advance mocks base method.
+func (m *MockChatReadCursorAdapter) Advance(cursor *models.ChatReadCursor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Advance", cursor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Advance indicates an expected call of Advance.
+func (mr *MockChatReadCursorAdapterMockRecorder) Advance(cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Advance", reflect.TypeOf((*MockChatReadCursorAdapter)(nil).Advance), cursor)
+}
+
+// Get mocks base method.
+func (m *MockChatReadCursorAdapter) Get(userID string, kind models.ChatKind, partnerID string) (*models.ChatReadCursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", userID, kind, partnerID)
+	ret0, _ := ret[0].(*models.ChatReadCursor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockChatReadCursorAdapterMockRecorder) Get(userID, kind, partnerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockChatReadCursorAdapter)(nil).Get), userID, kind, partnerID)
+}
+
+// GetByUserID mocks base method.
+func (m *MockChatReadCursorAdapter) GetByUserID(userID string) ([]models.ChatReadCursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", userID)
+	ret0, _ := ret[0].([]models.ChatReadCursor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockChatReadCursorAdapterMockRecorder) GetByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockChatReadCursorAdapter)(nil).GetByUserID), userID)
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: adapter/chat_summary_adapter.go
+//
+// Generated by this command:
+//
+//	mockgen -source=adapter/chat_summary_adapter.go -destination=tests/mock/chat_summary_adapter_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	adapter "github.com/hackathon-20260110/api/adapter"
+	models "github.com/hackathon-20260110/api/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockChatSummaryAdapter is a mock of ChatSummaryAdapter interface.
+type MockChatSummaryAdapter struct {
+	ctrl     *gomock.Controller
+	recorder *MockChatSummaryAdapterMockRecorder
+	isgomock struct{}
+}
+
+// MockChatSummaryAdapterMockRecorder is the mock recorder for MockChatSummaryAdapter.
+type MockChatSummaryAdapterMockRecorder struct {
+	mock *MockChatSummaryAdapter
+}
+
+// NewMockChatSummaryAdapter creates a new mock instance.
+func NewMockChatSummaryAdapter(ctrl *gomock.Controller) *MockChatSummaryAdapter {
+	mock := &MockChatSummaryAdapter{ctrl: ctrl}
+	mock.recorder = &MockChatSummaryAdapterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChatSummaryAdapter) EXPECT() *MockChatSummaryAdapterMockRecorder {
+	return m.recorder
+}
+
+// CreateChatSummary mocks base method.
+func (m *MockChatSummaryAdapter) CreateChatSummary(ctx context.Context, userID string, summary adapter.ChatSummary) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChatSummary", ctx, userID, summary)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateChatSummary indicates an expected call of CreateChatSummary.
+func (mr *MockChatSummaryAdapterMockRecorder) CreateChatSummary(ctx, userID, summary any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChatSummary", reflect.TypeOf((*MockChatSummaryAdapter)(nil).CreateChatSummary), ctx, userID, summary)
+}
+
+// GetChatSummaries mocks base method.
+func (m *MockChatSummaryAdapter) GetChatSummaries(ctx context.Context, userID string) ([]adapter.ChatSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatSummaries", ctx, userID)
+	ret0, _ := ret[0].([]adapter.ChatSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatSummaries indicates an expected call of GetChatSummaries.
+func (mr *MockChatSummaryAdapterMockRecorder) GetChatSummaries(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatSummaries", reflect.TypeOf((*MockChatSummaryAdapter)(nil).GetChatSummaries), ctx, userID)
+}
+
+// SetUnreadCount mocks base method.
+func (m *MockChatSummaryAdapter) SetUnreadCount(ctx context.Context, userID string, kind models.ChatKind, partnerID string, count int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUnreadCount", ctx, userID, kind, partnerID, count)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUnreadCount indicates an expected call of SetUnreadCount.
+func (mr *MockChatSummaryAdapterMockRecorder) SetUnreadCount(ctx, userID, kind, partnerID, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUnreadCount", reflect.TypeOf((*MockChatSummaryAdapter)(nil).SetUnreadCount), ctx, userID, kind, partnerID, count)
+}
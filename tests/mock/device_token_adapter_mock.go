@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: adapter/device_token_adapter.go
+//
+// Generated by this command:
+//
+//	mockgen -source=adapter/device_token_adapter.go -destination=tests/mock/device_token_adapter_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	models "github.com/hackathon-20260110/api/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDeviceTokenAdapter is a mock of DeviceTokenAdapter interface.
+type MockDeviceTokenAdapter struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeviceTokenAdapterMockRecorder
+	isgomock struct{}
+}
+
+// MockDeviceTokenAdapterMockRecorder is the mock recorder for MockDeviceTokenAdapter.
+type MockDeviceTokenAdapterMockRecorder struct {
+	mock *MockDeviceTokenAdapter
+}
+
+// NewMockDeviceTokenAdapter creates a new mock instance.
+func NewMockDeviceTokenAdapter(ctrl *gomock.Controller) *MockDeviceTokenAdapter {
+	mock := &MockDeviceTokenAdapter{ctrl: ctrl}
+	mock.recorder = &MockDeviceTokenAdapterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeviceTokenAdapter) EXPECT() *MockDeviceTokenAdapterMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockDeviceTokenAdapter) Delete(userID, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userID, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDeviceTokenAdapterMockRecorder) Delete(userID, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDeviceTokenAdapter)(nil).Delete), userID, token)
+}
+
+// DeleteByTokens mocks base method.
+func (m *MockDeviceTokenAdapter) DeleteByTokens(tokens []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByTokens", tokens)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByTokens indicates an expected call of DeleteByTokens.
+func (mr *MockDeviceTokenAdapterMockRecorder) DeleteByTokens(tokens any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByTokens", reflect.TypeOf((*MockDeviceTokenAdapter)(nil).DeleteByTokens), tokens)
+}
+
+// GetByUserID mocks base method.
+func (m *MockDeviceTokenAdapter) GetByUserID(userID string) ([]models.DeviceToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", userID)
+	ret0, _ := ret[0].([]models.DeviceToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockDeviceTokenAdapterMockRecorder) GetByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockDeviceTokenAdapter)(nil).GetByUserID), userID)
+}
+
+// Register mocks base method.
+func (m *MockDeviceTokenAdapter) Register(userID, token string, platform models.DevicePlatform) (*models.DeviceToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", userID, token, platform)
+	ret0, _ := ret[0].(*models.DeviceToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockDeviceTokenAdapterMockRecorder) Register(userID, token, platform any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockDeviceTokenAdapter)(nil).Register), userID, token, platform)
+}
@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: adapter/diagnosis_adapter.go
+//
+// Generated by this command:
+//
+//	mockgen -source=adapter/diagnosis_adapter.go -destination=tests/mock/diagnosis_adapter_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/hackathon-20260110/api/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDiagnosisAdapter is a mock of DiagnosisAdapter interface.
+type MockDiagnosisAdapter struct {
+	ctrl     *gomock.Controller
+	recorder *MockDiagnosisAdapterMockRecorder
+	isgomock struct{}
+}
+
+// MockDiagnosisAdapterMockRecorder is the mock recorder for MockDiagnosisAdapter.
+type MockDiagnosisAdapterMockRecorder struct {
+	mock *MockDiagnosisAdapter
+}
+
+// NewMockDiagnosisAdapter creates a new mock instance.
+func NewMockDiagnosisAdapter(ctrl *gomock.Controller) *MockDiagnosisAdapter {
+	mock := &MockDiagnosisAdapter{ctrl: ctrl}
+	mock.recorder = &MockDiagnosisAdapterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDiagnosisAdapter) EXPECT() *MockDiagnosisAdapterMockRecorder {
+	return m.recorder
+}
+
+// CountDiagnosisHistoriesSince mocks base method.
+func (m *MockDiagnosisAdapter) CountDiagnosisHistoriesSince(userID, targetAvatarID string, since time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountDiagnosisHistoriesSince", userID, targetAvatarID, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountDiagnosisHistoriesSince indicates an expected call of CountDiagnosisHistoriesSince.
+func (mr *MockDiagnosisAdapterMockRecorder) CountDiagnosisHistoriesSince(userID, targetAvatarID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDiagnosisHistoriesSince", reflect.TypeOf((*MockDiagnosisAdapter)(nil).CountDiagnosisHistoriesSince), userID, targetAvatarID, since)
+}
+
+// CreateDiagnosisHistory mocks base method.
+func (m *MockDiagnosisAdapter) CreateDiagnosisHistory(history *models.DiagnosisHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDiagnosisHistory", history)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDiagnosisHistory indicates an expected call of CreateDiagnosisHistory.
+func (mr *MockDiagnosisAdapterMockRecorder) CreateDiagnosisHistory(history any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDiagnosisHistory", reflect.TypeOf((*MockDiagnosisAdapter)(nil).CreateDiagnosisHistory), history)
+}
+
+// GetAvatarByID mocks base method.
+func (m *MockDiagnosisAdapter) GetAvatarByID(id string) (models.Avatar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvatarByID", id)
+	ret0, _ := ret[0].(models.Avatar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvatarByID indicates an expected call of GetAvatarByID.
+func (mr *MockDiagnosisAdapterMockRecorder) GetAvatarByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvatarByID", reflect.TypeOf((*MockDiagnosisAdapter)(nil).GetAvatarByID), id)
+}
+
+// GetByUserID mocks base method.
+func (m *MockDiagnosisAdapter) GetByUserID(userID string) (models.Avatar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", userID)
+	ret0, _ := ret[0].(models.Avatar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockDiagnosisAdapterMockRecorder) GetByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockDiagnosisAdapter)(nil).GetByUserID), userID)
+}
+
+// GetDiagnosisHistoryByID mocks base method.
+func (m *MockDiagnosisAdapter) GetDiagnosisHistoryByID(id string) (models.DiagnosisHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDiagnosisHistoryByID", id)
+	ret0, _ := ret[0].(models.DiagnosisHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDiagnosisHistoryByID indicates an expected call of GetDiagnosisHistoryByID.
+func (mr *MockDiagnosisAdapterMockRecorder) GetDiagnosisHistoryByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiagnosisHistoryByID", reflect.TypeOf((*MockDiagnosisAdapter)(nil).GetDiagnosisHistoryByID), id)
+}
+
+// GetDiagnosisHistoryByUserID mocks base method.
+func (m *MockDiagnosisAdapter) GetDiagnosisHistoryByUserID(userID string) ([]models.DiagnosisHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDiagnosisHistoryByUserID", userID)
+	ret0, _ := ret[0].([]models.DiagnosisHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDiagnosisHistoryByUserID indicates an expected call of GetDiagnosisHistoryByUserID.
+func (mr *MockDiagnosisAdapterMockRecorder) GetDiagnosisHistoryByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiagnosisHistoryByUserID", reflect.TypeOf((*MockDiagnosisAdapter)(nil).GetDiagnosisHistoryByUserID), userID)
+}
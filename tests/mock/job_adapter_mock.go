@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: adapter/job_adapter.go
+//
+// Generated by this command:
+//
+//	mockgen -source=adapter/job_adapter.go -destination=tests/mock/job_adapter_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/hackathon-20260110/api/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockJobAdapter is a mock of JobAdapter interface.
+type MockJobAdapter struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobAdapterMockRecorder
+	isgomock struct{}
+}
+
+// MockJobAdapterMockRecorder is the mock recorder for MockJobAdapter.
+type MockJobAdapterMockRecorder struct {
+	mock *MockJobAdapter
+}
+
+// NewMockJobAdapter creates a new mock instance.
+func NewMockJobAdapter(ctrl *gomock.Controller) *MockJobAdapter {
+	mock := &MockJobAdapter{ctrl: ctrl}
+	mock.recorder = &MockJobAdapterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobAdapter) EXPECT() *MockJobAdapterMockRecorder {
+	return m.recorder
+}
+
+// Complete mocks base method.
+func (m *MockJobAdapter) Complete(jobID, workerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", jobID, workerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockJobAdapterMockRecorder) Complete(jobID, workerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockJobAdapter)(nil).Complete), jobID, workerID)
+}
+
+// Enqueue mocks base method.
+func (m *MockJobAdapter) Enqueue(job *models.Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockJobAdapterMockRecorder) Enqueue(job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockJobAdapter)(nil).Enqueue), job)
+}
+
+// EnqueueIfNotActive mocks base method.
+func (m *MockJobAdapter) EnqueueIfNotActive(job *models.Job) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueIfNotActive", job)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnqueueIfNotActive indicates an expected call of EnqueueIfNotActive.
+func (mr *MockJobAdapterMockRecorder) EnqueueIfNotActive(job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueIfNotActive", reflect.TypeOf((*MockJobAdapter)(nil).EnqueueIfNotActive), job)
+}
+
+// Fail mocks base method.
+func (m *MockJobAdapter) Fail(jobID, workerID, lastError string, retryAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", jobID, workerID, lastError, retryAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockJobAdapterMockRecorder) Fail(jobID, workerID, lastError, retryAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockJobAdapter)(nil).Fail), jobID, workerID, lastError, retryAt)
+}
+
+// GetLatestJobBySubject mocks base method.
+func (m *MockJobAdapter) GetLatestJobBySubject(jobType models.JobType, subjectID string) (*models.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestJobBySubject", jobType, subjectID)
+	ret0, _ := ret[0].(*models.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestJobBySubject indicates an expected call of GetLatestJobBySubject.
+func (mr *MockJobAdapterMockRecorder) GetLatestJobBySubject(jobType, subjectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestJobBySubject", reflect.TypeOf((*MockJobAdapter)(nil).GetLatestJobBySubject), jobType, subjectID)
+}
+
+// Lease mocks base method.
+func (m *MockJobAdapter) Lease(workerID string, jobTypes []models.JobType, leaseDuration time.Duration) (*models.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lease", workerID, jobTypes, leaseDuration)
+	ret0, _ := ret[0].(*models.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lease indicates an expected call of Lease.
+func (mr *MockJobAdapterMockRecorder) Lease(workerID, jobTypes, leaseDuration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lease", reflect.TypeOf((*MockJobAdapter)(nil).Lease), workerID, jobTypes, leaseDuration)
+}
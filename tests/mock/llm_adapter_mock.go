@@ -10,11 +10,11 @@
 package mock
 
 import (
+	context "context"
 	reflect "reflect"
 
 	adapter "github.com/hackathon-20260110/api/adapter"
 	gomock "go.uber.org/mock/gomock"
-	genai "google.golang.org/genai"
 )
 
 // MockLLMAdapter is a mock of LLMAdapter interface.
@@ -42,31 +42,60 @@ func (m *MockLLMAdapter) EXPECT() *MockLLMAdapterMockRecorder {
 }
 
 // CreateChatCompletion mocks base method.
-func (m *MockLLMAdapter) CreateChatCompletion(contents []*genai.Content, model adapter.LLMModelType) (string, error) {
+func (m *MockLLMAdapter) CreateChatCompletion(ctx context.Context, req adapter.LLMRequest) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateChatCompletion", contents, model)
+	ret := m.ctrl.Call(m, "CreateChatCompletion", ctx, req)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateChatCompletion indicates an expected call of CreateChatCompletion.
-func (mr *MockLLMAdapterMockRecorder) CreateChatCompletion(contents, model any) *gomock.Call {
+func (mr *MockLLMAdapterMockRecorder) CreateChatCompletion(ctx, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChatCompletion", reflect.TypeOf((*MockLLMAdapter)(nil).CreateChatCompletion), contents, model)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChatCompletion", reflect.TypeOf((*MockLLMAdapter)(nil).CreateChatCompletion), ctx, req)
 }
 
 // CreateChatCompletionJSON mocks base method.
-func (m *MockLLMAdapter) CreateChatCompletionJSON(contents []*genai.Content, model adapter.LLMModelType) (string, error) {
+func (m *MockLLMAdapter) CreateChatCompletionJSON(ctx context.Context, req adapter.LLMRequest) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateChatCompletionJSON", contents, model)
+	ret := m.ctrl.Call(m, "CreateChatCompletionJSON", ctx, req)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateChatCompletionJSON indicates an expected call of CreateChatCompletionJSON.
-func (mr *MockLLMAdapterMockRecorder) CreateChatCompletionJSON(contents, model any) *gomock.Call {
+func (mr *MockLLMAdapterMockRecorder) CreateChatCompletionJSON(ctx, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChatCompletionJSON", reflect.TypeOf((*MockLLMAdapter)(nil).CreateChatCompletionJSON), contents, model)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChatCompletionJSON", reflect.TypeOf((*MockLLMAdapter)(nil).CreateChatCompletionJSON), ctx, req)
+}
+
+// CreateChatCompletionStream mocks base method.
+func (m *MockLLMAdapter) CreateChatCompletionStream(ctx context.Context, req adapter.LLMRequest, onChunk func(string) error) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChatCompletionStream", ctx, req, onChunk)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateChatCompletionStream indicates an expected call of CreateChatCompletionStream.
+func (mr *MockLLMAdapterMockRecorder) CreateChatCompletionStream(ctx, req, onChunk any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChatCompletionStream", reflect.TypeOf((*MockLLMAdapter)(nil).CreateChatCompletionStream), ctx, req, onChunk)
+}
+
+// CreateStructuredCompletion mocks base method.
+func (m *MockLLMAdapter) CreateStructuredCompletion(ctx context.Context, req adapter.LLMRequest, out adapter.LLMStructuredOutput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStructuredCompletion", ctx, req, out)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateStructuredCompletion indicates an expected call of CreateStructuredCompletion.
+func (mr *MockLLMAdapterMockRecorder) CreateStructuredCompletion(ctx, req, out any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStructuredCompletion", reflect.TypeOf((*MockLLMAdapter)(nil).CreateStructuredCompletion), ctx, req, out)
 }
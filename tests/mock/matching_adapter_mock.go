@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: adapter/matching_adapter.go
+//
+// Generated by this command:
+//
+//	mockgen -source=adapter/matching_adapter.go -destination=tests/mock/matching_adapter_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	models "github.com/hackathon-20260110/api/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMatchingAdapter is a mock of MatchingAdapter interface.
+type MockMatchingAdapter struct {
+	ctrl     *gomock.Controller
+	recorder *MockMatchingAdapterMockRecorder
+	isgomock struct{}
+}
+
+// MockMatchingAdapterMockRecorder is the mock recorder for MockMatchingAdapter.
+type MockMatchingAdapterMockRecorder struct {
+	mock *MockMatchingAdapter
+}
+
+// NewMockMatchingAdapter creates a new mock instance.
+func NewMockMatchingAdapter(ctrl *gomock.Controller) *MockMatchingAdapter {
+	mock := &MockMatchingAdapter{ctrl: ctrl}
+	mock.recorder = &MockMatchingAdapterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMatchingAdapter) EXPECT() *MockMatchingAdapterMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockMatchingAdapter) GetByID(id string) (*models.Matching, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*models.Matching)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockMatchingAdapterMockRecorder) GetByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockMatchingAdapter)(nil).GetByID), id)
+}
+
+// GetMatchingByUsers mocks base method.
+func (m *MockMatchingAdapter) GetMatchingByUsers(user1ID, user2ID string) (*models.Matching, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMatchingByUsers", user1ID, user2ID)
+	ret0, _ := ret[0].(*models.Matching)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMatchingByUsers indicates an expected call of GetMatchingByUsers.
+func (mr *MockMatchingAdapterMockRecorder) GetMatchingByUsers(user1ID, user2ID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMatchingByUsers", reflect.TypeOf((*MockMatchingAdapter)(nil).GetMatchingByUsers), user1ID, user2ID)
+}
+
+// GetMatchingsByUserID mocks base method.
+func (m *MockMatchingAdapter) GetMatchingsByUserID(userID string) ([]models.Matching, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMatchingsByUserID", userID)
+	ret0, _ := ret[0].([]models.Matching)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMatchingsByUserID indicates an expected call of GetMatchingsByUserID.
+func (mr *MockMatchingAdapterMockRecorder) GetMatchingsByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMatchingsByUserID", reflect.TypeOf((*MockMatchingAdapter)(nil).GetMatchingsByUserID), userID)
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: adapter/mission_adapter.go
+//
+// Generated by this command:
+//
+//	mockgen -source=adapter/mission_adapter.go -destination=tests/mock/mission_adapter_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	models "github.com/hackathon-20260110/api/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMissionAdapter is a mock of MissionAdapter interface.
+type MockMissionAdapter struct {
+	ctrl     *gomock.Controller
+	recorder *MockMissionAdapterMockRecorder
+	isgomock struct{}
+}
+
+// MockMissionAdapterMockRecorder is the mock recorder for MockMissionAdapter.
+type MockMissionAdapterMockRecorder struct {
+	mock *MockMissionAdapter
+}
+
+// NewMockMissionAdapter creates a new mock instance.
+func NewMockMissionAdapter(ctrl *gomock.Controller) *MockMissionAdapter {
+	mock := &MockMissionAdapter{ctrl: ctrl}
+	mock.recorder = &MockMissionAdapterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMissionAdapter) EXPECT() *MockMissionAdapterMockRecorder {
+	return m.recorder
+}
+
+// GetMissionUnlock mocks base method.
+func (m *MockMissionAdapter) GetMissionUnlock(missionID, userID string) (*models.MissionUnlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMissionUnlock", missionID, userID)
+	ret0, _ := ret[0].(*models.MissionUnlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMissionUnlock indicates an expected call of GetMissionUnlock.
+func (mr *MockMissionAdapterMockRecorder) GetMissionUnlock(missionID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMissionUnlock", reflect.TypeOf((*MockMissionAdapter)(nil).GetMissionUnlock), missionID, userID)
+}
+
+// GetMissionUnlocksByUserID mocks base method.
+func (m *MockMissionAdapter) GetMissionUnlocksByUserID(userID string) ([]models.MissionUnlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMissionUnlocksByUserID", userID)
+	ret0, _ := ret[0].([]models.MissionUnlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMissionUnlocksByUserID indicates an expected call of GetMissionUnlocksByUserID.
+func (mr *MockMissionAdapterMockRecorder) GetMissionUnlocksByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMissionUnlocksByUserID", reflect.TypeOf((*MockMissionAdapter)(nil).GetMissionUnlocksByUserID), userID)
+}
+
+// GetMissionsByOwnerUserID mocks base method.
+func (m *MockMissionAdapter) GetMissionsByOwnerUserID(ownerUserID string) ([]models.Mission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMissionsByOwnerUserID", ownerUserID)
+	ret0, _ := ret[0].([]models.Mission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMissionsByOwnerUserID indicates an expected call of GetMissionsByOwnerUserID.
+func (mr *MockMissionAdapterMockRecorder) GetMissionsByOwnerUserID(ownerUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMissionsByOwnerUserID", reflect.TypeOf((*MockMissionAdapter)(nil).GetMissionsByOwnerUserID), ownerUserID)
+}
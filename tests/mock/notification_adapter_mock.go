@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: adapter/notification_adapter.go
+//
+// Generated by this command:
+//
+//	mockgen -source=adapter/notification_adapter.go -destination=tests/mock/notification_adapter_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/hackathon-20260110/api/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockNotificationAdapter is a mock of NotificationAdapter interface.
+type MockNotificationAdapter struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationAdapterMockRecorder
+	isgomock struct{}
+}
+
+// MockNotificationAdapterMockRecorder is the mock recorder for MockNotificationAdapter.
+type MockNotificationAdapterMockRecorder struct {
+	mock *MockNotificationAdapter
+}
+
+// NewMockNotificationAdapter creates a new mock instance.
+func NewMockNotificationAdapter(ctrl *gomock.Controller) *MockNotificationAdapter {
+	mock := &MockNotificationAdapter{ctrl: ctrl}
+	mock.recorder = &MockNotificationAdapterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationAdapter) EXPECT() *MockNotificationAdapterMockRecorder {
+	return m.recorder
+}
+
+// CountUnread mocks base method.
+func (m *MockNotificationAdapter) CountUnread(ctx context.Context, userID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnread", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnread indicates an expected call of CountUnread.
+func (mr *MockNotificationAdapterMockRecorder) CountUnread(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnread", reflect.TypeOf((*MockNotificationAdapter)(nil).CountUnread), ctx, userID)
+}
+
+// CreateNotification mocks base method.
+func (m *MockNotificationAdapter) CreateNotification(ctx context.Context, userID string, notification models.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotification", ctx, userID, notification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateNotification indicates an expected call of CreateNotification.
+func (mr *MockNotificationAdapterMockRecorder) CreateNotification(ctx, userID, notification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotification", reflect.TypeOf((*MockNotificationAdapter)(nil).CreateNotification), ctx, userID, notification)
+}
+
+// DeleteNotification mocks base method.
+func (m *MockNotificationAdapter) DeleteNotification(ctx context.Context, userID, notificationID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNotification", ctx, userID, notificationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNotification indicates an expected call of DeleteNotification.
+func (mr *MockNotificationAdapterMockRecorder) DeleteNotification(ctx, userID, notificationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNotification", reflect.TypeOf((*MockNotificationAdapter)(nil).DeleteNotification), ctx, userID, notificationID)
+}
+
+// ListNotifications mocks base method.
+func (m *MockNotificationAdapter) ListNotifications(ctx context.Context, userID, cursor string, limit int) ([]models.Notification, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotifications", ctx, userID, cursor, limit)
+	ret0, _ := ret[0].([]models.Notification)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListNotifications indicates an expected call of ListNotifications.
+func (mr *MockNotificationAdapterMockRecorder) ListNotifications(ctx, userID, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotifications", reflect.TypeOf((*MockNotificationAdapter)(nil).ListNotifications), ctx, userID, cursor, limit)
+}
+
+// MarkAllAsRead mocks base method.
+func (m *MockNotificationAdapter) MarkAllAsRead(ctx context.Context, userID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllAsRead", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkAllAsRead indicates an expected call of MarkAllAsRead.
+func (mr *MockNotificationAdapterMockRecorder) MarkAllAsRead(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllAsRead", reflect.TypeOf((*MockNotificationAdapter)(nil).MarkAllAsRead), ctx, userID)
+}
+
+// MarkAsRead mocks base method.
+func (m *MockNotificationAdapter) MarkAsRead(ctx context.Context, userID, notificationID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAsRead", ctx, userID, notificationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAsRead indicates an expected call of MarkAsRead.
+func (mr *MockNotificationAdapterMockRecorder) MarkAsRead(ctx, userID, notificationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsRead", reflect.TypeOf((*MockNotificationAdapter)(nil).MarkAsRead), ctx, userID, notificationID)
+}
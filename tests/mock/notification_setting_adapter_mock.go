@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: adapter/notification_setting_adapter.go
+//
+// Generated by this command:
+//
+//	mockgen -source=adapter/notification_setting_adapter.go -destination=tests/mock/notification_setting_adapter_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	models "github.com/hackathon-20260110/api/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockNotificationSettingAdapter is a mock of NotificationSettingAdapter interface.
+type MockNotificationSettingAdapter struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationSettingAdapterMockRecorder
+	isgomock struct{}
+}
+
+// MockNotificationSettingAdapterMockRecorder is the mock recorder for MockNotificationSettingAdapter.
+type MockNotificationSettingAdapterMockRecorder struct {
+	mock *MockNotificationSettingAdapter
+}
+
+// NewMockNotificationSettingAdapter creates a new mock instance.
+func NewMockNotificationSettingAdapter(ctrl *gomock.Controller) *MockNotificationSettingAdapter {
+	mock := &MockNotificationSettingAdapter{ctrl: ctrl}
+	mock.recorder = &MockNotificationSettingAdapterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationSettingAdapter) EXPECT() *MockNotificationSettingAdapterMockRecorder {
+	return m.recorder
+}
+
+// AddSuppressedPush mocks base method.
+func (m *MockNotificationSettingAdapter) AddSuppressedPush(push models.SuppressedPush) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSuppressedPush", push)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSuppressedPush indicates an expected call of AddSuppressedPush.
+func (mr *MockNotificationSettingAdapterMockRecorder) AddSuppressedPush(push any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSuppressedPush", reflect.TypeOf((*MockNotificationSettingAdapter)(nil).AddSuppressedPush), push)
+}
+
+// GetByUserID mocks base method.
+func (m *MockNotificationSettingAdapter) GetByUserID(userID string) (*models.NotificationSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", userID)
+	ret0, _ := ret[0].(*models.NotificationSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockNotificationSettingAdapterMockRecorder) GetByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockNotificationSettingAdapter)(nil).GetByUserID), userID)
+}
+
+// Save mocks base method.
+func (m *MockNotificationSettingAdapter) Save(setting models.NotificationSetting) (*models.NotificationSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", setting)
+	ret0, _ := ret[0].(*models.NotificationSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockNotificationSettingAdapterMockRecorder) Save(setting any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockNotificationSettingAdapter)(nil).Save), setting)
+}
+
+// TakeSuppressedPushes mocks base method.
+func (m *MockNotificationSettingAdapter) TakeSuppressedPushes(userID string) ([]models.SuppressedPush, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeSuppressedPushes", userID)
+	ret0, _ := ret[0].([]models.SuppressedPush)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeSuppressedPushes indicates an expected call of TakeSuppressedPushes.
+func (mr *MockNotificationSettingAdapterMockRecorder) TakeSuppressedPushes(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeSuppressedPushes", reflect.TypeOf((*MockNotificationSettingAdapter)(nil).TakeSuppressedPushes), userID)
+}
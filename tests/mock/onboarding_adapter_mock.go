@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: adapter/onboarding_adapter.go
+//
+// Generated by this command:
+//
+//	mockgen -source=adapter/onboarding_adapter.go -destination=tests/mock/onboarding_adapter_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	adapter "github.com/hackathon-20260110/api/adapter"
+	models "github.com/hackathon-20260110/api/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOnboardingAdapter is a mock of OnboardingAdapter interface.
+type MockOnboardingAdapter struct {
+	ctrl     *gomock.Controller
+	recorder *MockOnboardingAdapterMockRecorder
+	isgomock struct{}
+}
+
+// MockOnboardingAdapterMockRecorder is the mock recorder for MockOnboardingAdapter.
+type MockOnboardingAdapterMockRecorder struct {
+	mock *MockOnboardingAdapter
+}
+
+// NewMockOnboardingAdapter creates a new mock instance.
+func NewMockOnboardingAdapter(ctrl *gomock.Controller) *MockOnboardingAdapter {
+	mock := &MockOnboardingAdapter{ctrl: ctrl}
+	mock.recorder = &MockOnboardingAdapterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOnboardingAdapter) EXPECT() *MockOnboardingAdapterMockRecorder {
+	return m.recorder
+}
+
+// CreateOnboardingChat mocks base method.
+func (m *MockOnboardingAdapter) CreateOnboardingChat(ctx context.Context, userID string, chat models.OnboardingChat) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOnboardingChat", ctx, userID, chat)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOnboardingChat indicates an expected call of CreateOnboardingChat.
+func (mr *MockOnboardingAdapterMockRecorder) CreateOnboardingChat(ctx, userID, chat any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOnboardingChat", reflect.TypeOf((*MockOnboardingAdapter)(nil).CreateOnboardingChat), ctx, userID, chat)
+}
+
+// GetOnboardingChats mocks base method.
+func (m *MockOnboardingAdapter) GetOnboardingChats(ctx context.Context, userID string, page adapter.ChatPage) ([]models.OnboardingChat, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOnboardingChats", ctx, userID, page)
+	ret0, _ := ret[0].([]models.OnboardingChat)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetOnboardingChats indicates an expected call of GetOnboardingChats.
+func (mr *MockOnboardingAdapterMockRecorder) GetOnboardingChats(ctx, userID, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOnboardingChats", reflect.TypeOf((*MockOnboardingAdapter)(nil).GetOnboardingChats), ctx, userID, page)
+}
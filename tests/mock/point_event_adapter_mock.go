@@ -0,0 +1,120 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: adapter/point_event_adapter.go
+//
+// Generated by this command:
+//
+//	mockgen -source=adapter/point_event_adapter.go -destination=tests/mock/point_event_adapter_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	time "time"
+
+	adapter "github.com/hackathon-20260110/api/adapter"
+	models "github.com/hackathon-20260110/api/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPointEventAdapter is a mock of PointEventAdapter interface.
+type MockPointEventAdapter struct {
+	ctrl     *gomock.Controller
+	recorder *MockPointEventAdapterMockRecorder
+	isgomock struct{}
+}
+
+// MockPointEventAdapterMockRecorder is the mock recorder for MockPointEventAdapter.
+type MockPointEventAdapterMockRecorder struct {
+	mock *MockPointEventAdapter
+}
+
+// NewMockPointEventAdapter creates a new mock instance.
+func NewMockPointEventAdapter(ctrl *gomock.Controller) *MockPointEventAdapter {
+	mock := &MockPointEventAdapter{ctrl: ctrl}
+	mock.recorder = &MockPointEventAdapterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPointEventAdapter) EXPECT() *MockPointEventAdapterMockRecorder {
+	return m.recorder
+}
+
+// ApplyAdminAdjustment mocks base method.
+func (m *MockPointEventAdapter) ApplyAdminAdjustment(adminUserID, userID, avatarID string, event models.PointEvent) (*adapter.PointChangeResult, *models.PointAdjustmentAudit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyAdminAdjustment", adminUserID, userID, avatarID, event)
+	ret0, _ := ret[0].(*adapter.PointChangeResult)
+	ret1, _ := ret[1].(*models.PointAdjustmentAudit)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ApplyAdminAdjustment indicates an expected call of ApplyAdminAdjustment.
+func (mr *MockPointEventAdapterMockRecorder) ApplyAdminAdjustment(adminUserID, userID, avatarID, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyAdminAdjustment", reflect.TypeOf((*MockPointEventAdapter)(nil).ApplyAdminAdjustment), adminUserID, userID, avatarID, event)
+}
+
+// ApplyDiagnosisResult mocks base method.
+func (m *MockPointEventAdapter) ApplyDiagnosisResult(history *models.DiagnosisHistory, cooldownSince time.Time, event models.PointEvent) (*adapter.PointChangeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyDiagnosisResult", history, cooldownSince, event)
+	ret0, _ := ret[0].(*adapter.PointChangeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyDiagnosisResult indicates an expected call of ApplyDiagnosisResult.
+func (mr *MockPointEventAdapterMockRecorder) ApplyDiagnosisResult(history, cooldownSince, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyDiagnosisResult", reflect.TypeOf((*MockPointEventAdapter)(nil).ApplyDiagnosisResult), history, cooldownSince, event)
+}
+
+// ApplyPointChange mocks base method.
+func (m *MockPointEventAdapter) ApplyPointChange(userID, avatarID string, event models.PointEvent) (*adapter.PointChangeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyPointChange", userID, avatarID, event)
+	ret0, _ := ret[0].(*adapter.PointChangeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyPointChange indicates an expected call of ApplyPointChange.
+func (mr *MockPointEventAdapterMockRecorder) ApplyPointChange(userID, avatarID, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyPointChange", reflect.TypeOf((*MockPointEventAdapter)(nil).ApplyPointChange), userID, avatarID, event)
+}
+
+// GetPointEventsByRelationID mocks base method.
+func (m *MockPointEventAdapter) GetPointEventsByRelationID(relationID string, limit, offset int) ([]models.PointEvent, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPointEventsByRelationID", relationID, limit, offset)
+	ret0, _ := ret[0].([]models.PointEvent)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPointEventsByRelationID indicates an expected call of GetPointEventsByRelationID.
+func (mr *MockPointEventAdapterMockRecorder) GetPointEventsByRelationID(relationID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPointEventsByRelationID", reflect.TypeOf((*MockPointEventAdapter)(nil).GetPointEventsByRelationID), relationID, limit, offset)
+}
+
+// SumDeltaBySource mocks base method.
+func (m *MockPointEventAdapter) SumDeltaBySource(relationID string) (map[models.PointEventSource]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumDeltaBySource", relationID)
+	ret0, _ := ret[0].(map[models.PointEventSource]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumDeltaBySource indicates an expected call of SumDeltaBySource.
+func (mr *MockPointEventAdapterMockRecorder) SumDeltaBySource(relationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumDeltaBySource", reflect.TypeOf((*MockPointEventAdapter)(nil).SumDeltaBySource), relationID)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: adapter/push_sender.go
+//
+// Generated by this command:
+//
+//	mockgen -source=adapter/push_sender.go -destination=tests/mock/push_sender_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	adapter "github.com/hackathon-20260110/api/adapter"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPushSender is a mock of PushSender interface.
+type MockPushSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockPushSenderMockRecorder
+	isgomock struct{}
+}
+
+// MockPushSenderMockRecorder is the mock recorder for MockPushSender.
+type MockPushSenderMockRecorder struct {
+	mock *MockPushSender
+}
+
+// NewMockPushSender creates a new mock instance.
+func NewMockPushSender(ctrl *gomock.Controller) *MockPushSender {
+	mock := &MockPushSender{ctrl: ctrl}
+	mock.recorder = &MockPushSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPushSender) EXPECT() *MockPushSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockPushSender) Send(ctx context.Context, tokens []string, message adapter.PushMessage) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, tokens, message)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockPushSenderMockRecorder) Send(ctx, tokens, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockPushSender)(nil).Send), ctx, tokens, message)
+}
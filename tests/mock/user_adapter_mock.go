@@ -70,6 +70,21 @@ func (mr *MockUserAdapterMockRecorder) GetByID(id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserAdapter)(nil).GetByID), id)
 }
 
+// GetByIDs mocks base method.
+func (m *MockUserAdapter) GetByIDs(ids []string) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ids)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockUserAdapterMockRecorder) GetByIDs(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockUserAdapter)(nil).GetByIDs), ids)
+}
+
 // Update mocks base method.
 func (m *MockUserAdapter) Update(user models.User) (models.User, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: adapter/user_chat_adapter.go
+//
+// Generated by this command:
+//
+//	mockgen -source=adapter/user_chat_adapter.go -destination=tests/mock/user_chat_adapter_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	adapter "github.com/hackathon-20260110/api/adapter"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserChatAdapter is a mock of UserChatAdapter interface.
+type MockUserChatAdapter struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserChatAdapterMockRecorder
+	isgomock struct{}
+}
+
+// MockUserChatAdapterMockRecorder is the mock recorder for MockUserChatAdapter.
+type MockUserChatAdapterMockRecorder struct {
+	mock *MockUserChatAdapter
+}
+
+// NewMockUserChatAdapter creates a new mock instance.
+func NewMockUserChatAdapter(ctrl *gomock.Controller) *MockUserChatAdapter {
+	mock := &MockUserChatAdapter{ctrl: ctrl}
+	mock.recorder = &MockUserChatAdapterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserChatAdapter) EXPECT() *MockUserChatAdapterMockRecorder {
+	return m.recorder
+}
+
+// CountMessagesFrom mocks base method.
+func (m *MockUserChatAdapter) CountMessagesFrom(ctx context.Context, user1ID, user2ID, senderID, after string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountMessagesFrom", ctx, user1ID, user2ID, senderID, after)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountMessagesFrom indicates an expected call of CountMessagesFrom.
+func (mr *MockUserChatAdapterMockRecorder) CountMessagesFrom(ctx, user1ID, user2ID, senderID, after any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountMessagesFrom", reflect.TypeOf((*MockUserChatAdapter)(nil).CountMessagesFrom), ctx, user1ID, user2ID, senderID, after)
+}
+
+// CreateUserChatMessage mocks base method.
+func (m *MockUserChatAdapter) CreateUserChatMessage(ctx context.Context, user1ID, user2ID string, message adapter.UserChatMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserChatMessage", ctx, user1ID, user2ID, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUserChatMessage indicates an expected call of CreateUserChatMessage.
+func (mr *MockUserChatAdapterMockRecorder) CreateUserChatMessage(ctx, user1ID, user2ID, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserChatMessage", reflect.TypeOf((*MockUserChatAdapter)(nil).CreateUserChatMessage), ctx, user1ID, user2ID, message)
+}
+
+// GetUserChatMessages mocks base method.
+func (m *MockUserChatAdapter) GetUserChatMessages(ctx context.Context, user1ID, user2ID string, page adapter.ChatPage) ([]adapter.UserChatMessage, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserChatMessages", ctx, user1ID, user2ID, page)
+	ret0, _ := ret[0].([]adapter.UserChatMessage)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserChatMessages indicates an expected call of GetUserChatMessages.
+func (mr *MockUserChatAdapterMockRecorder) GetUserChatMessages(ctx, user1ID, user2ID, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserChatMessages", reflect.TypeOf((*MockUserChatAdapter)(nil).GetUserChatMessages), ctx, user1ID, user2ID, page)
+}
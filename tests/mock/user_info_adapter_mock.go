@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: adapter/user_info_adapter.go
+//
+// Generated by this command:
+//
+//	mockgen -source=adapter/user_info_adapter.go -destination=tests/mock/user_info_adapter_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	models "github.com/hackathon-20260110/api/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserInfoAdapter is a mock of UserInfoAdapter interface.
+type MockUserInfoAdapter struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserInfoAdapterMockRecorder
+	isgomock struct{}
+}
+
+// MockUserInfoAdapterMockRecorder is the mock recorder for MockUserInfoAdapter.
+type MockUserInfoAdapterMockRecorder struct {
+	mock *MockUserInfoAdapter
+}
+
+// NewMockUserInfoAdapter creates a new mock instance.
+func NewMockUserInfoAdapter(ctrl *gomock.Controller) *MockUserInfoAdapter {
+	mock := &MockUserInfoAdapter{ctrl: ctrl}
+	mock.recorder = &MockUserInfoAdapterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserInfoAdapter) EXPECT() *MockUserInfoAdapterMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockUserInfoAdapter) Create(userInfo models.UserInfo) (*models.UserInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userInfo)
+	ret0, _ := ret[0].(*models.UserInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUserInfoAdapterMockRecorder) Create(userInfo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserInfoAdapter)(nil).Create), userInfo)
+}
+
+// CreateMany mocks base method.
+func (m *MockUserInfoAdapter) CreateMany(userInfos []*models.UserInfo) ([]*models.UserInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMany", userInfos)
+	ret0, _ := ret[0].([]*models.UserInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMany indicates an expected call of CreateMany.
+func (mr *MockUserInfoAdapterMockRecorder) CreateMany(userInfos any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMany", reflect.TypeOf((*MockUserInfoAdapter)(nil).CreateMany), userInfos)
+}
+
+// GetByID mocks base method.
+func (m *MockUserInfoAdapter) GetByID(id string) (*models.UserInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*models.UserInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockUserInfoAdapterMockRecorder) GetByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserInfoAdapter)(nil).GetByID), id)
+}
+
+// GetByUserID mocks base method.
+func (m *MockUserInfoAdapter) GetByUserID(userID string) ([]*models.UserInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", userID)
+	ret0, _ := ret[0].([]*models.UserInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockUserInfoAdapterMockRecorder) GetByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockUserInfoAdapter)(nil).GetByUserID), userID)
+}
+
+// Update mocks base method.
+func (m *MockUserInfoAdapter) Update(userInfo models.UserInfo) (*models.UserInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", userInfo)
+	ret0, _ := ret[0].(*models.UserInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockUserInfoAdapterMockRecorder) Update(userInfo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserInfoAdapter)(nil).Update), userInfo)
+}
@@ -0,0 +1,285 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/service"
+	"github.com/hackathon-20260110/api/tests/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+	"go.uber.org/mock/gomock"
+)
+
+type notificationDeliveryTestMocks struct {
+	notification *mock.MockNotificationAdapter
+	setting      *mock.MockNotificationSettingAdapter
+	deviceToken  *mock.MockDeviceTokenAdapter
+	job          *mock.MockJobAdapter
+	push         *adapter.RecordingPushSender
+}
+
+// provideNotificationDelivery NotificationService.Notifyが使うアダプターと、それを使うNotificationServiceをコンテナに登録する
+func provideNotificationDelivery(t *testing.T, ctrl *gomock.Controller, container *dig.Container) *notificationDeliveryTestMocks {
+	m := &notificationDeliveryTestMocks{
+		notification: mock.NewMockNotificationAdapter(ctrl),
+		setting:      mock.NewMockNotificationSettingAdapter(ctrl),
+		deviceToken:  mock.NewMockDeviceTokenAdapter(ctrl),
+		job:          mock.NewMockJobAdapter(ctrl),
+		push:         adapter.NewRecordingPushSender(),
+	}
+
+	require.NoError(t, container.Provide(func() adapter.NotificationAdapter { return m.notification }))
+	require.NoError(t, container.Provide(func() adapter.NotificationSettingAdapter { return m.setting }))
+	require.NoError(t, container.Provide(func() adapter.DeviceTokenAdapter { return m.deviceToken }))
+	require.NoError(t, container.Provide(func() adapter.JobAdapter { return m.job }))
+	require.NoError(t, container.Provide(func() adapter.PushSender { return m.push }))
+	require.NoError(t, container.Provide(service.NewNotificationService))
+	require.NoError(t, container.Provide(func(s *service.NotificationService) service.Notifier { return s }))
+	return m
+}
+
+// expectDefaultNotificationSettings 通知設定が未設定で端末も無いユーザーとして振る舞わせる
+func expectDefaultNotificationSettings(m *notificationDeliveryTestMocks) {
+	m.setting.EXPECT().GetByUserID(gomock.Any()).DoAndReturn(func(userID string) (*models.NotificationSetting, error) {
+		setting := models.DefaultNotificationSetting(userID)
+		return &setting, nil
+	}).AnyTimes()
+	m.deviceToken.EXPECT().GetByUserID(gomock.Any()).Return(nil, nil).AnyTimes()
+}
+
+// alwaysQuietSetting 現在時刻に関係なくおやすみ時間中になる設定（00:00〜23:59）
+func alwaysQuietSetting(userID string) *models.NotificationSetting {
+	setting := models.DefaultNotificationSetting(userID)
+	setting.QuietHoursEnabled = true
+	setting.QuietHoursStart = "00:00"
+	setting.QuietHoursEnd = "23:59"
+	return &setting
+}
+
+func TestNotificationService_Notify_SkipsDisabledType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container := dig.New()
+	m := provideNotificationDelivery(t, ctrl, container)
+
+	setting := models.DefaultNotificationSetting("user-1")
+	setting.MatchEnabled = false
+	m.setting.EXPECT().GetByUserID("user-1").Return(&setting, nil)
+
+	s := resolve[*service.NotificationService](t, container)
+	err := s.Notify(context.Background(), models.Notification{ID: "n-1", UserID: "user-1", Type: models.NotificationTypeMatch, Title: "マッチング成立！"})
+
+	require.NoError(t, err)
+	assert.Empty(t, m.push.Sent())
+}
+
+func TestNotificationService_Notify_PushesAndPrunesInvalidTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container := dig.New()
+	m := provideNotificationDelivery(t, ctrl, container)
+	m.push.MarkInvalid("stale")
+
+	setting := models.DefaultNotificationSetting("user-1")
+	m.setting.EXPECT().GetByUserID("user-1").Return(&setting, nil)
+	m.notification.EXPECT().CreateNotification(gomock.Any(), "user-1", gomock.Any()).Return(nil)
+	m.deviceToken.EXPECT().GetByUserID("user-1").Return([]models.DeviceToken{
+		{ID: "d-1", UserID: "user-1", Token: "fresh"},
+		{ID: "d-2", UserID: "user-1", Token: "stale"},
+	}, nil)
+	m.deviceToken.EXPECT().DeleteByTokens([]string{"stale"}).Return(nil)
+
+	s := resolve[*service.NotificationService](t, container)
+	err := s.Notify(context.Background(), models.Notification{
+		ID:       "n-1",
+		UserID:   "user-1",
+		Type:     models.NotificationTypeMatch,
+		Title:    "マッチング成立！",
+		Message:  "花子さんとマッチングしました",
+		DeepLink: "/matches/match-1",
+		Payload:  map[string]string{"matching_id": "match-1"},
+	})
+
+	require.NoError(t, err)
+	sent := m.push.Sent()
+	require.Len(t, sent, 1)
+	assert.Equal(t, []string{"fresh"}, sent[0].Tokens)
+	assert.Equal(t, "マッチング成立！", sent[0].Message.Title)
+	assert.Equal(t, "/matches/match-1", sent[0].Message.Data["deep_link"])
+	assert.Equal(t, "match-1", sent[0].Message.Data["matching_id"])
+}
+
+func TestNotificationService_Notify_SuppressesPushDuringQuietHours(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container := dig.New()
+	m := provideNotificationDelivery(t, ctrl, container)
+
+	m.setting.EXPECT().GetByUserID("user-1").Return(alwaysQuietSetting("user-1"), nil)
+	// 受信箱には保存する
+	m.notification.EXPECT().CreateNotification(gomock.Any(), "user-1", gomock.Any()).Return(nil)
+	m.setting.EXPECT().AddSuppressedPush(gomock.Any()).DoAndReturn(func(push models.SuppressedPush) error {
+		assert.Equal(t, "n-1", push.NotificationID)
+		assert.Equal(t, models.NotificationTypeMissionUnlock, push.Type)
+		return nil
+	})
+	m.job.EXPECT().GetLatestJobBySubject(models.JobTypeNotificationDigest, "user-1").Return(nil, nil)
+	m.job.EXPECT().Enqueue(gomock.Any()).DoAndReturn(func(job *models.Job) error {
+		assert.Equal(t, models.JobTypeNotificationDigest, job.Type)
+		assert.Equal(t, "user-1", job.SubjectID)
+		assert.True(t, job.RunAt.After(time.Now()))
+		return nil
+	})
+
+	s := resolve[*service.NotificationService](t, container)
+	err := s.Notify(context.Background(), models.Notification{ID: "n-1", UserID: "user-1", Type: models.NotificationTypeMissionUnlock, Title: "ミッション解放"})
+
+	require.NoError(t, err)
+	assert.Empty(t, m.push.Sent())
+}
+
+func TestNotificationService_Notify_ReusesQueuedDigest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container := dig.New()
+	m := provideNotificationDelivery(t, ctrl, container)
+
+	m.setting.EXPECT().GetByUserID("user-1").Return(alwaysQuietSetting("user-1"), nil)
+	m.notification.EXPECT().CreateNotification(gomock.Any(), "user-1", gomock.Any()).Return(nil)
+	m.setting.EXPECT().AddSuppressedPush(gomock.Any()).Return(nil)
+	m.job.EXPECT().GetLatestJobBySubject(models.JobTypeNotificationDigest, "user-1").
+		Return(&models.Job{ID: "job-1", Status: models.JobStatusQueued}, nil)
+
+	s := resolve[*service.NotificationService](t, container)
+	err := s.Notify(context.Background(), models.Notification{ID: "n-2", UserID: "user-1", Type: models.NotificationTypeMessage, Title: "新着メッセージ"})
+
+	require.NoError(t, err)
+	assert.Empty(t, m.push.Sent())
+}
+
+func TestNotificationService_SendNotificationDigest_SendsOnePush(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container := dig.New()
+	m := provideNotificationDelivery(t, ctrl, container)
+
+	setting := models.DefaultNotificationSetting("user-1")
+	m.setting.EXPECT().GetByUserID("user-1").Return(&setting, nil)
+	m.setting.EXPECT().TakeSuppressedPushes("user-1").Return([]models.SuppressedPush{
+		{ID: "s-1", UserID: "user-1", NotificationID: "n-1", Title: "ミッション解放"},
+		{ID: "s-2", UserID: "user-1", NotificationID: "n-2", Title: "新着メッセージ"},
+		{ID: "s-3", UserID: "user-1", NotificationID: "n-3", Title: "マッチング成立！"},
+	}, nil)
+	m.deviceToken.EXPECT().GetByUserID("user-1").Return([]models.DeviceToken{{ID: "d-1", UserID: "user-1", Token: "fresh"}}, nil)
+
+	payload, err := json.Marshal(map[string]string{"user_id": "user-1"})
+	require.NoError(t, err)
+
+	s := resolve[*service.NotificationService](t, container)
+	err = s.SendNotificationDigest(context.Background(), models.Job{ID: "job-1", Type: models.JobTypeNotificationDigest, Payload: string(payload)})
+
+	require.NoError(t, err)
+	sent := m.push.Sent()
+	require.Len(t, sent, 1)
+	assert.Equal(t, "「マッチング成立！」ほか2件の通知があります", sent[0].Message.Body)
+	assert.Equal(t, "3", sent[0].Message.Data["count"])
+}
+
+func TestNotificationService_SendNotificationDigest_WaitsWhileStillQuiet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container := dig.New()
+	m := provideNotificationDelivery(t, ctrl, container)
+
+	m.setting.EXPECT().GetByUserID("user-1").Return(alwaysQuietSetting("user-1"), nil)
+	m.job.EXPECT().Enqueue(gomock.Any()).Return(nil)
+
+	payload, err := json.Marshal(map[string]string{"user_id": "user-1"})
+	require.NoError(t, err)
+
+	s := resolve[*service.NotificationService](t, container)
+	err = s.SendNotificationDigest(context.Background(), models.Job{ID: "job-1", Type: models.JobTypeNotificationDigest, Payload: string(payload)})
+
+	require.NoError(t, err)
+	assert.Empty(t, m.push.Sent())
+}
+
+func TestNotificationSetting_QuietUntil(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		start     string
+		end       string
+		now       time.Time
+		wantQuiet bool
+		wantUntil time.Time
+	}{
+		{
+			name:      "日をまたぐ設定の夜",
+			start:     "22:00",
+			end:       "07:00",
+			now:       time.Date(2026, 1, 10, 23, 30, 0, 0, tokyo),
+			wantQuiet: true,
+			wantUntil: time.Date(2026, 1, 11, 7, 0, 0, 0, tokyo),
+		},
+		{
+			name:      "日をまたぐ設定の早朝",
+			start:     "22:00",
+			end:       "07:00",
+			now:       time.Date(2026, 1, 11, 6, 59, 0, 0, tokyo),
+			wantQuiet: true,
+			wantUntil: time.Date(2026, 1, 11, 7, 0, 0, 0, tokyo),
+		},
+		{
+			name:  "日をまたぐ設定の終了時刻ちょうど",
+			start: "22:00",
+			end:   "07:00",
+			now:   time.Date(2026, 1, 11, 7, 0, 0, 0, tokyo),
+		},
+		{
+			name:      "同じ日の中の設定",
+			start:     "13:00",
+			end:       "15:00",
+			now:       time.Date(2026, 1, 10, 14, 0, 0, 0, tokyo),
+			wantQuiet: true,
+			wantUntil: time.Date(2026, 1, 10, 15, 0, 0, 0, tokyo),
+		},
+		{
+			name:  "UTCで渡されてもユーザーのタイムゾーンで判定する",
+			start: "22:00",
+			end:   "07:00",
+			// 東京では12:00
+			now: time.Date(2026, 1, 10, 3, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setting := models.DefaultNotificationSetting("user-1")
+			setting.QuietHoursEnabled = true
+			setting.QuietHoursStart = tt.start
+			setting.QuietHoursEnd = tt.end
+
+			until, quiet := setting.QuietUntil(tt.now)
+
+			assert.Equal(t, tt.wantQuiet, quiet)
+			if tt.wantQuiet {
+				assert.True(t, tt.wantUntil.Equal(until), "got %v", until)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/service"
+	"github.com/hackathon-20260110/api/tests/mock"
+	"github.com/hackathon-20260110/api/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+	"go.uber.org/mock/gomock"
+)
+
+func newNotificationServiceTestContainer(t *testing.T, ctrl *gomock.Controller) (*dig.Container, *mock.MockNotificationAdapter) {
+	container := dig.New()
+	m := provideNotificationDelivery(t, ctrl, container)
+	return container, m.notification
+}
+
+func TestNotificationService_ListNotifications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container, mockNotificationAdapter := newNotificationServiceTestContainer(t, ctrl)
+
+	createdAt := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	mockNotificationAdapter.EXPECT().ListNotifications(gomock.Any(), "user-1", "cursor-1", 2).Return([]models.Notification{
+		{
+			ID:        "n-2",
+			Type:      models.NotificationTypeMatch,
+			Title:     "マッチング成立",
+			Message:   "佐藤花子さんとマッチングしました！",
+			DeepLink:  "/matches/match-1",
+			Payload:   map[string]string{"matching_id": "match-1"},
+			CreatedAt: createdAt,
+		},
+		{ID: "n-1", Type: models.NotificationTypeSystem, HasRead: true, CreatedAt: createdAt.Add(-time.Hour)},
+	}, "n-1", nil)
+
+	s := resolve[*service.NotificationService](t, container)
+	result, err := s.ListNotifications(context.Background(), "user-1", "cursor-1", 2)
+
+	require.NoError(t, err)
+	require.Len(t, result.Notifications, 2)
+	assert.Equal(t, "match", result.Notifications[0].Type)
+	assert.Equal(t, "/matches/match-1", result.Notifications[0].DeepLink)
+	assert.Equal(t, "match-1", result.Notifications[0].Payload["matching_id"])
+	assert.False(t, result.Notifications[0].HasRead)
+	assert.True(t, result.Notifications[1].HasRead)
+	assert.Equal(t, "n-1", result.NextCursor)
+	assert.True(t, result.HasMore)
+}
+
+func TestNotificationService_ListNotifications_LastPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container, mockNotificationAdapter := newNotificationServiceTestContainer(t, ctrl)
+
+	mockNotificationAdapter.EXPECT().ListNotifications(gomock.Any(), "user-1", "", 20).Return([]models.Notification{}, "", nil)
+
+	s := resolve[*service.NotificationService](t, container)
+	result, err := s.ListNotifications(context.Background(), "user-1", "", 20)
+
+	require.NoError(t, err)
+	assert.Empty(t, result.Notifications)
+	assert.NotNil(t, result.Notifications)
+	assert.False(t, result.HasMore)
+}
+
+func TestNotificationService_UnreadCountAndReadAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container, mockNotificationAdapter := newNotificationServiceTestContainer(t, ctrl)
+
+	mockNotificationAdapter.EXPECT().CountUnread(gomock.Any(), "user-1").Return(int64(3), nil)
+	mockNotificationAdapter.EXPECT().MarkAllAsRead(gomock.Any(), "user-1").Return(3, nil)
+
+	s := resolve[*service.NotificationService](t, container)
+
+	count, err := s.GetUnreadCount(context.Background(), "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	updated, err := s.MarkAllAsRead(context.Background(), "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, updated)
+}
+
+func TestNotificationService_DeleteNotification_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container, mockNotificationAdapter := newNotificationServiceTestContainer(t, ctrl)
+
+	mockNotificationAdapter.EXPECT().DeleteNotification(gomock.Any(), "user-1", "missing").Return(utils.ErrorRecordNotFound)
+
+	s := resolve[*service.NotificationService](t, container)
+	err := s.DeleteNotification(context.Background(), "user-1", "missing")
+
+	assert.ErrorIs(t, err, utils.ErrorRecordNotFound)
+}
@@ -0,0 +1,201 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/service"
+	"github.com/hackathon-20260110/api/tests/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOnboardingService_FinishOnboarding_StoresPersonaAndTraits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOnboardingAdapter := mock.NewMockOnboardingAdapter(ctrl)
+	mockUserAdapter := mock.NewMockUserAdapter(ctrl)
+	mockAvatarAdapter := mock.NewMockAvatarAdapter(ctrl)
+	mockUserInfoAdapter := mock.NewMockUserInfoAdapter(ctrl)
+
+	container := dig.New()
+	require.NoError(t, container.Provide(func() adapter.OnboardingAdapter { return mockOnboardingAdapter }))
+	require.NoError(t, container.Provide(func() adapter.UserAdapter { return mockUserAdapter }))
+	require.NoError(t, container.Provide(func() adapter.JobAdapter { return mock.NewMockJobAdapter(ctrl) }))
+	require.NoError(t, container.Provide(func() adapter.AvatarAdapter { return mockAvatarAdapter }))
+	require.NoError(t, container.Provide(func() adapter.UserInfoAdapter { return mockUserInfoAdapter }))
+	require.NoError(t, container.Provide(func() adapter.LLMAdapter { return adapter.NewScriptedLLMAdapter() }))
+
+	mockUserAdapter.EXPECT().GetByID("user-1").Return(models.User{ID: "user-1", DisplayName: "花子"}, nil)
+	mockOnboardingAdapter.EXPECT().GetOnboardingChats(gomock.Any(), "user-1", adapter.ChatPage{}).Return([]models.OnboardingChat{
+		{SenderType: models.SenderTypeSystem, Message: "休日は何をしていますか？"},
+		{SenderType: models.SenderTypeUser, Message: "カフェ巡りをしています"},
+	}, "", nil)
+	mockUserInfoAdapter.EXPECT().CreateMany(gomock.Any()).DoAndReturn(func(userInfos []*models.UserInfo) ([]*models.UserInfo, error) {
+		return userInfos, nil
+	})
+	var created models.Avatar
+	mockAvatarAdapter.EXPECT().Create(gomock.Any()).DoAndReturn(func(avatar models.Avatar) (*models.Avatar, error) {
+		created = avatar
+		return &avatar, nil
+	})
+	mockUserAdapter.EXPECT().Update(gomock.Any()).DoAndReturn(func(user models.User) (models.User, error) {
+		assert.True(t, user.IsOnboardingCompleted)
+		return user, nil
+	})
+
+	s := newService[*service.OnboardingService](t, container, service.NewOnboardingService)
+	_, avatar, err := s.FinishOnboarding(context.Background(), "user-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, avatar.ID)
+	assert.NotEmpty(t, avatar.Prompt)
+
+	var traits models.PersonalityTraits
+	require.NoError(t, json.Unmarshal([]byte(avatar.PersonalityTraits), &traits))
+	assert.Equal(t, 5, traits.BigFive.Agreeableness)
+	assert.Contains(t, traits.Interests, "カフェ巡り")
+	assert.NotEmpty(t, traits.DatingValues)
+}
+
+func TestOnboardingService_FinishOnboarding_InvalidPersonaSavesNothing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOnboardingAdapter := mock.NewMockOnboardingAdapter(ctrl)
+	mockUserAdapter := mock.NewMockUserAdapter(ctrl)
+
+	container := dig.New()
+	require.NoError(t, container.Provide(func() adapter.OnboardingAdapter { return mockOnboardingAdapter }))
+	require.NoError(t, container.Provide(func() adapter.UserAdapter { return mockUserAdapter }))
+	require.NoError(t, container.Provide(func() adapter.JobAdapter { return mock.NewMockJobAdapter(ctrl) }))
+	require.NoError(t, container.Provide(func() adapter.AvatarAdapter { return mock.NewMockAvatarAdapter(ctrl) }))
+	require.NoError(t, container.Provide(func() adapter.UserInfoAdapter { return mock.NewMockUserInfoAdapter(ctrl) }))
+	require.NoError(t, container.Provide(func() adapter.LLMAdapter {
+		return adapter.NewScriptedLLMAdapterWithScripts(map[adapter.LLMTask][]string{
+			// Big Fiveが範囲外のまま直らない
+			adapter.LLMTaskOnboardingPersona: {`{"persona_prompt": "明るい", "traits": {"big_five": {"openness": 9, "conscientiousness": 3, "extraversion": 3, "agreeableness": 3, "neuroticism": 3}, "interests": [], "dating_values": [], "speaking_style": "", "topics_to_avoid": []}}`},
+		})
+	}))
+
+	mockUserAdapter.EXPECT().GetByID("user-1").Return(models.User{ID: "user-1", DisplayName: "花子"}, nil)
+	mockOnboardingAdapter.EXPECT().GetOnboardingChats(gomock.Any(), "user-1", adapter.ChatPage{}).Return(nil, "", nil)
+
+	s := newService[*service.OnboardingService](t, container, service.NewOnboardingService)
+	_, _, err := s.FinishOnboarding(context.Background(), "user-1")
+
+	assert.ErrorIs(t, err, adapter.ErrLLMInvalidStructuredOutput)
+}
+
+func TestOnboardingService_SendOnboardingMessage_EnqueuesReplyJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOnboardingAdapter := mock.NewMockOnboardingAdapter(ctrl)
+	mockUserAdapter := mock.NewMockUserAdapter(ctrl)
+	mockJobAdapter := mock.NewMockJobAdapter(ctrl)
+
+	container := dig.New()
+	require.NoError(t, container.Provide(func() adapter.OnboardingAdapter { return mockOnboardingAdapter }))
+	require.NoError(t, container.Provide(func() adapter.UserAdapter { return mockUserAdapter }))
+	require.NoError(t, container.Provide(func() adapter.AvatarAdapter { return mock.NewMockAvatarAdapter(ctrl) }))
+	require.NoError(t, container.Provide(func() adapter.UserInfoAdapter { return mock.NewMockUserInfoAdapter(ctrl) }))
+	require.NoError(t, container.Provide(func() adapter.LLMAdapter { return mock.NewMockLLMAdapter(ctrl) }))
+	require.NoError(t, container.Provide(func() adapter.JobAdapter { return mockJobAdapter }))
+
+	mockUserAdapter.EXPECT().GetByID("user-1").Return(models.User{ID: "user-1"}, nil)
+	var savedID string
+	mockOnboardingAdapter.EXPECT().CreateOnboardingChat(gomock.Any(), "user-1", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, userID string, chat models.OnboardingChat) error {
+			savedID = chat.ID
+			return nil
+		})
+	mockJobAdapter.EXPECT().Enqueue(gomock.Any()).DoAndReturn(func(job *models.Job) error {
+		assert.Equal(t, models.JobTypeOnboardingReply, job.Type)
+		assert.Equal(t, "user-1", job.SubjectID)
+		assert.JSONEq(t, `{"user_id": "user-1", "message_id": "`+savedID+`"}`, job.Payload)
+		return nil
+	})
+
+	s := newService[*service.OnboardingService](t, container, service.NewOnboardingService)
+	require.NoError(t, s.SendOnboardingMessage(context.Background(), "user-1", "カフェ巡りが好きです"))
+}
+
+func TestOnboardingService_ProcessOnboardingReply_SkipsWhenAlreadyReplied(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOnboardingAdapter := mock.NewMockOnboardingAdapter(ctrl)
+	mockUserAdapter := mock.NewMockUserAdapter(ctrl)
+
+	container := dig.New()
+	require.NoError(t, container.Provide(func() adapter.OnboardingAdapter { return mockOnboardingAdapter }))
+	require.NoError(t, container.Provide(func() adapter.UserAdapter { return mockUserAdapter }))
+	require.NoError(t, container.Provide(func() adapter.JobAdapter { return mock.NewMockJobAdapter(ctrl) }))
+	require.NoError(t, container.Provide(func() adapter.AvatarAdapter { return mock.NewMockAvatarAdapter(ctrl) }))
+	require.NoError(t, container.Provide(func() adapter.UserInfoAdapter { return mock.NewMockUserInfoAdapter(ctrl) }))
+	// 返信済みならLLMは呼ばれない
+	require.NoError(t, container.Provide(func() adapter.LLMAdapter { return mock.NewMockLLMAdapter(ctrl) }))
+
+	mockUserAdapter.EXPECT().GetByID("user-1").Return(models.User{ID: "user-1"}, nil)
+	mockOnboardingAdapter.EXPECT().GetOnboardingChats(gomock.Any(), "user-1", adapter.ChatPage{}).Return([]models.OnboardingChat{
+		{SenderType: models.SenderTypeUser, Message: "カフェ巡りが好きです"},
+		{SenderType: models.SenderTypeSystem, Message: "素敵ですね！"},
+	}, "", nil)
+
+	s := newService[*service.OnboardingService](t, container, service.NewOnboardingService)
+	err := s.ProcessOnboardingReply(context.Background(), models.Job{
+		Type:    models.JobTypeOnboardingReply,
+		Payload: `{"user_id": "user-1", "message_id": "msg-1"}`,
+	})
+
+	require.NoError(t, err)
+}
+
+func TestOnboardingService_ProcessOnboardingReply_SavesSystemReply(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOnboardingAdapter := mock.NewMockOnboardingAdapter(ctrl)
+	mockUserAdapter := mock.NewMockUserAdapter(ctrl)
+
+	container := dig.New()
+	require.NoError(t, container.Provide(func() adapter.OnboardingAdapter { return mockOnboardingAdapter }))
+	require.NoError(t, container.Provide(func() adapter.UserAdapter { return mockUserAdapter }))
+	require.NoError(t, container.Provide(func() adapter.JobAdapter { return mock.NewMockJobAdapter(ctrl) }))
+	require.NoError(t, container.Provide(func() adapter.AvatarAdapter { return mock.NewMockAvatarAdapter(ctrl) }))
+	require.NoError(t, container.Provide(func() adapter.UserInfoAdapter { return mock.NewMockUserInfoAdapter(ctrl) }))
+	require.NoError(t, container.Provide(func() adapter.LLMAdapter {
+		return adapter.NewScriptedLLMAdapterWithScripts(map[adapter.LLMTask][]string{
+			adapter.LLMTaskOnboardingJudge:    {"FALSE"},
+			adapter.LLMTaskOnboardingQuestion: {"どんなカフェが好きですか？"},
+		})
+	}))
+
+	mockUserAdapter.EXPECT().GetByID("user-1").Return(models.User{ID: "user-1", DisplayName: "花子"}, nil)
+	mockOnboardingAdapter.EXPECT().GetOnboardingChats(gomock.Any(), "user-1", adapter.ChatPage{}).Return([]models.OnboardingChat{
+		{SenderType: models.SenderTypeSystem, Message: "休日は何をしていますか？"},
+		{SenderType: models.SenderTypeUser, Message: "カフェ巡りが好きです"},
+	}, "", nil)
+	mockOnboardingAdapter.EXPECT().CreateOnboardingChat(gomock.Any(), "user-1", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, userID string, chat models.OnboardingChat) error {
+			assert.Equal(t, models.SenderTypeSystem, chat.SenderType)
+			assert.Equal(t, "どんなカフェが好きですか？", chat.Message)
+			assert.False(t, chat.IsOnboardingCompleted)
+			return nil
+		})
+
+	s := newService[*service.OnboardingService](t, container, service.NewOnboardingService)
+	err := s.ProcessOnboardingReply(context.Background(), models.Job{
+		Type:    models.JobTypeOnboardingReply,
+		Payload: `{"user_id": "user-1", "message_id": "msg-1"}`,
+	})
+
+	require.NoError(t, err)
+}
@@ -1,7 +1,6 @@
 package tests
 
 import (
-	"os"
 	"testing"
 
 	"github.com/hackathon-20260110/api/adapter"
@@ -115,10 +114,7 @@ func TestBuildPublicURL(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			os.Setenv("R2_PUBLIC_BASE_URL", tt.baseURL)
-			defer os.Unsetenv("R2_PUBLIC_BASE_URL")
-
-			result := adapter.BuildPublicURL(tt.objectKey)
+			result := adapter.BuildPublicURL(tt.baseURL, tt.objectKey)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
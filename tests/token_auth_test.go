@@ -0,0 +1,218 @@
+package tests
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/config"
+	"github.com/hackathon-20260110/api/controller"
+	"github.com/hackathon-20260110/api/middleware"
+	"github.com/hackathon-20260110/api/router"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+)
+
+func newLocalTokenAuth(t *testing.T, local config.LocalAuthConfig) *adapter.LocalTokenAuth {
+	t.Helper()
+	if local.TokenTTL == 0 {
+		local.TokenTTL = time.Hour
+	}
+	tokenAuth, err := adapter.NewLocalTokenAuth(&config.Config{Auth: config.AuthConfig{Provider: config.AuthProviderLocal, Local: local}})
+	require.NoError(t, err)
+	return tokenAuth
+}
+
+// generateRSAKeyPEM RS256のテスト用に、PKCS#8の秘密鍵とPKIXの公開鍵をPEMで返す
+func generateRSAKeyPEM(t *testing.T) (string, string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	privateDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	publicDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+}
+
+func TestLocalTokenAuth_HS256(t *testing.T) {
+	ctx := context.Background()
+	tokenAuth := newLocalTokenAuth(t, config.LocalAuthConfig{Algorithm: config.LocalAuthHS256, Secret: "test-secret"})
+
+	issued, err := tokenAuth.IssueIDTokenWithRole(ctx, "user-1", middleware.RoleAdmin)
+	require.NoError(t, err)
+	assert.Equal(t, "3600", issued.ExpiresIn)
+
+	verified, err := tokenAuth.VerifyIDToken(ctx, issued.IDToken)
+	require.NoError(t, err)
+	assert.Equal(t, &adapter.VerifiedToken{UserID: "user-1", Role: middleware.RoleAdmin}, verified)
+
+	_, err = tokenAuth.VerifyIDToken(ctx, issued.IDToken+"x")
+	assert.ErrorIs(t, err, adapter.ErrInvalidIDToken)
+
+	// 別の鍵で署名したトークンは受け付けない
+	other := newLocalTokenAuth(t, config.LocalAuthConfig{Algorithm: config.LocalAuthHS256, Secret: "other-secret"})
+	_, err = other.VerifyIDToken(ctx, issued.IDToken)
+	assert.ErrorIs(t, err, adapter.ErrInvalidIDToken)
+
+	expiredAuth := newLocalTokenAuth(t, config.LocalAuthConfig{Algorithm: config.LocalAuthHS256, Secret: "test-secret", TokenTTL: -time.Minute})
+	expired, err := expiredAuth.IssueIDToken(ctx, "user-1")
+	require.NoError(t, err)
+	_, err = tokenAuth.VerifyIDToken(ctx, expired.IDToken)
+	assert.ErrorIs(t, err, adapter.ErrInvalidIDToken)
+}
+
+func TestLocalTokenAuth_RS256(t *testing.T) {
+	ctx := context.Background()
+	privateKey, publicKey := generateRSAKeyPEM(t)
+	tokenAuth := newLocalTokenAuth(t, config.LocalAuthConfig{Algorithm: config.LocalAuthRS256, PrivateKey: privateKey})
+
+	issued, err := tokenAuth.IssueIDToken(ctx, "user-1")
+	require.NoError(t, err)
+
+	// 公開鍵だけでも検証できる（環境変数のように改行を \n で書いても読める）
+	verifier := newLocalTokenAuth(t, config.LocalAuthConfig{Algorithm: config.LocalAuthRS256, PublicKey: strings.ReplaceAll(publicKey, "\n", `\n`)})
+	verified, err := verifier.VerifyIDToken(ctx, issued.IDToken)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", verified.UserID)
+	assert.Empty(t, verified.Role)
+
+	_, err = verifier.IssueIDToken(ctx, "user-1")
+	assert.ErrorIs(t, err, adapter.ErrTokenIssuerNotConfigured)
+
+	// HS256で署名したトークンは、algを書き換えてもRS256の検証器では受け付けない
+	hs256 := newLocalTokenAuth(t, config.LocalAuthConfig{Algorithm: config.LocalAuthHS256, Secret: publicKey})
+	forged, err := hs256.IssueIDTokenWithRole(ctx, "user-1", middleware.RoleAdmin)
+	require.NoError(t, err)
+	_, err = verifier.VerifyIDToken(ctx, forged.IDToken)
+	assert.ErrorIs(t, err, adapter.ErrInvalidIDToken)
+
+	parts := strings.Split(forged.IDToken, ".")
+	parts[0] = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	_, err = verifier.VerifyIDToken(ctx, strings.Join(parts, "."))
+	assert.ErrorIs(t, err, adapter.ErrInvalidIDToken)
+}
+
+func TestNewLocalTokenAuth_RejectsInvalidKeys(t *testing.T) {
+	for _, local := range []config.LocalAuthConfig{
+		{Algorithm: config.LocalAuthHS256},
+		{Algorithm: config.LocalAuthRS256, PrivateKey: "not a key"},
+		{Algorithm: config.LocalAuthRS256, PublicKey: "not a key"},
+		{Algorithm: "none", Secret: "secret"},
+	} {
+		_, err := adapter.NewLocalTokenAuth(&config.Config{Auth: config.AuthConfig{Local: local}})
+		assert.Error(t, err)
+	}
+}
+
+func TestFirebaseAuthMiddleware_UsesTokenVerifier(t *testing.T) {
+	tokenAuth := newLocalTokenAuth(t, config.LocalAuthConfig{Algorithm: config.LocalAuthHS256, Secret: "test-secret"})
+	admin, err := tokenAuth.IssueIDTokenWithRole(context.Background(), "admin-1", middleware.RoleAdmin)
+	require.NoError(t, err)
+	user, err := tokenAuth.IssueIDToken(context.Background(), "user-1")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+		wantUserID    string
+	}{
+		{name: "admin", authorization: "Bearer " + admin.IDToken, wantStatus: http.StatusOK, wantUserID: "admin-1"},
+		{name: "without role", authorization: "Bearer " + user.IDToken, wantStatus: http.StatusForbidden},
+		{name: "invalid token", authorization: "Bearer " + user.IDToken + "x", wantStatus: http.StatusUnauthorized},
+		{name: "missing header", wantStatus: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/admin/users", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			c := e.NewContext(req, rec)
+
+			var gotUserID string
+			handler := middleware.FirebaseAuthMiddleware(tokenAuth)(middleware.RequireRole(middleware.RoleAdmin)(func(c echo.Context) error {
+				gotUserID = middleware.GetFirebaseUID(c)
+				return c.NoContent(http.StatusOK)
+			}))
+			require.NoError(t, handler(c))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantUserID, gotUserID)
+		})
+	}
+}
+
+func TestDebugTokenController_IDTokenUsesTokenIssuer(t *testing.T) {
+	tokenAuth := newLocalTokenAuth(t, config.LocalAuthConfig{Algorithm: config.LocalAuthHS256, Secret: "test-secret"})
+	debug := controller.NewDebugTokenController(tokenAuth)
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/debug/id-token?userId=user-1&role=admin", nil), rec)
+	require.NoError(t, debug.IDToken(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"user_id":"user-1"`)
+
+	// 公開鍵しか無いRS256では発行できない
+	_, publicKey := generateRSAKeyPEM(t)
+	verifyOnly := controller.NewDebugTokenController(newLocalTokenAuth(t, config.LocalAuthConfig{Algorithm: config.LocalAuthRS256, PublicKey: publicKey}))
+	rec = httptest.NewRecorder()
+	c = e.NewContext(httptest.NewRequest(http.MethodGet, "/debug/id-token?userId=user-1", nil), rec)
+	require.NoError(t, verifyOnly.IDToken(c))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestDebugRouter_RegistersIDTokenOnlyWithLocalAuth(t *testing.T) {
+	tokenAuth := newLocalTokenAuth(t, config.LocalAuthConfig{Algorithm: config.LocalAuthHS256, Secret: "test-secret"})
+	for _, tt := range []struct {
+		provider    string
+		wantIDToken bool
+	}{
+		{provider: config.AuthProviderLocal, wantIDToken: true},
+		{provider: config.AuthProviderFirebase, wantIDToken: false},
+	} {
+		t.Run(tt.provider, func(t *testing.T) {
+			container := dig.New()
+			require.NoError(t, container.Provide(func() *config.Config {
+				return &config.Config{Auth: config.AuthConfig{Provider: tt.provider}}
+			}))
+			require.NoError(t, container.Provide(func() adapter.TokenVerifier { return tokenAuth }))
+			require.NoError(t, container.Provide(controller.NewDebugController))
+			if tt.provider == config.AuthProviderLocal {
+				require.NoError(t, container.Provide(func() adapter.TokenIssuer { return tokenAuth }))
+				require.NoError(t, container.Provide(controller.NewDebugTokenController))
+			}
+
+			e := echo.New()
+			router.DebugRouter(e, container)
+
+			// ヘルスチェックはどのAUTH_PROVIDERでも使える
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/health", nil))
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			rec = httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/id-token?userId=user-1", nil))
+			if tt.wantIDToken {
+				assert.Equal(t, http.StatusOK, rec.Code)
+			} else {
+				assert.Equal(t, http.StatusNotFound, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/service"
+	"github.com/hackathon-20260110/api/tests/mock"
+	"github.com/hackathon-20260110/api/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type userChatTestMocks struct {
+	userChat   *mock.MockUserChatAdapter
+	matching   *mock.MockMatchingAdapter
+	user       *mock.MockUserAdapter
+	readCursor *mock.MockChatReadCursorAdapter
+	summary    *mock.MockChatSummaryAdapter
+	delivery   *notificationDeliveryTestMocks
+}
+
+func newUserChatTestContainer(t *testing.T, ctrl *gomock.Controller) (*dig.Container, *userChatTestMocks) {
+	m := &userChatTestMocks{
+		userChat:   mock.NewMockUserChatAdapter(ctrl),
+		matching:   mock.NewMockMatchingAdapter(ctrl),
+		user:       mock.NewMockUserAdapter(ctrl),
+		readCursor: mock.NewMockChatReadCursorAdapter(ctrl),
+		summary:    mock.NewMockChatSummaryAdapter(ctrl),
+	}
+
+	container := dig.New()
+	require.NoError(t, container.Provide(func() adapter.UserChatAdapter { return m.userChat }))
+	require.NoError(t, container.Provide(func() adapter.MatchingAdapter { return m.matching }))
+	require.NoError(t, container.Provide(func() adapter.UserAdapter { return m.user }))
+	require.NoError(t, container.Provide(func() adapter.ChatReadCursorAdapter { return m.readCursor }))
+	require.NoError(t, container.Provide(func() adapter.ChatSummaryAdapter { return m.summary }))
+	m.delivery = provideNotificationDelivery(t, ctrl, container)
+	expectDefaultNotificationSettings(m.delivery)
+	return container, m
+}
+
+func TestUserChatService_SendMatchMessage_StoresAndNotifiesPartner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container, m := newUserChatTestContainer(t, ctrl)
+
+	m.matching.EXPECT().GetByID("match-1").Return(&models.Matching{ID: "match-1", User1ID: "user-1", User2ID: "user-2"}, nil)
+	m.userChat.EXPECT().CreateUserChatMessage(gomock.Any(), "user-2", "user-1", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, user1ID, user2ID string, message adapter.UserChatMessage) error {
+			assert.Equal(t, "user-2", message.SenderID)
+			assert.Equal(t, models.SenderTypeUser, message.SenderType)
+			assert.Equal(t, "はじめまして！", message.Message)
+			return nil
+		})
+	// 返信した時点で相手のメッセージは読んでいる
+	m.readCursor.EXPECT().Advance(gomock.Any()).DoAndReturn(func(cursor *models.ChatReadCursor) error {
+		assert.Equal(t, "user-2", cursor.UserID)
+		assert.Equal(t, models.ChatKindUser, cursor.ChatKind)
+		assert.Equal(t, "user-1", cursor.PartnerID)
+		assert.NotEmpty(t, cursor.LastReadMessageID)
+		return nil
+	})
+	m.user.EXPECT().GetByID("user-2").Return(models.User{ID: "user-2", DisplayName: "花子"}, nil)
+	m.delivery.notification.EXPECT().CreateNotification(gomock.Any(), "user-1", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, userID string, notification models.Notification) error {
+			assert.Equal(t, models.NotificationTypeMessage, notification.Type)
+			assert.Equal(t, "花子さんからメッセージが届きました", notification.Title)
+			assert.Equal(t, "はじめまして！", notification.Message)
+			assert.Equal(t, "/matches/match-1", notification.DeepLink)
+			assert.Equal(t, "user-2", notification.Payload["sender_user_id"])
+			return nil
+		})
+
+	s := newService[*service.UserChatService](t, container, service.NewUserChatService)
+	result, err := s.SendMatchMessage(context.Background(), "user-2", "match-1", "はじめまして！")
+
+	require.NoError(t, err)
+	assert.Equal(t, "match-1", result.Message.MatchID)
+	assert.Equal(t, "user-2", result.Message.SenderID)
+	assert.Equal(t, "はじめまして！", result.Message.Content)
+	assert.NotEmpty(t, result.Message.ID)
+}
+
+// TestUserChatService_SendMessage_NotifiesPartner 相手のユーザーIDを指定する旧エンドポイントでも、
+// マッチングID指定と同じように相手へ新着メッセージを通知する
+func TestUserChatService_SendMessage_NotifiesPartner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container, m := newUserChatTestContainer(t, ctrl)
+
+	m.matching.EXPECT().GetMatchingByUsers("user-2", "user-1").Return(&models.Matching{ID: "match-1", User1ID: "user-1", User2ID: "user-2"}, nil)
+	m.userChat.EXPECT().CreateUserChatMessage(gomock.Any(), "user-2", "user-1", gomock.Any()).Return(nil)
+	m.readCursor.EXPECT().Advance(gomock.Any()).Return(nil)
+	m.user.EXPECT().GetByID("user-2").Return(models.User{ID: "user-2", DisplayName: "花子"}, nil)
+	m.delivery.notification.EXPECT().CreateNotification(gomock.Any(), "user-1", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, userID string, notification models.Notification) error {
+			assert.Equal(t, models.NotificationTypeMessage, notification.Type)
+			assert.Equal(t, "/matches/match-1", notification.DeepLink)
+			assert.Equal(t, "match-1", notification.Payload["matching_id"])
+			return nil
+		})
+
+	s := newService[*service.UserChatService](t, container, service.NewUserChatService)
+	message, err := s.SendMessage(context.Background(), "user-2", "user-1", "こんにちは")
+
+	require.NoError(t, err)
+	assert.Equal(t, "user-2", message.SenderID)
+	assert.Equal(t, "こんにちは", message.Message)
+}
+
+func TestUserChatService_SendMatchMessage_RejectsNonMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container, m := newUserChatTestContainer(t, ctrl)
+
+	m.matching.EXPECT().GetByID("match-1").Return(&models.Matching{ID: "match-1", User1ID: "user-1", User2ID: "user-2"}, nil)
+
+	s := newService[*service.UserChatService](t, container, service.NewUserChatService)
+	_, err := s.SendMatchMessage(context.Background(), "intruder", "match-1", "こんにちは")
+
+	assert.ErrorIs(t, err, service.ErrNotMatchingMember)
+}
+
+func TestUserChatService_GetMatchMessages_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container, m := newUserChatTestContainer(t, ctrl)
+
+	m.matching.EXPECT().GetByID("missing").Return(nil, gorm.ErrRecordNotFound)
+
+	s := newService[*service.UserChatService](t, container, service.NewUserChatService)
+	_, err := s.GetMatchMessages(context.Background(), "user-1", "missing", adapter.ChatPage{Limit: 20})
+
+	assert.ErrorIs(t, err, utils.ErrorRecordNotFound)
+}
+
+func TestUserChatService_GetMatchMessages_PassesCursorThrough(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	container, m := newUserChatTestContainer(t, ctrl)
+
+	base := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	page := adapter.ChatPage{Limit: 2, Before: "m5"}
+
+	m.matching.EXPECT().GetByID("match-1").Return(&models.Matching{ID: "match-1", User1ID: "user-1", User2ID: "user-2"}, nil)
+	m.userChat.EXPECT().GetUserChatMessages(gomock.Any(), "user-2", "user-1", page).Return([]adapter.UserChatMessage{
+		{ID: "m3", SenderID: "user-1", Message: "m3", CreatedAt: base},
+		{ID: "m4", SenderID: "user-2", Message: "m4", CreatedAt: base.Add(time.Minute)},
+	}, "m3", nil)
+	m.readCursor.EXPECT().Get("user-1", models.ChatKindUser, "user-2").
+		Return(&models.ChatReadCursor{UserID: "user-1", ChatKind: models.ChatKindUser, PartnerID: "user-2", LastReadMessageID: "m4"}, nil)
+
+	s := newService[*service.UserChatService](t, container, service.NewUserChatService)
+	result, err := s.GetMatchMessages(context.Background(), "user-2", "match-1", page)
+
+	require.NoError(t, err)
+	require.Len(t, result.Messages, 2)
+	assert.Equal(t, "m3", result.Messages[0].ID)
+	assert.Equal(t, "m4", result.Messages[1].ID)
+	assert.Equal(t, "match-1", result.Messages[0].MatchID)
+	assert.Equal(t, "m3", result.NextCursor)
+	assert.Equal(t, 2, result.Limit)
+	assert.Equal(t, "m4", result.PartnerLastReadMessageID)
+}
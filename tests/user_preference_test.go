@@ -0,0 +1,131 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/requests"
+	"github.com/stretchr/testify/assert"
+)
+
+func intPtr(v int) *int { return &v }
+
+func float64Ptr(v float64) *float64 { return &v }
+
+func TestUser_MutuallyAdmits(t *testing.T) {
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	tokyo := func(u models.User) models.User {
+		u.Latitude, u.Longitude = float64Ptr(35.681236), float64Ptr(139.767125)
+		return u
+	}
+	osaka := func(u models.User) models.User {
+		u.Latitude, u.Longitude = float64Ptr(34.702485), float64Ptr(135.495951)
+		return u
+	}
+
+	tests := []struct {
+		name string
+		a    models.User
+		b    models.User
+		want bool
+	}{
+		{
+			name: "未設定なら従来どおり異性同士",
+			a:    models.User{Gender: "male", BirthDate: now.AddDate(-25, 0, 0)},
+			b:    models.User{Gender: "female", BirthDate: now.AddDate(-25, 0, 0)},
+			want: true,
+		},
+		{
+			name: "未設定の同性同士は対象外",
+			a:    models.User{Gender: "male", BirthDate: now.AddDate(-25, 0, 0)},
+			b:    models.User{Gender: "male", BirthDate: now.AddDate(-25, 0, 0)},
+			want: false,
+		},
+		{
+			name: "同性が恋愛対象同士",
+			a:    models.User{Gender: "female", InterestedInGenders: []string{"female"}, BirthDate: now.AddDate(-25, 0, 0)},
+			b:    models.User{Gender: "female", InterestedInGenders: []string{"female", "other"}, BirthDate: now.AddDate(-30, 0, 0)},
+			want: true,
+		},
+		{
+			name: "片方だけが相手を対象にしている",
+			a:    models.User{Gender: "male", InterestedInGenders: []string{"other"}, BirthDate: now.AddDate(-25, 0, 0)},
+			b:    models.User{Gender: "other", InterestedInGenders: []string{"female"}, BirthDate: now.AddDate(-25, 0, 0)},
+			want: false,
+		},
+		{
+			name: "相手の希望年齢の外",
+			a:    models.User{Gender: "male", BirthDate: now.AddDate(-40, 0, 0)},
+			b:    models.User{Gender: "female", PreferredMaxAge: 35, BirthDate: now.AddDate(-30, 0, 0)},
+			want: false,
+		},
+		{
+			name: "距離の上限内",
+			a:    tokyo(models.User{Gender: "male", MaxDistanceKm: intPtr(30), BirthDate: now.AddDate(-25, 0, 0)}),
+			b:    tokyo(models.User{Gender: "female", BirthDate: now.AddDate(-25, 0, 0)}),
+			want: true,
+		},
+		{
+			name: "距離の上限を超える",
+			a:    tokyo(models.User{Gender: "male", BirthDate: now.AddDate(-25, 0, 0)}),
+			b:    osaka(models.User{Gender: "female", MaxDistanceKm: intPtr(100), BirthDate: now.AddDate(-25, 0, 0)}),
+			want: false,
+		},
+		{
+			name: "相手が位置情報未登録なら距離は判定しない",
+			a:    tokyo(models.User{Gender: "male", MaxDistanceKm: intPtr(10), BirthDate: now.AddDate(-25, 0, 0)}),
+			b:    models.User{Gender: "female", BirthDate: now.AddDate(-25, 0, 0)},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, models.MutuallyAdmits(&tt.a, &tt.b, now))
+			assert.Equal(t, tt.want, models.MutuallyAdmits(&tt.b, &tt.a, now))
+		})
+	}
+}
+
+func TestCreateUserRequest_Validate(t *testing.T) {
+	valid := func() requests.CreateUserRequest {
+		return requests.CreateUserRequest{DisplayName: "山田太郎", Gender: "male"}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(r *requests.CreateUserRequest)
+		wantErr bool
+	}{
+		{name: "最小限", modify: func(r *requests.CreateUserRequest) {}},
+		{name: "全項目", modify: func(r *requests.CreateUserRequest) {
+			r.InterestedInGenders = []string{"female", "other"}
+			r.PreferredMinAge, r.PreferredMaxAge = 20, 35
+			r.MaxDistanceKm = intPtr(30)
+			r.Latitude, r.Longitude = float64Ptr(35.68), float64Ptr(139.76)
+		}},
+		{name: "不明な性別", modify: func(r *requests.CreateUserRequest) { r.Gender = "unknown" }, wantErr: true},
+		{name: "不明な恋愛対象", modify: func(r *requests.CreateUserRequest) { r.InterestedInGenders = []string{"men"} }, wantErr: true},
+		{name: "18歳未満", modify: func(r *requests.CreateUserRequest) { r.PreferredMinAge = 16 }, wantErr: true},
+		{name: "年齢の範囲が逆", modify: func(r *requests.CreateUserRequest) { r.PreferredMinAge, r.PreferredMaxAge = 40, 30 }, wantErr: true},
+		{name: "位置情報なしで距離指定", modify: func(r *requests.CreateUserRequest) { r.MaxDistanceKm = intPtr(30) }, wantErr: true},
+		{name: "緯度だけ指定", modify: func(r *requests.CreateUserRequest) { r.Latitude = float64Ptr(35.68) }, wantErr: true},
+		{name: "緯度が範囲外", modify: func(r *requests.CreateUserRequest) {
+			r.Latitude, r.Longitude = float64Ptr(100), float64Ptr(139.76)
+		}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := valid()
+			tt.modify(&r)
+			err := r.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -15,7 +15,6 @@ import (
 	"github.com/hackathon-20260110/api/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"go.uber.org/dig"
 	"go.uber.org/mock/gomock"
 )
 
@@ -107,13 +106,7 @@ func TestUserService_GetUserByID_Success(t *testing.T) {
 		Return(expectedUser, nil).
 		Times(1)
 
-	container := dig.New()
-	err := container.Provide(func() adapter.UserAdapter {
-		return mockUserAdapter
-	})
-	require.NoError(t, err)
-
-	userService := service.NewUserService(container)
+	userService := service.NewUserService(mockUserAdapter, nil)
 	result, err := userService.GetUserByID("user-123")
 
 	require.NoError(t, err)
@@ -139,13 +132,7 @@ func TestUserService_GetUserByID_AdapterError(t *testing.T) {
 		Return(models.User{}, adapterErr).
 		Times(1)
 
-	container := dig.New()
-	err := container.Provide(func() adapter.UserAdapter {
-		return mockUserAdapter
-	})
-	require.NoError(t, err)
-
-	userService := service.NewUserService(container)
+	userService := service.NewUserService(mockUserAdapter, nil)
 	result, err := userService.GetUserByID("nonexistent-user")
 
 	require.Error(t, err)
@@ -153,16 +140,6 @@ func TestUserService_GetUserByID_AdapterError(t *testing.T) {
 	assert.Equal(t, response.User{}, result)
 }
 
-func TestUserService_GetUserByID_DIResolutionError(t *testing.T) {
-	container := dig.New()
-
-	userService := service.NewUserService(container)
-	result, err := userService.GetUserByID("any-id")
-
-	require.Error(t, err)
-	assert.Equal(t, response.User{}, result)
-}
-
 func TestUserService_CreateUser_Success_PNG(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -209,11 +186,7 @@ func TestUserService_CreateUser_Success_PNG(t *testing.T) {
 		}).
 		Times(1)
 
-	container := dig.New()
-	require.NoError(t, container.Provide(func() adapter.UserAdapter { return mockUserAdapter }))
-	require.NoError(t, container.Provide(func() adapter.R2Adapter { return mockR2Adapter }))
-
-	userService := service.NewUserService(container)
+	userService := service.NewUserService(mockUserAdapter, mockR2Adapter)
 	result, err := userService.UpsertUser(userID, request)
 
 	require.NoError(t, err)
@@ -263,11 +236,7 @@ func TestUserService_CreateUser_Success_JPEG(t *testing.T) {
 		}).
 		Times(1)
 
-	container := dig.New()
-	require.NoError(t, container.Provide(func() adapter.UserAdapter { return mockUserAdapter }))
-	require.NoError(t, container.Provide(func() adapter.R2Adapter { return mockR2Adapter }))
-
-	userService := service.NewUserService(container)
+	userService := service.NewUserService(mockUserAdapter, mockR2Adapter)
 	result, err := userService.UpsertUser(userID, request)
 
 	require.NoError(t, err)
@@ -313,11 +282,7 @@ func TestUserService_CreateUser_Success_HEIC(t *testing.T) {
 		}).
 		Times(1)
 
-	container := dig.New()
-	require.NoError(t, container.Provide(func() adapter.UserAdapter { return mockUserAdapter }))
-	require.NoError(t, container.Provide(func() adapter.R2Adapter { return mockR2Adapter }))
-
-	userService := service.NewUserService(container)
+	userService := service.NewUserService(mockUserAdapter, mockR2Adapter)
 	result, err := userService.UpsertUser(userID, request)
 
 	require.NoError(t, err)
@@ -332,11 +297,7 @@ func TestUserService_CreateUser_InvalidDataURI(t *testing.T) {
 	mockUserAdapter := mock.NewMockUserAdapter(ctrl)
 	mockR2Adapter := mock.NewMockR2Adapter(ctrl)
 
-	container := dig.New()
-	require.NoError(t, container.Provide(func() adapter.UserAdapter { return mockUserAdapter }))
-	require.NoError(t, container.Provide(func() adapter.R2Adapter { return mockR2Adapter }))
-
-	userService := service.NewUserService(container)
+	userService := service.NewUserService(mockUserAdapter, mockR2Adapter)
 
 	testCases := []struct {
 		name               string
@@ -380,11 +341,7 @@ func TestUserService_CreateUser_R2UploadError(t *testing.T) {
 		Return("", uploadErr).
 		Times(1)
 
-	container := dig.New()
-	require.NoError(t, container.Provide(func() adapter.UserAdapter { return mockUserAdapter }))
-	require.NoError(t, container.Provide(func() adapter.R2Adapter { return mockR2Adapter }))
-
-	userService := service.NewUserService(container)
+	userService := service.NewUserService(mockUserAdapter, mockR2Adapter)
 	result, err := userService.UpsertUser("user-id", requests.CreateUserRequest{
 		DisplayName:        "テスト",
 		ProfileImageBase64: pngDataURI,
@@ -420,11 +377,7 @@ func TestUserService_CreateUser_UserAdapterError(t *testing.T) {
 		Return(models.User{}, createErr).
 		Times(1)
 
-	container := dig.New()
-	require.NoError(t, container.Provide(func() adapter.UserAdapter { return mockUserAdapter }))
-	require.NoError(t, container.Provide(func() adapter.R2Adapter { return mockR2Adapter }))
-
-	userService := service.NewUserService(container)
+	userService := service.NewUserService(mockUserAdapter, mockR2Adapter)
 	result, err := userService.UpsertUser("user-id", requests.CreateUserRequest{
 		DisplayName:        "テスト",
 		ProfileImageBase64: pngDataURI,
@@ -435,12 +388,64 @@ func TestUserService_CreateUser_UserAdapterError(t *testing.T) {
 	assert.Equal(t, response.User{}, result)
 }
 
-func TestUserService_CreateUser_DIResolutionError(t *testing.T) {
-	container := dig.New()
+func TestUserService_UpsertUser_UpdateWithoutPreferencesSavesEmptyList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
-	userService := service.NewUserService(container)
-	result, err := userService.UpsertUser("user-id", requests.CreateUserRequest{})
+	mockUserAdapter := mock.NewMockUserAdapter(ctrl)
+	mockR2Adapter := mock.NewMockR2Adapter(ctrl)
 
-	require.Error(t, err)
-	assert.Equal(t, response.User{}, result)
+	mockR2Adapter.EXPECT().
+		UploadImage(minimalPNGBytes, "user-id.png", "image/png").
+		Return("https://cdn.example.com/image.png", nil)
+	mockUserAdapter.EXPECT().
+		GetByID("user-id").
+		Return(models.User{ID: "user-id"}, nil)
+	mockUserAdapter.EXPECT().
+		Update(gomock.Any()).
+		DoAndReturn(func(user models.User) (models.User, error) {
+			// nilだとjsonbの列に空文字が書き込まれる
+			assert.NotNil(t, user.InterestedInGenders)
+			assert.Empty(t, user.InterestedInGenders)
+			return user, nil
+		})
+
+	userService := service.NewUserService(mockUserAdapter, mockR2Adapter)
+	_, err := userService.UpsertUser("user-id", requests.CreateUserRequest{
+		DisplayName:        "テスト",
+		ProfileImageBase64: buildDataURI("image/png", minimalPNGBytes),
+	})
+	require.NoError(t, err)
+}
+
+// TestUserService_UpsertUser_UpdatesWithoutPreferences 恋愛対象を省略して登録し直しても、
+// jsonbのNOT NULL列に空文字を書き込まず更新できることを実際のPostgresで確かめる。TEST_DATABASE_URL が必要（CIでは必須）
+func TestUserService_UpsertUser_UpdatesWithoutPreferences(t *testing.T) {
+	db := openTestDatabase(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockR2Adapter := mock.NewMockR2Adapter(ctrl)
+	mockR2Adapter.EXPECT().UploadImage(gomock.Any(), gomock.Any(), gomock.Any()).Return("https://cdn.example.com/image.png", nil).Times(2)
+
+	userID := utils.GenerateULID()
+	userService := service.NewUserService(adapter.NewUserAdapter(db), mockR2Adapter)
+	request := requests.CreateUserRequest{
+		DisplayName:        "テスト",
+		Gender:             models.GenderFemale,
+		BirthDate:          time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+		ProfileImageBase64: buildDataURI("image/png", minimalPNGBytes),
+	}
+	_, err := userService.UpsertUser(userID, request)
+	require.NoError(t, err)
+
+	request.Bio = "更新しました"
+	result, err := userService.UpsertUser(userID, request)
+	require.NoError(t, err)
+	assert.Equal(t, "更新しました", result.Bio)
+
+	var saved models.User
+	require.NoError(t, db.First(&saved, "id = ?", userID).Error)
+	assert.Empty(t, saved.InterestedInGenders)
+	assert.Equal(t, []string{models.GenderMale}, saved.InterestedGenders())
 }
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"log"
 	"time"
 
+	"github.com/hackathon-20260110/api/config"
 	"github.com/hackathon-20260110/api/driver"
 	"github.com/hackathon-20260110/api/models"
 	"github.com/hackathon-20260110/api/utils"
@@ -17,7 +19,14 @@ var uid5 = utils.GenerateULID()
 var uid6 = utils.GenerateULID()
 
 func main() {
-	db := driver.NewPsql()
+	cfg, err := config.LoadDatabase()
+	if err != nil {
+		log.Fatal(err)
+	}
+	db, err := driver.NewPsql(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	createUsers(db)
 	createUserInfos(db)
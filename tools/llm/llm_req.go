@@ -16,17 +16,12 @@ func main() {
 		log.Fatalf("failed to create genai client: %v", err)
 	}
 
-	llmAdapter := adapter.NewLLMAdapter(client)
-	resp, err := llmAdapter.CreateChatCompletion([]*genai.Content{
-		{
-			Role: "user",
-			Parts: []*genai.Part{
-				{
-					Text: "Hello, how are you?",
-				},
-			},
-		},
-	}, adapter.LLM_MODEL_TYPE_GEMINI2_5_FLASH)
+	llmAdapter := adapter.NewGeminiLLMAdapter(client)
+	resp, err := llmAdapter.CreateChatCompletion(ctx, adapter.NewLLMPromptRequest(
+		adapter.LLM_MODEL_TYPE_GEMINI2_5_FLASH,
+		adapter.LLMTaskGeneral,
+		"Hello, how are you?",
+	))
 	if err != nil {
 		log.Fatalf("CreateChatCompletion error: %v", err)
 	}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/config"
+)
+
+// AUTH_PROVIDER=local のAPIサーバーで使える開発用トークンを発行する
+// 例: go run tools/local_token/local_token.go -uid user-1 -role admin
+func main() {
+	uid := flag.String("uid", "", "トークンのユーザーID（必須）")
+	role := flag.String("role", "", "トークンのroleクレーム（運営用APIを呼ぶならadmin）")
+	ttl := flag.Duration("ttl", 0, "有効期限（省略時はLOCAL_AUTH_TOKEN_TTL）")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if cfg.Auth.Provider != config.AuthProviderLocal {
+		log.Fatalf("local tokens are only accepted with AUTH_PROVIDER=local (AUTH_PROVIDER=%s)", cfg.Auth.Provider)
+	}
+	if *ttl > 0 {
+		cfg.Auth.Local.TokenTTL = *ttl
+	}
+
+	issuer, err := adapter.NewLocalTokenAuth(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	token, err := issuer.IssueIDTokenWithRole(context.Background(), *uid, *role)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(token.IDToken)
+}
@@ -1,19 +1,86 @@
 package main
 
 import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/hackathon-20260110/api/config"
 	"github.com/hackathon-20260110/api/driver"
-	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/migrations"
 )
 
+const usage = `usage: migrate <command>
+
+commands:
+  up          未適用のマイグレーションをすべて適用する
+  down [n]    適用済みのマイグレーションを新しい順にn個（デフォルト1）取り消す
+  status      マイグレーションごとの適用状況を表示する`
+
 func main() {
-	db := driver.NewPsql()
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	cfg, err := config.LoadDatabase()
+	if err != nil {
+		log.Fatal(err)
+	}
+	db, err := driver.NewPsql(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	runner, err := migrations.NewRunner(db)
+	if err != nil {
+		log.Fatalf("failed to load migrations: %v", err)
+	}
 
-	db.AutoMigrate(&models.Avatar{})
-	db.AutoMigrate(&models.UserInfo{})
-	db.AutoMigrate(&models.Mission{})
-	db.AutoMigrate(&models.MissionUnlock{})
-	db.AutoMigrate(&models.UserAvatarRelation{})
-	db.AutoMigrate(&models.Matching{})
-	db.AutoMigrate(&models.DiagnosisHistory{})
-	db.AutoMigrate(&models.User{})
+	switch os.Args[1] {
+	case "up":
+		applied, err := runner.Up()
+		for _, migration := range applied {
+			fmt.Printf("applied %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			log.Fatalf("failed to migrate up: %v", err)
+		}
+		if len(applied) == 0 {
+			fmt.Println("no pending migrations")
+		}
+	case "down":
+		steps := 1
+		if len(os.Args) > 2 {
+			steps, err = strconv.Atoi(os.Args[2])
+			if err != nil || steps < 1 {
+				log.Fatalf("invalid number of steps: %q", os.Args[2])
+			}
+		}
+		reverted, err := runner.Down(steps)
+		for _, migration := range reverted {
+			fmt.Printf("reverted %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			log.Fatalf("failed to migrate down: %v", err)
+		}
+		if len(reverted) == 0 {
+			fmt.Println("no applied migrations")
+		}
+	case "status":
+		statuses, err := runner.Status()
+		if err != nil {
+			log.Fatalf("failed to get migration status: %v", err)
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = "applied at " + status.AppliedAt.Format("2006-01-02 15:04:05 MST")
+			}
+			fmt.Printf("%04d_%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
 }
@@ -0,0 +1,156 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/models"
+)
+
+const (
+	defaultPollInterval  = time.Second
+	defaultLeaseDuration = 2 * time.Minute
+	retryBaseDelay       = 5 * time.Second
+	retryMaxDelay        = 5 * time.Minute
+)
+
+// Handler ジョブ1件を処理する。errorを返すとバックオフ後に再実行される
+type Handler func(ctx context.Context, job models.Job) error
+
+// Pool JobAdapterからジョブをリースして、種類ごとのHandlerで処理するワーカー群
+type Pool struct {
+	jobAdapter    adapter.JobAdapter
+	workers       int
+	handlers      map[models.JobType]Handler
+	pollInterval  time.Duration
+	leaseDuration time.Duration
+	wg            sync.WaitGroup
+}
+
+func NewPool(jobAdapter adapter.JobAdapter, workers int) *Pool {
+	if workers <= 0 {
+		workers = 1
+	}
+	return &Pool{
+		jobAdapter:    jobAdapter,
+		workers:       workers,
+		handlers:      make(map[models.JobType]Handler),
+		pollInterval:  defaultPollInterval,
+		leaseDuration: defaultLeaseDuration,
+	}
+}
+
+func (p *Pool) Register(jobType models.JobType, handler Handler) {
+	p.handlers[jobType] = handler
+}
+
+// Start ワーカーを起動してすぐに戻る。ctxがキャンセルされると処理中のジョブを終えてから止まる
+func (p *Pool) Start(ctx context.Context) {
+	hostname, _ := os.Hostname()
+	for i := 0; i < p.workers; i++ {
+		workerID := fmt.Sprintf("%s-%d-%d", hostname, os.Getpid(), i)
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			p.run(ctx, workerID)
+		}()
+	}
+}
+
+// Wait Startで起動したワーカーがすべて止まるまで待つ
+func (p *Pool) Wait() {
+	p.wg.Wait()
+}
+
+func (p *Pool) run(ctx context.Context, workerID string) {
+	for {
+		processed, err := p.RunOnce(ctx, workerID)
+		if err != nil {
+			log.Printf("Job worker %s failed to lease a job: %v", workerID, err)
+		}
+		if processed {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(p.pollInterval):
+		}
+	}
+}
+
+// RunOnce ジョブを1件リースして処理する。処理したジョブが無ければfalseを返す
+func (p *Pool) RunOnce(ctx context.Context, workerID string) (bool, error) {
+	if ctx.Err() != nil {
+		return false, nil
+	}
+
+	jobTypes := make([]models.JobType, 0, len(p.handlers))
+	for jobType := range p.handlers {
+		jobTypes = append(jobTypes, jobType)
+	}
+
+	job, err := p.jobAdapter.Lease(workerID, jobTypes, p.leaseDuration)
+	if err != nil {
+		return false, err
+	}
+	if job == nil {
+		return false, nil
+	}
+
+	if err := p.handle(ctx, *job); err != nil {
+		var retryAt *time.Time
+		if job.Attempts < job.MaxAttempts {
+			next := time.Now().Add(RetryDelay(job.Attempts))
+			retryAt = &next
+			log.Printf("Job %s (%s) failed on attempt %d, retrying at %s: %v", job.ID, job.Type, job.Attempts, next.Format(time.RFC3339), err)
+		} else {
+			log.Printf("Job %s (%s) failed on attempt %d, moving to dead letter: %v", job.ID, job.Type, job.Attempts, err)
+		}
+		if err := p.jobAdapter.Fail(job.ID, workerID, err.Error(), retryAt); err != nil {
+			log.Printf("Error marking job %s as failed: %v", job.ID, err)
+		}
+		return true, nil
+	}
+
+	if err := p.jobAdapter.Complete(job.ID, workerID); err != nil {
+		log.Printf("Error marking job %s as succeeded: %v", job.ID, err)
+	}
+	return true, nil
+}
+
+// handle リース期限内に終わるようタイムアウトを付け、panicもエラーとして扱う
+func (p *Pool) handle(ctx context.Context, job models.Job) (err error) {
+	handler, ok := p.handlers[job.Type]
+	if !ok {
+		return fmt.Errorf("no handler registered for job type %q", job.Type)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.leaseDuration)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job handler panicked: %v", r)
+		}
+	}()
+	return handler(ctx, job)
+}
+
+// RetryDelay attempts回目が失敗した後の待ち時間。5秒から倍々にし、5分で頭打ちにする
+func RetryDelay(attempts int) time.Duration {
+	delay := retryBaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= retryMaxDelay {
+			return retryMaxDelay
+		}
+	}
+	return delay
+}
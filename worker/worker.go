@@ -0,0 +1,43 @@
+package worker
+
+import (
+	"context"
+
+	"github.com/hackathon-20260110/api/adapter"
+	"github.com/hackathon-20260110/api/config"
+	"github.com/hackathon-20260110/api/models"
+	"github.com/hackathon-20260110/api/service"
+	"go.uber.org/dig"
+)
+
+// Start ジョブの種類ごとのハンドラーを登録し、JOB_WORKER_COUNT 個のワーカーを起動する
+func Start(ctx context.Context, container *dig.Container) (*Pool, error) {
+	var cfg *config.Config
+	var jobAdapter adapter.JobAdapter
+	var onboardingService *service.OnboardingService
+	var notificationService *service.NotificationService
+	var avatarChatService *service.AvatarChatService
+	if err := container.Invoke(func(
+		c *config.Config,
+		ja adapter.JobAdapter,
+		obs *service.OnboardingService,
+		ns *service.NotificationService,
+		acs *service.AvatarChatService,
+	) error {
+		cfg = c
+		jobAdapter = ja
+		onboardingService = obs
+		notificationService = ns
+		avatarChatService = acs
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	pool := NewPool(jobAdapter, cfg.JobWorkerCount)
+	pool.Register(models.JobTypeOnboardingReply, onboardingService.ProcessOnboardingReply)
+	pool.Register(models.JobTypeNotificationDigest, notificationService.SendNotificationDigest)
+	pool.Register(models.JobTypeAvatarChatSummary, avatarChatService.SummarizeAvatarChat)
+	pool.Start(ctx)
+	return pool, nil
+}